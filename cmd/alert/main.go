@@ -2,16 +2,29 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"runtime"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/tiongMax/gostocks/internal/alert"
+	"github.com/tiongMax/gostocks/internal/bus"
+	"github.com/tiongMax/gostocks/internal/history"
+	"github.com/tiongMax/gostocks/internal/lag"
+	"github.com/tiongMax/gostocks/internal/portfolio"
 	pb "github.com/tiongMax/gostocks/proto/alert"
+	pbt "github.com/tiongMax/gostocks/proto/trading"
+	pbw "github.com/tiongMax/gostocks/proto/watchlist"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -37,13 +50,92 @@ func main() {
 	if kafkaBrokers == "" {
 		kafkaBrokers = "localhost:9092"
 	}
-	brokers := strings.Split(kafkaBrokers, ",")
+	kafka := bus.NewKafka(strings.Split(kafkaBrokers, ","))
 
 	kafkaTopic := os.Getenv("KAFKA_TOPIC")
 	if kafkaTopic == "" {
 		kafkaTopic = "market_ticks"
 	}
 
+	// Latest prices, for valuing portfolios watched by portfolio alerts
+	redisAddr := os.Getenv("REDIS_ADDR")
+	if redisAddr == "" {
+		redisAddr = "localhost:6379"
+	}
+
+	// Trading Service, which places the paper orders of alert actions
+	tradingAddr := os.Getenv("TRADING_SERVICE_ADDR")
+	if tradingAddr == "" {
+		tradingAddr = "localhost:50053"
+	}
+
+	// How often order actions are retried while the Trading Service is down
+	actionRetryInterval := 30 * time.Second
+	if v := os.Getenv("ALERT_ACTION_RETRY_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			slog.Error("Invalid ALERT_ACTION_RETRY_INTERVAL", "value", v)
+			os.Exit(1)
+		}
+		actionRetryInterval = d
+	}
+
+	// Alert expiry sweep interval
+	sweepInterval := 30 * time.Second
+	if v := os.Getenv("ALERT_SWEEP_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			slog.Error("Invalid ALERT_SWEEP_INTERVAL", "value", v)
+			os.Exit(1)
+		}
+		sweepInterval = d
+	}
+
+	// Consumer lag past which ticks are conflated, and where it is served
+	thresholds, err := lag.ParseThresholds(os.Getenv("LAG_OFFSET_THRESHOLD"), os.Getenv("LAG_TIME_THRESHOLD"), lag.DefaultThresholds)
+	if err != nil {
+		slog.Error("Invalid lag thresholds", "error", err)
+		os.Exit(1)
+	}
+	debugPort := os.Getenv("DEBUG_PORT")
+	if debugPort == "" {
+		debugPort = "6062"
+	}
+
+	// Ticks of different symbols are processed concurrently within a partition
+	workers := runtime.GOMAXPROCS(0)
+	if v := os.Getenv("CONSUMER_WORKERS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			slog.Error("Invalid CONSUMER_WORKERS", "value", v)
+			os.Exit(1)
+		}
+		workers = n
+	}
+
+	// Per-user cap on active alerts (0 = unlimited)
+	maxActiveAlerts := 100
+	if v := os.Getenv("MAX_ACTIVE_ALERTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			slog.Error("Invalid MAX_ACTIVE_ALERTS", "value", v)
+			os.Exit(1)
+		}
+		maxActiveAlerts = n
+	}
+
+	// Optional holiday calendar for windows that skip holidays
+	var calendar *alert.HolidayCalendar
+	if path := os.Getenv("HOLIDAY_CALENDAR"); path != "" {
+		cal, err := alert.LoadHolidayCalendar(path)
+		if err != nil {
+			slog.Error("Failed to load holiday calendar", "path", path, "error", err)
+			os.Exit(1)
+		}
+		calendar = cal
+		slog.Info("Loaded holiday calendar", "path", path, "dates", cal.Len())
+	}
+
 	// 4. Connect to Database
 	slog.Info("Connecting to database...")
 	store, err := alert.NewStore(connStr)
@@ -61,9 +153,47 @@ func main() {
 	}
 	slog.Info("Schema migrated successfully")
 
+	// Portfolio alerts value the portfolios kept by the Portfolio Service
+	portfolioStore, err := portfolio.NewStore(connStr)
+	if err != nil {
+		slog.Error("Failed to connect to portfolio store", "error", err)
+		os.Exit(1)
+	}
+	defer portfolioStore.Close()
+	if err := portfolioStore.AutoMigrate(); err != nil {
+		slog.Error("Failed to migrate portfolio store", "error", err)
+		os.Exit(1)
+	}
+
+	quotes, err := portfolio.NewRedisQuotes(redisAddr)
+	if err != nil {
+		slog.Error("Failed to connect to Redis", "error", err)
+		os.Exit(1)
+	}
+	defer quotes.Close()
+	portfolios := alert.NewPortfolioValuer(portfolioStore, quotes)
+
+	// Order actions connect lazily, so the Trading Service may start later
+	tradingConn, err := grpc.NewClient(tradingAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		slog.Error("Failed to create Trading Service client", "addr", tradingAddr, "error", err)
+		os.Exit(1)
+	}
+	defer tradingConn.Close()
+
 	// 6. Start Kafka Consumer (Trigger Logic)
+	dlq, err := kafka.NewPublisher()
+	if err != nil {
+		slog.Error("Failed to connect to Kafka", "error", err)
+		os.Exit(1)
+	}
+	defer dlq.Close()
+
 	ctx, cancel := context.WithCancel(context.Background())
-	consumer := alert.NewConsumer(brokers, kafkaTopic, store)
+	broker := alert.NewBroker()
+	notifiers := []alert.Notifier{alert.NewLogNotifier(), broker}
+	actions := alert.NewActionRunner(store, pbt.NewTradingServiceClient(tradingConn), actionRetryInterval, notifiers...)
+	consumer := alert.NewConsumer(kafka, dlq, kafkaTopic, store, calendar, portfolios, actions, thresholds, workers, notifiers...)
 
 	go func() {
 		slog.Info("Starting Alert Consumer")
@@ -73,10 +203,39 @@ func main() {
 		}
 	}()
 
+	// Expire alerts in the background
+	sweeper := alert.NewSweeper(store, sweepInterval)
+	go func() {
+		slog.Info("Starting Alert Expiry Sweeper", "interval", sweepInterval)
+		sweeper.Start(ctx)
+	}()
+
+	// Retry order actions left pending
+	go func() {
+		slog.Info("Starting Alert Action Runner", "retry_interval", actionRetryInterval)
+		actions.Start(ctx)
+	}()
+
+	// Backtests replay the tick and candle history written by the processor
+	historyReader, err := history.NewReader(connStr)
+	if err != nil {
+		slog.Error("Failed to connect to history store", "error", err)
+		os.Exit(1)
+	}
+	defer historyReader.Close()
+	backtests := alert.NewBacktester(historyReader, calendar)
+
 	// 7. Create gRPC Server
-	grpcServer := grpc.NewServer()
-	alertServer := alert.NewServer(store)
+	grpcServer := grpc.NewServer(
+		// Detect clients that vanished from long-lived WatchAlerts streams
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    30 * time.Second,
+			Timeout: 10 * time.Second,
+		}),
+	)
+	alertServer := alert.NewServer(store, portfolios, actions, backtests, broker, maxActiveAlerts)
 	pb.RegisterAlertServiceServer(grpcServer, alertServer)
+	pbw.RegisterWatchlistServiceServer(grpcServer, alert.NewWatchlistServer(store))
 
 	// Enable reflection for tools like grpcurl
 	reflection.Register(grpcServer)
@@ -97,6 +256,15 @@ func main() {
 		}
 	}()
 
+	// Serve consumer lag
+	debug := lag.NewDebugServer(":"+debugPort, consumer.Lag())
+	go func() {
+		slog.Info("Debug server listening", "port", debugPort)
+		if err := debug.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Debug server failed", "error", err)
+		}
+	}()
+
 	slog.Info("Alert Service is fully running (gRPC + Kafka Consumer)")
 
 	// 10. Wait for shutdown signal
@@ -113,5 +281,6 @@ func main() {
 	// Graceful shutdown
 	cancel()
 	grpcServer.GracefulStop()
+	debug.Close()
 	slog.Info("Alert Service stopped")
 }
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	pb "github.com/tiongMax/gostocks/proto/alert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const usage = `Backtest a price alert against stored ticks or candles.
+
+Usage:
+  alertbacktest -symbol AAPL -condition BELOW -target 180 -from 2026-03-02 [-to 2026-03-07]
+                [-interval tick|1m|5m|15m|30m|1h|4h|1d] [-market-hours] [-json]
+
+Times are RFC 3339, a date (midnight UTC) or Unix milliseconds; -to defaults
+to now.
+`
+
+func main() {
+	// 1. Load Environment Variables
+	godotenv.Load(".env")
+
+	addr := os.Getenv("ALERT_SERVICE_ADDR")
+	if addr == "" {
+		addr = "localhost:50051"
+	}
+
+	// 2. Parse flags
+	fs := flag.NewFlagSet("alertbacktest", flag.ExitOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	symbol := fs.String("symbol", "", "symbol, e.g. AAPL")
+	condition := fs.String("condition", "", "ABOVE or BELOW")
+	target := fs.Float64("target", 0, "target price")
+	fromFlag := fs.String("from", "", "start of the range (inclusive)")
+	toFlag := fs.String("to", "", "end of the range (exclusive)")
+	interval := fs.String("interval", "tick", "tick, or a candle interval")
+	marketHours := fs.Bool("market-hours", false, "only evaluate regular US market hours")
+	asJSON := fs.Bool("json", false, "print the response as JSON")
+	fs.Parse(os.Args[1:])
+
+	if *symbol == "" || *target <= 0 || *fromFlag == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+	cond, ok := pb.AlertCondition_value[strings.ToUpper(*condition)]
+	if !ok || cond == 0 {
+		fail("-condition must be ABOVE or BELOW")
+	}
+	from, err := parseTime(*fromFlag)
+	if err != nil {
+		fail("invalid -from: %v", err)
+	}
+	var to time.Time
+	if *toFlag != "" {
+		if to, err = parseTime(*toFlag); err != nil {
+			fail("invalid -to: %v", err)
+		}
+	}
+
+	// 3. Call the Alert Service
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		fail("failed to create Alert Service client: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	req := &pb.BacktestAlertRequest{
+		Symbol:          *symbol,
+		TargetPrice:     *target,
+		Condition:       pb.AlertCondition(cond),
+		MarketHoursOnly: *marketHours,
+		From:            from.UnixMilli(),
+		Interval:        *interval,
+	}
+	if !to.IsZero() {
+		req.To = to.UnixMilli()
+	}
+	resp, err := pb.NewAlertServiceClient(conn).BacktestAlert(ctx, req)
+	if err != nil {
+		fail("%s", status.Convert(err).Message())
+	}
+
+	// 4. Print the triggers and summary
+	if *asJSON {
+		out, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(resp)
+		if err != nil {
+			fail("%v", err)
+		}
+		fmt.Println(string(out))
+		return
+	}
+
+	for _, t := range resp.Triggers {
+		fmt.Printf("%s\t%.4f\n", time.UnixMilli(t.Timestamp).UTC().Format(time.RFC3339Nano), t.Price)
+	}
+	s := resp.Summary
+	unit := "ticks"
+	if *interval != "tick" {
+		unit = *interval + " candles"
+	}
+	fmt.Printf("\n%s %s %.2f: %d triggers in %d %s (%d in window, %d meeting the condition)\n",
+		strings.ToUpper(*symbol), pb.AlertCondition(cond), *target, s.TriggerCount, s.Points, unit, s.PointsInWindow, s.PointsMatched)
+	if s.Points > 0 {
+		fmt.Printf("Price range %.4f - %.4f\n", s.MinPrice, s.MaxPrice)
+	}
+	if s.Truncated {
+		fmt.Printf("Stopped at the point limit; continue with -from %d\n", s.EvaluatedTo+1)
+	}
+}
+
+// parseTime accepts RFC 3339, a date or Unix milliseconds.
+func parseTime(v string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "alertbacktest: "+format+"\n", args...)
+	os.Exit(1)
+}
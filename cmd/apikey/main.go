@@ -0,0 +1,142 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/tiongMax/gostocks/internal/alert"
+	"github.com/tiongMax/gostocks/internal/auth"
+)
+
+const usage = `Manage gateway API keys.
+
+Usage:
+  apikey create -user <username> [-name <label>] [-scopes admin]
+  apikey list -user <username>
+  apikey revoke -id <key id>
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	// 1. Load Environment Variables
+	godotenv.Load(".env")
+
+	connStr := os.Getenv("DATABASE_URL")
+	if connStr == "" {
+		connStr = "user=user password=password dbname=gostocks sslmode=disable host=127.0.0.1 port=5433"
+	}
+
+	// 2. Connect to Postgres (users and keys)
+	store, err := alert.NewStore(connStr)
+	if err != nil {
+		fail("failed to connect to database: %v", err)
+	}
+	defer store.Close()
+	if err := store.AutoMigrate(); err != nil {
+		fail("%v", err)
+	}
+
+	keys, err := auth.NewKeyStore(connStr)
+	if err != nil {
+		fail("failed to connect to database: %v", err)
+	}
+	defer keys.Close()
+	if err := keys.AutoMigrate(); err != nil {
+		fail("%v", err)
+	}
+
+	// 3. Run the command
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	username := fs.String("user", "", "username")
+	name := fs.String("name", "", "label for the key")
+	scopes := fs.String("scopes", "", "comma-separated scopes, e.g. admin")
+	id := fs.Int("id", 0, "key ID")
+	fs.Parse(os.Args[2:])
+
+	switch os.Args[1] {
+	case "create":
+		userID := lookupUser(store, *username, true)
+		raw, key, err := keys.CreateKey(userID, *name, splitScopes(*scopes))
+		if err != nil {
+			fail("%v", err)
+		}
+		fmt.Printf("Created key %d for %s (user %d)\n", key.ID, *username, userID)
+		fmt.Printf("%s\n", raw)
+		fmt.Println("Store it now; it cannot be shown again.")
+
+	case "list":
+		userID := lookupUser(store, *username, false)
+		list, err := keys.ListKeys(userID)
+		if err != nil {
+			fail("%v", err)
+		}
+		for _, k := range list {
+			state := "active"
+			if k.RevokedAt != nil {
+				state = "revoked " + k.RevokedAt.Format("2006-01-02")
+			}
+			fmt.Printf("%d\t%s…\t%s\t%s\t%s\n", k.ID, k.Prefix, k.Name, k.Scopes, state)
+		}
+
+	case "revoke":
+		if *id <= 0 {
+			fail("-id is required")
+		}
+		ok, err := keys.RevokeKey(*id)
+		if err != nil {
+			fail("%v", err)
+		}
+		if !ok {
+			fail("no active key with ID %d", *id)
+		}
+		fmt.Printf("Revoked key %d\n", *id)
+
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// lookupUser returns the ID of a user, creating the user if create is set.
+func lookupUser(store *alert.Store, username string, create bool) int {
+	if username == "" {
+		fail("-user is required")
+	}
+	user, err := store.GetUserByUsername(username)
+	if err != nil {
+		fail("%v", err)
+	}
+	if user != nil {
+		return user.ID
+	}
+	if !create {
+		fail("unknown user %q", username)
+	}
+	id, err := store.CreateUser(username)
+	if err != nil {
+		fail("%v", err)
+	}
+	return id
+}
+
+func splitScopes(v string) []string {
+	var scopes []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "apikey: "+format+"\n", args...)
+	os.Exit(1)
+}
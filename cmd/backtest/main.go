@@ -0,0 +1,425 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/tiongMax/gostocks/internal/backtest"
+	"github.com/tiongMax/gostocks/internal/history"
+	"github.com/tiongMax/gostocks/internal/trading"
+)
+
+const usage = `Backtest trading strategies over recorded market data.
+
+Usage:
+  backtest run    -strategy NAME -symbols AAPL,MSFT -from 2026-01-02 [-to 2026-03-01]
+                  [-interval tick|1m|5m|15m|30m|1h|4h|1d] [-segments FILE,...]
+                  [-param name=value ...] [-json FILE] [-html FILE]
+  backtest sweep  -strategy NAME -symbols AAPL -from 2026-01-02 -param fast=5,10,20 -param slow=30,60
+                  [-workers N] [-json FILE]
+  backtest export -symbols AAPL,MSFT -from 2026-01-02 [-to 2026-03-01] -out ticks.seg.gz
+
+Data is read from the time-series store at DATABASE_URL, or from the segment
+files given with -segments. export records stored ticks to a segment file.
+Times are RFC 3339, a date (midnight UTC) or Unix milliseconds; -to defaults
+to now. Strategies: %s.
+`
+
+func main() {
+	// 1. Load Environment Variables
+	godotenv.Load(".env")
+
+	connStr := os.Getenv("DATABASE_URL")
+	if connStr == "" {
+		connStr = "user=user password=password dbname=gostocks sslmode=disable host=127.0.0.1 port=5433"
+	}
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+	cmd, args := os.Args[1], os.Args[2:]
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	switch cmd {
+	case "run", "sweep":
+		runBacktest(ctx, cmd, args, connStr)
+	case "export":
+		export(ctx, args, connStr)
+	default:
+		printUsage()
+		os.Exit(2)
+	}
+}
+
+func printUsage() {
+	fmt.Fprintf(os.Stderr, usage, strings.Join(backtest.StrategyNames(), ", "))
+}
+
+// runBacktest runs a strategy once, or once per combination of parameter
+// values for a sweep.
+func runBacktest(ctx context.Context, cmd string, args []string, connStr string) {
+	// 2. Parse flags
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	fs.Usage = printUsage
+	strategy := fs.String("strategy", "sma-cross", "strategy to run")
+	symbolsFlag := fs.String("symbols", "", "comma-separated symbols")
+	fromFlag := fs.String("from", "", "start of the range (inclusive)")
+	toFlag := fs.String("to", "", "end of the range (exclusive)")
+	intervalFlag := fs.String("interval", "tick", "tick, or a candle interval")
+	segments := fs.String("segments", "", "comma-separated segment files to read instead of Postgres")
+	params := paramFlag{}
+	fs.Var(params, "param", "strategy parameter as name=value, or name=v1,v2,... for a sweep (repeatable)")
+	cash := fs.Float64("cash", envAmount("PAPER_STARTING_CASH", 100000), "starting cash")
+	slippage := fs.Float64("slippage-bps", envAmount("PAPER_SLIPPAGE_BPS", 5), "slippage in basis points")
+	feePerOrder := fs.Float64("fee-per-order", envAmount("PAPER_FEE_PER_ORDER", 0), "fixed fee per fill")
+	feeBps := fs.Float64("fee-bps", envAmount("PAPER_FEE_BPS", 0), "fee in basis points of the fill's value")
+	workers := fs.Int("workers", 0, "parallel runs of a sweep (default GOMAXPROCS)")
+	jsonOut := fs.String("json", "", "write the JSON report to this file (- for stdout)")
+	htmlOut := fs.String("html", "", "write the HTML report to this file")
+	fs.Parse(args)
+
+	if !slices.Contains(backtest.StrategyNames(), *strategy) {
+		fail("unknown strategy %q", *strategy)
+	}
+	newStrategy := func(p backtest.Params) (backtest.Strategy, error) {
+		return backtest.NewStrategy(*strategy, p)
+	}
+	grid := backtest.Grid(params)
+	if cmd == "run" && len(grid) > 1 {
+		fail("-param lists several values; use backtest sweep")
+	}
+	if cmd == "sweep" && *htmlOut != "" {
+		fail("-html is only supported by backtest run")
+	}
+	if *cash <= 0 {
+		fail("-cash must be positive")
+	}
+
+	// 3. Load the market data
+	events := loadEvents(ctx, connStr, *symbolsFlag, *fromFlag, *toFlag, *intervalFlag, *segments)
+	if len(events) == 0 {
+		fail("no market data in the range")
+	}
+
+	cfg := backtest.Config{
+		StartingCash: *cash,
+		Execution: trading.ExecutionModel{
+			SlippageBps: *slippage,
+			FeePerOrder: *feePerOrder,
+			FeeBps:      *feeBps,
+		},
+	}
+
+	// 4. Run the strategy
+	if cmd == "run" {
+		s, err := newStrategy(grid[0])
+		if err != nil {
+			fail("%v", err)
+		}
+		r, err := backtest.Run(ctx, cfg, s, events)
+		if err != nil {
+			fail("%v", err)
+		}
+		r.Strategy, r.Params = *strategy, grid[0]
+
+		// 5. Write the reports
+		if *jsonOut != "-" {
+			printMetrics(r)
+		}
+		if *jsonOut != "" {
+			writeFile(*jsonOut, func(w io.Writer) error { return backtest.WriteJSON(w, r) })
+		}
+		if *htmlOut != "" {
+			writeFile(*htmlOut, func(w io.Writer) error { return backtest.WriteHTML(w, r) })
+		}
+		return
+	}
+
+	results := backtest.Sweep(ctx, cfg, events, newStrategy, grid, *workers)
+
+	// 5. Print the runs, best Sharpe ratio first
+	if *jsonOut != "-" {
+		printSweep(results)
+	}
+	if *jsonOut != "" {
+		type run struct {
+			Params  backtest.Params   `json:"params"`
+			Metrics *backtest.Metrics `json:"metrics,omitempty"`
+			Error   string            `json:"error,omitempty"`
+		}
+		runs := make([]run, len(results))
+		for i, sr := range results {
+			runs[i].Params = sr.Params
+			if sr.Err != nil {
+				runs[i].Error = sr.Err.Error()
+			} else {
+				runs[i].Metrics = &sr.Result.Metrics
+			}
+		}
+		writeFile(*jsonOut, func(w io.Writer) error {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(runs)
+		})
+	}
+}
+
+// loadEvents reads ticks, or candles of the interval, from segment files or
+// from Postgres.
+func loadEvents(ctx context.Context, connStr, symbolsFlag, fromFlag, toFlag, intervalFlag, segments string) []backtest.Event {
+	symbols := splitList(strings.ToUpper(symbolsFlag))
+	var interval time.Duration
+	if intervalFlag != "tick" {
+		var err error
+		if interval, err = history.ParseInterval(intervalFlag); err != nil {
+			fail("invalid -interval: %v", err)
+		}
+	}
+	from, to := parseRange(fromFlag, toFlag, segments == "")
+
+	if segments != "" {
+		events, err := backtest.ReadSegments(splitList(segments), symbols, from, to)
+		if err != nil {
+			fail("failed to read segments: %v", err)
+		}
+		if interval != 0 {
+			events = backtest.Candles(events, interval)
+		}
+		return events
+	}
+
+	if len(symbols) == 0 {
+		fail("-symbols is required")
+	}
+	reader, err := history.NewReader(connStr)
+	if err != nil {
+		fail("failed to connect to database: %v", err)
+	}
+	defer reader.Close()
+
+	events, err := backtest.LoadHistory(ctx, reader, symbols, from, to, interval)
+	if err != nil {
+		fail("%v", err)
+	}
+	return events
+}
+
+// export records the stored ticks of symbols to a segment file.
+func export(ctx context.Context, args []string, connStr string) {
+	// 2. Parse flags
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	fs.Usage = printUsage
+	symbolsFlag := fs.String("symbols", "", "comma-separated symbols")
+	fromFlag := fs.String("from", "", "start of the range (inclusive)")
+	toFlag := fs.String("to", "", "end of the range (exclusive)")
+	out := fs.String("out", "", "segment file to write; gzip-compressed if it ends in .gz")
+	fs.Parse(args)
+
+	symbols := splitList(strings.ToUpper(*symbolsFlag))
+	if len(symbols) == 0 || *out == "" {
+		printUsage()
+		os.Exit(2)
+	}
+	from, to := parseRange(*fromFlag, *toFlag, true)
+
+	// 3. Read the ticks
+	reader, err := history.NewReader(connStr)
+	if err != nil {
+		fail("failed to connect to database: %v", err)
+	}
+	defer reader.Close()
+
+	events, err := backtest.LoadHistory(ctx, reader, symbols, from, to, 0)
+	if err != nil {
+		fail("%v", err)
+	}
+	ticks := make([]history.Tick, len(events))
+	for i, e := range events {
+		ticks[i] = *e.Tick
+	}
+
+	// 4. Write the segment file
+	if err := backtest.WriteSegment(*out, ticks); err != nil {
+		fail("failed to write %s: %v", *out, err)
+	}
+	fmt.Printf("Wrote %d ticks to %s\n", len(ticks), *out)
+}
+
+func printMetrics(r *backtest.Result) {
+	m := r.Metrics
+	fmt.Printf("%s %s\n", r.Strategy, formatParams(r.Params))
+	fmt.Printf("%s - %s, %d events\n\n", r.From.UTC().Format(time.RFC3339), r.To.UTC().Format(time.RFC3339), m.Events)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Starting cash\t%.2f\n", m.StartingCash)
+	fmt.Fprintf(w, "Final equity\t%.2f\n", m.FinalEquity)
+	fmt.Fprintf(w, "Total return\t%.2f%%\n", m.TotalReturn*100)
+	fmt.Fprintf(w, "Max drawdown\t%.2f%%\n", m.MaxDrawdown*100)
+	fmt.Fprintf(w, "Sharpe ratio\t%.2f\n", m.Sharpe)
+	fmt.Fprintf(w, "Trades\t%d\n", m.Trades)
+	fmt.Fprintf(w, "Win rate\t%.2f%%\n", m.WinRate*100)
+	fmt.Fprintf(w, "Fees\t%.2f\n", m.Fees)
+	fmt.Fprintf(w, "Rejected orders\t%d\n", m.Rejected)
+	w.Flush()
+}
+
+func printSweep(results []backtest.SweepResult) {
+	order := make([]int, len(results))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ra, rb := results[order[a]], results[order[b]]
+		if (ra.Err == nil) != (rb.Err == nil) {
+			return ra.Err == nil
+		}
+		return ra.Err == nil && ra.Result.Metrics.Sharpe > rb.Result.Metrics.Sharpe
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PARAMS\tRETURN\tMAX DRAWDOWN\tSHARPE\tTRADES\tWIN RATE")
+	for _, i := range order {
+		sr := results[i]
+		if sr.Err != nil {
+			fmt.Fprintf(w, "%s\terror: %v\n", formatParams(sr.Params), sr.Err)
+			continue
+		}
+		m := sr.Result.Metrics
+		fmt.Fprintf(w, "%s\t%.2f%%\t%.2f%%\t%.2f\t%d\t%.2f%%\n", formatParams(sr.Params),
+			m.TotalReturn*100, m.MaxDrawdown*100, m.Sharpe, m.Trades, m.WinRate*100)
+	}
+	w.Flush()
+}
+
+// paramFlag collects -param name=v1,v2,... values.
+type paramFlag map[string][]float64
+
+func (p paramFlag) String() string {
+	return ""
+}
+
+func (p paramFlag) Set(v string) error {
+	name, values, ok := strings.Cut(v, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("want name=value, got %q", v)
+	}
+	for _, s := range splitList(values) {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s", s, name)
+		}
+		p[name] = append(p[name], f)
+	}
+	if len(p[name]) == 0 {
+		return fmt.Errorf("no value for %s", name)
+	}
+	return nil
+}
+
+func formatParams(p backtest.Params) string {
+	if len(p) == 0 {
+		return "(defaults)"
+	}
+	return p.String()
+}
+
+// parseRange parses -from and -to. -to defaults to now when -from is
+// required, and to the end of the data otherwise.
+func parseRange(fromFlag, toFlag string, required bool) (from, to time.Time) {
+	if fromFlag == "" && required {
+		fail("-from is required")
+	}
+	var err error
+	if fromFlag != "" {
+		if from, err = parseTime(fromFlag); err != nil {
+			fail("invalid -from: %v", err)
+		}
+	}
+	switch {
+	case toFlag != "":
+		if to, err = parseTime(toFlag); err != nil {
+			fail("invalid -to: %v", err)
+		}
+	case required:
+		to = time.Now()
+	}
+	return from, to
+}
+
+// parseTime accepts RFC 3339, a date or Unix milliseconds.
+func parseTime(v string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+func splitList(v string) []string {
+	var items []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			items = append(items, s)
+		}
+	}
+	return items
+}
+
+// envAmount reads a non-negative number from the environment, so runs use
+// the paper trading settings unless overridden.
+func envAmount(name string, def float64) float64 {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 {
+		fail("invalid %s %q", name, v)
+	}
+	return f
+}
+
+// writeFile writes a report to path, or to stdout if path is "-".
+func writeFile(path string, write func(io.Writer) error) {
+	if path == "-" {
+		if err := write(os.Stdout); err != nil {
+			fail("%v", err)
+		}
+		return
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		fail("%v", err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		fail("failed to write %s: %v", path, err)
+	}
+	if err := f.Close(); err != nil {
+		fail("failed to write %s: %v", path, err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", path)
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "backtest: "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/joho/godotenv"
+	"github.com/tiongMax/gostocks/internal/bus"
+)
+
+const usage = `Inspect and redrive dead-lettered messages.
+
+Usage:
+  dlq list -topic <topic> [-values]
+  dlq redrive -topic <topic>
+
+The topic is the one the messages were consumed from, e.g. market_ticks;
+its dead letters are kept in market_ticks.dlq. redrive publishes every
+message dead-lettered since the last redrive back to its topic, where only
+the consumer group that dead-lettered it processes it again.
+`
+
+// redriveGroup records how far the dead-letter topics have been redriven.
+const redriveGroup = "dlq-redrive"
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	// 1. Load Environment Variables
+	godotenv.Load(".env")
+
+	kafkaBrokers := os.Getenv("KAFKA_BROKERS")
+	if kafkaBrokers == "" {
+		kafkaBrokers = "localhost:9092"
+	}
+	kafka := bus.NewKafka(strings.Split(kafkaBrokers, ","))
+
+	// 2. Run the command
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	topic := fs.String("topic", "", "topic the messages were consumed from")
+	values := fs.Bool("values", false, "dump the message values")
+	fs.Parse(os.Args[2:])
+
+	if *topic == "" {
+		fail("-topic is required")
+	}
+	dead := bus.DeadLetterTopic(strings.TrimSuffix(*topic, bus.DeadLetterSuffix))
+
+	switch os.Args[1] {
+	case "list":
+		n := 0
+		err := readAll(kafka, dead, "", func(msg *bus.Message) error {
+			n++
+			h := msg.Headers
+			fmt.Printf("%d@%d\t%s %s@%s\t%s\t%s\t%s attempts\t%s\n", msg.Partition, msg.Offset,
+				h[bus.HeaderTopic], h[bus.HeaderPartition], h[bus.HeaderOffset], msg.Key,
+				h[bus.HeaderGroup], h[bus.HeaderAttempts], h[bus.HeaderFailedAt])
+			fmt.Printf("\t%s\n", h[bus.HeaderError])
+			if *values {
+				fmt.Print(hex.Dump(msg.Value))
+			}
+			return nil
+		})
+		if err != nil {
+			fail("%v", err)
+		}
+		fmt.Printf("%d dead-lettered messages in %s\n", n, dead)
+
+	case "redrive":
+		pub, err := kafka.NewPublisher()
+		if err != nil {
+			fail("%v", err)
+		}
+		defer pub.Close()
+
+		n := 0
+		err = readAll(kafka, dead, redriveGroup, func(msg *bus.Message) error {
+			redriven := bus.Redrive(msg)
+			if err := pub.Publish(context.Background(), redriven); err != nil {
+				return fmt.Errorf("failed to redrive %d@%d: %w", msg.Partition, msg.Offset, err)
+			}
+			n++
+			fmt.Printf("%d@%d\t-> %s %d@%d\n", msg.Partition, msg.Offset, redriven.Topic, redriven.Partition, redriven.Offset)
+			return nil
+		})
+		if err != nil {
+			fail("%v (%d messages redriven)", err, n)
+		}
+		fmt.Printf("Redrove %d messages from %s\n", n, dead)
+
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// readAll calls fn for every message of topic up to the end of each
+// partition when it was claimed, one at a time. With a group, it starts
+// where the group got to and commits every message fn succeeds for.
+func readAll(sub bus.Subscriber, topic, group string, fn func(*bus.Message) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var pending int // Partitions not read to the end yet
+	var failed error
+	done := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil && failed == nil {
+			failed = err
+		}
+		if pending--; pending <= 0 || failed != nil {
+			cancel()
+		}
+	}
+
+	handler := bus.HandlerFunc(func(claim bus.Claim) error {
+		end := claim.HighWaterMark()
+		var err error
+		if claim.InitialOffset() < end {
+			for msg := range claim.Messages() {
+				mu.Lock()
+				err = fn(msg)
+				mu.Unlock()
+				if err != nil {
+					break
+				}
+				claim.Commit(msg)
+				if msg.Offset+1 >= end {
+					break
+				}
+			}
+		}
+		done(err)
+
+		// Hold the claim until every partition is read: returning early
+		// would end the session of a consumer group
+		<-claim.Context().Done()
+		return nil
+	})
+
+	err := sub.Subscribe(ctx, bus.Subscription{
+		Topics: []string{topic},
+		Group:  group,
+		Start:  bus.Oldest,
+		OnAssign: func(claims map[string][]int32) {
+			mu.Lock()
+			defer mu.Unlock()
+			if pending = len(claims[topic]); pending == 0 {
+				cancel()
+			}
+		},
+	}, handler)
+	if err != nil {
+		return err
+	}
+	return failed
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "dlq: "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -1,13 +1,22 @@
 package main
 
 import (
+	"context"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tiongMax/gostocks/internal/apierror"
+	"github.com/tiongMax/gostocks/internal/auth"
+	"github.com/tiongMax/gostocks/internal/freshness"
 	"github.com/tiongMax/gostocks/internal/gateway"
+	"github.com/tiongMax/gostocks/internal/ratelimit"
 )
 
 func main() {
@@ -31,6 +40,109 @@ func main() {
 		alertServiceAddr = "localhost:50051"
 	}
 
+	// Watchlists are served by the Alert Service unless split out
+	watchlistServiceAddr := os.Getenv("WATCHLIST_SERVICE_ADDR")
+	if watchlistServiceAddr == "" {
+		watchlistServiceAddr = alertServiceAddr
+	}
+
+	portfolioServiceAddr := os.Getenv("PORTFOLIO_SERVICE_ADDR")
+	if portfolioServiceAddr == "" {
+		portfolioServiceAddr = "localhost:50052"
+	}
+
+	tradingServiceAddr := os.Getenv("TRADING_SERVICE_ADDR")
+	if tradingServiceAddr == "" {
+		tradingServiceAddr = "localhost:50053"
+	}
+
+	connStr := os.Getenv("DATABASE_URL")
+	if connStr == "" {
+		connStr = "user=user password=password dbname=gostocks sslmode=disable host=127.0.0.1 port=5433"
+	}
+
+	historyMaxPoints := 5000
+	if v := os.Getenv("HISTORY_MAX_POINTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			slog.Error("Invalid HISTORY_MAX_POINTS", "value", v)
+			os.Exit(1)
+		}
+		historyMaxPoints = n
+	}
+
+	sla, err := freshness.ParseSLA(os.Getenv("FRESHNESS_SLA"), os.Getenv("FRESHNESS_SLA_OVERRIDES"), time.Minute)
+	if err != nil {
+		slog.Error("Invalid freshness configuration", "error", err)
+		os.Exit(1)
+	}
+
+	staleMode := os.Getenv("STALE_MODE")
+	switch staleMode {
+	case "":
+		staleMode = gateway.StaleModeFlag
+	case gateway.StaleModeFlag, gateway.StaleModeWarning, gateway.StaleModeReject:
+	default:
+		slog.Error("Invalid STALE_MODE", "value", staleMode)
+		os.Exit(1)
+	}
+
+	// JWT authentication is optional; API keys are always accepted
+	jwtSecret := os.Getenv("JWT_SECRET")
+	jwksFile := os.Getenv("JWT_JWKS_FILE")
+
+	// Rate limits per route class: "<n>/<s|m|h>[:burst]", or "off"
+	limits := make(map[string]ratelimit.Limit)
+	for _, l := range []struct{ class, env, def string }{
+		{"ip", "RATE_LIMIT_IP", "100/s:200"},
+		{"read", "RATE_LIMIT_READ", "20/s:40"},
+		{"write", "RATE_LIMIT_WRITE", "30/m:10"},
+		{"stream", "RATE_LIMIT_STREAM", "10/m:5"},
+	} {
+		v := os.Getenv(l.env)
+		if v == "" {
+			v = l.def
+		}
+		limit, err := ratelimit.ParseLimit(v)
+		if err != nil {
+			slog.Error("Invalid rate limit", "variable", l.env, "error", err)
+			os.Exit(1)
+		}
+		limits[l.class] = limit
+	}
+
+	rateLimitBackend := os.Getenv("RATE_LIMIT_BACKEND")
+	if rateLimitBackend == "" {
+		rateLimitBackend = "redis"
+	}
+
+	// Client IPs are taken from X-Forwarded-For only behind these proxies
+	var trustedProxies []string
+	if v := os.Getenv("TRUSTED_PROXIES"); v != "" {
+		trustedProxies = strings.Split(v, ",")
+	}
+
+	// Request and response validation against the OpenAPI spec, for test environments
+	openAPIValidation := os.Getenv("OPENAPI_VALIDATION")
+	switch openAPIValidation {
+	case "":
+		openAPIValidation = gateway.ValidationOff
+	case gateway.ValidationOff, gateway.ValidationWarn, gateway.ValidationStrict:
+	default:
+		slog.Error("Invalid OPENAPI_VALIDATION", "value", openAPIValidation)
+		os.Exit(1)
+	}
+
+	sseHeartbeat := 15 * time.Second
+	if v := os.Getenv("SSE_HEARTBEAT_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			slog.Error("Invalid SSE_HEARTBEAT_INTERVAL", "value", v)
+			os.Exit(1)
+		}
+		sseHeartbeat = d
+	}
+
 	// 2. Connect to Redis (Speed Layer)
 	slog.Info("Connecting to Redis...")
 	redisClient, err := gateway.NewRedisClient(redisAddr)
@@ -51,23 +163,130 @@ func main() {
 	defer alertClient.Close()
 	slog.Info("Connected to Alert Service")
 
-	// 4. Set up Gin router
-	router := gin.Default()
+	watchlistClient, err := gateway.NewWatchlistClient(watchlistServiceAddr)
+	if err != nil {
+		slog.Error("Failed to connect to Watchlist Service", "error", err)
+		os.Exit(1)
+	}
+	defer watchlistClient.Close()
+
+	// Connect to Portfolio Service (gRPC)
+	slog.Info("Connecting to Portfolio Service...")
+	portfolioClient, err := gateway.NewPortfolioClient(portfolioServiceAddr)
+	if err != nil {
+		slog.Error("Failed to connect to Portfolio Service", "error", err)
+		os.Exit(1)
+	}
+	defer portfolioClient.Close()
+	slog.Info("Connected to Portfolio Service")
 
-	// Create handler with dependencies
-	handler := gateway.NewHandler(redisClient, alertClient)
+	// Connect to Trading Service (gRPC)
+	slog.Info("Connecting to Trading Service...")
+	tradingClient, err := gateway.NewTradingClient(tradingServiceAddr)
+	if err != nil {
+		slog.Error("Failed to connect to Trading Service", "error", err)
+		os.Exit(1)
+	}
+	defer tradingClient.Close()
+	slog.Info("Connected to Trading Service")
+
+	// 4. Connect to Postgres (price history)
+	slog.Info("Connecting to history store...")
+	historyClient, err := gateway.NewHistoryClient(connStr, historyMaxPoints)
+	if err != nil {
+		slog.Error("Failed to connect to history store", "error", err)
+		os.Exit(1)
+	}
+	defer historyClient.Close()
+	slog.Info("Connected to history store")
 
-	// Health check
-	router.GET("/health", handler.HealthCheck)
+	// 5. Set up authentication (API keys in Postgres, optional JWTs)
+	keyStore, err := auth.NewKeyStore(connStr)
+	if err != nil {
+		slog.Error("Failed to connect to key store", "error", err)
+		os.Exit(1)
+	}
+	defer keyStore.Close()
+	if err := keyStore.AutoMigrate(); err != nil {
+		slog.Error("Failed to migrate key store", "error", err)
+		os.Exit(1)
+	}
 
-	// Day 11: Price endpoint (Redis lookup)
-	router.GET("/price/:symbol", handler.GetPrice)
+	var jwtVerifier *auth.JWTVerifier
+	if jwtSecret != "" || jwksFile != "" {
+		jwtVerifier, err = auth.NewJWTVerifier([]byte(jwtSecret), jwksFile, os.Getenv("JWT_ISSUER"), os.Getenv("JWT_AUDIENCE"))
+		if err != nil {
+			slog.Error("Failed to set up JWT authentication", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("JWT authentication enabled", "hs256", jwtSecret != "", "jwks", jwksFile)
+	}
+	authenticator := auth.NewAuthenticator(keyStore, jwtVerifier)
 
-	// Day 12: Alert endpoints (gRPC to Alert Service)
-	router.POST("/alerts", handler.CreateAlert)
-	router.GET("/alerts", handler.GetAlerts)
+	// 6. Set up rate limiting (shared across replicas through Redis)
+	var limiter ratelimit.Limiter
+	switch rateLimitBackend {
+	case "redis":
+		redisLimiter, err := ratelimit.NewRedisLimiter(redisAddr)
+		if err != nil {
+			slog.Error("Failed to connect rate limiter to Redis", "error", err)
+			os.Exit(1)
+		}
+		defer redisLimiter.Close()
+		limiter = redisLimiter
+	case "memory":
+		limiter = ratelimit.NewMemoryLimiter()
+	default:
+		slog.Error("Invalid RATE_LIMIT_BACKEND", "value", rateLimitBackend)
+		os.Exit(1)
+	}
+
+	// 7. Start live price hub (Redis pub/sub fan-out)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hub := gateway.NewHub(redisClient)
+	go func() {
+		if err := hub.Run(ctx); err != nil {
+			slog.Error("Price hub failed", "error", err)
+		}
+	}()
+
+	// 8. Set up Gin router
+	spec, err := gateway.LoadSpec()
+	if err != nil {
+		slog.Error("Failed to load OpenAPI spec", "error", err)
+		os.Exit(1)
+	}
+
+	router := gin.New()
+	// Every response carries a request ID, echoed in error bodies. Query
+	// tokens are moved to a header before the logger sees the URL.
+	router.Use(apierror.RequestID(), auth.TokenFromQuery(), gin.Logger())
+	if openAPIValidation != gateway.ValidationOff {
+		// Outside recovery, so that responses to panics are checked too
+		slog.Warn("OpenAPI validation enabled; not intended for production", "mode", openAPIValidation)
+		router.Use(gateway.ValidateOpenAPI(spec, openAPIValidation))
+	}
+	router.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		apierror.Abort(c, http.StatusInternalServerError, "internal error")
+	}))
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		slog.Error("Invalid TRUSTED_PROXIES", "error", err)
+		os.Exit(1)
+	}
+
+	// Create handler with dependencies
+	handler := gateway.NewHandler(redisClient, alertClient, watchlistClient, portfolioClient, tradingClient, historyClient, hub, sla, staleMode, sseHeartbeat)
+	gateway.RegisterRoutes(router, handler, spec, gateway.RouteMiddleware{
+		IPLimit: ratelimit.Middleware(limiter, "ip", limits["ip"], ratelimit.ByIP),
+		Auth:    authenticator.Middleware(),
+		Read:    ratelimit.Middleware(limiter, "read", limits["read"], ratelimit.ByCaller),
+		Write:   ratelimit.Middleware(limiter, "write", limits["write"], ratelimit.ByCaller),
+		Stream:  ratelimit.Middleware(limiter, "stream", limits["stream"], ratelimit.ByCaller),
+	})
 
-	// 5. Start server in goroutine
+	// 9. Start server in goroutine
 	go func() {
 		slog.Info("API Gateway listening", "port", port)
 		if err := router.Run(":" + port); err != nil {
@@ -76,7 +295,7 @@ func main() {
 		}
 	}()
 
-	// 6. Wait for shutdown signal
+	// 10. Wait for shutdown signal
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 
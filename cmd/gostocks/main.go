@@ -0,0 +1,489 @@
+// Command gostocks runs every GoStocks service in one process: the
+// ingestor, processor, alert, watchlist, portfolio and trading services and
+// the API gateway. Kafka, Redis and Postgres are replaced by an in-memory
+// bus, an embedded Redis and an embedded Postgres unless KAFKA_BROKERS,
+// REDIS_ADDR or DATABASE_URL point at real ones.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/gin-gonic/gin"
+	"github.com/joho/godotenv"
+	"github.com/tiongMax/gostocks/internal/alert"
+	"github.com/tiongMax/gostocks/internal/apierror"
+	"github.com/tiongMax/gostocks/internal/auth"
+	"github.com/tiongMax/gostocks/internal/bus"
+	"github.com/tiongMax/gostocks/internal/freshness"
+	"github.com/tiongMax/gostocks/internal/gateway"
+	"github.com/tiongMax/gostocks/internal/history"
+	"github.com/tiongMax/gostocks/internal/ingestor"
+	"github.com/tiongMax/gostocks/internal/lag"
+	"github.com/tiongMax/gostocks/internal/portfolio"
+	"github.com/tiongMax/gostocks/internal/processor"
+	"github.com/tiongMax/gostocks/internal/ratelimit"
+	"github.com/tiongMax/gostocks/internal/symbols"
+	"github.com/tiongMax/gostocks/internal/trading"
+	pba "github.com/tiongMax/gostocks/proto/alert"
+	pbp "github.com/tiongMax/gostocks/proto/portfolio"
+	pbt "github.com/tiongMax/gostocks/proto/trading"
+	pbw "github.com/tiongMax/gostocks/proto/watchlist"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+)
+
+const ticksTopic = "market_ticks"
+
+func main() {
+	// Configure JSON logger
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+
+	// Errors are returned rather than exiting so that the embedded Postgres
+	// is always stopped
+	if err := run(); err != nil {
+		slog.Error("GoStocks failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	// 1. Load Environment Variables
+	if err := godotenv.Load(".env"); err != nil {
+		slog.Info("No .env file found, using system environment variables")
+	}
+
+	// 2. Configuration
+	apiKey := os.Getenv("FINNHUB_API_KEY")
+	if apiKey == "" {
+		return errors.New("FINNHUB_API_KEY environment variable is not set")
+	}
+
+	port := envOr("PORT", "8080")
+	grpcPort := envOr("GRPC_PORT", "50051")
+	debugPort := envOr("DEBUG_PORT", "6060")
+
+	// Embedded Postgres keeps its data here between runs
+	dataDir := envOr("GOSTOCKS_DATA_DIR", ".gostocks")
+
+	baseSymbols := []string{"AAPL", "BINANCE:BTCUSDT", "IC MARKETS:1"}
+	if v := os.Getenv("SYMBOLS"); v != "" {
+		list, err := symbols.NormalizeAll(strings.Split(v, ","))
+		if err != nil {
+			return fmt.Errorf("invalid SYMBOLS: %w", err)
+		}
+		baseSymbols = list
+	}
+
+	dayLocation, err := time.LoadLocation(envOr("DAY_STATS_TIMEZONE", "America/New_York"))
+	if err != nil {
+		return fmt.Errorf("invalid DAY_STATS_TIMEZONE: %w", err)
+	}
+
+	sla, err := freshness.ParseSLA(os.Getenv("FRESHNESS_SLA"), os.Getenv("FRESHNESS_SLA_OVERRIDES"), time.Minute)
+	if err != nil {
+		return fmt.Errorf("invalid freshness configuration: %w", err)
+	}
+
+	thresholds, err := lag.ParseThresholds(os.Getenv("LAG_OFFSET_THRESHOLD"), os.Getenv("LAG_TIME_THRESHOLD"), lag.DefaultThresholds)
+	if err != nil {
+		return err
+	}
+
+	workers, err := envInt("CONSUMER_WORKERS", runtime.GOMAXPROCS(0))
+	if err != nil {
+		return err
+	}
+
+	startingCash, err := envFloat("PAPER_STARTING_CASH", 100000)
+	if err != nil {
+		return err
+	}
+	model := trading.ExecutionModel{}
+	if model.SlippageBps, err = envFloat("PAPER_SLIPPAGE_BPS", 5); err != nil {
+		return err
+	}
+	if model.FeePerOrder, err = envFloat("PAPER_FEE_PER_ORDER", 0); err != nil {
+		return err
+	}
+	if model.FeeBps, err = envFloat("PAPER_FEE_BPS", 0); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 3. Start Postgres, unless DATABASE_URL points at one
+	connStr := os.Getenv("DATABASE_URL")
+	if connStr == "" {
+		pgPort, err := strconv.ParseUint(envOr("EMBEDDED_POSTGRES_PORT", "5434"), 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid EMBEDDED_POSTGRES_PORT: %w", err)
+		}
+		config := embeddedpostgres.DefaultConfig().
+			Port(uint32(pgPort)).
+			Database("gostocks").
+			Username("user").
+			Password("password").
+			RuntimePath(filepath.Join(dataDir, "runtime")).
+			DataPath(filepath.Join(dataDir, "postgres")).
+			CachePath(filepath.Join(dataDir, "cache")).
+			StartTimeout(time.Minute).
+			Logger(os.Stderr)
+
+		slog.Info("Starting embedded Postgres...", "port", pgPort, "data_dir", dataDir)
+		postgres := embeddedpostgres.NewDatabase(config)
+		if err := postgres.Start(); err != nil {
+			return fmt.Errorf("failed to start embedded Postgres: %w", err)
+		}
+		defer func() {
+			if err := postgres.Stop(); err != nil {
+				slog.Error("Failed to stop embedded Postgres", "error", err)
+			}
+		}()
+		connStr = config.GetConnectionURL() + "?sslmode=disable"
+	}
+
+	// 4. Start Redis, unless REDIS_ADDR points at one
+	redisAddr := os.Getenv("REDIS_ADDR")
+	if redisAddr == "" {
+		redis, err := miniredis.Run()
+		if err != nil {
+			return fmt.Errorf("failed to start embedded Redis: %w", err)
+		}
+		defer redis.Close()
+		redisAddr = redis.Addr()
+		slog.Info("Started embedded Redis", "addr", redisAddr)
+	}
+
+	// 5. Connect the message bus, in memory unless KAFKA_BROKERS is set
+	var b bus.Bus
+	if v := os.Getenv("KAFKA_BROKERS"); v != "" {
+		b = bus.NewKafka(strings.Split(v, ","))
+		slog.Info("Using Kafka", "brokers", v)
+	} else {
+		partitions, err := envInt("BUS_PARTITIONS", 4)
+		if err != nil {
+			return err
+		}
+		retention, err := envInt("BUS_RETENTION", 100000)
+		if err != nil {
+			return err
+		}
+		b = bus.NewMemory(partitions, retention)
+		slog.Info("Using in-memory bus", "partitions", partitions, "retention", retention)
+	}
+	publisher, err := b.NewPublisher()
+	if err != nil {
+		return err
+	}
+	defer publisher.Close()
+
+	// 6. Connect to the stores and migrate their schemas
+	alerts, err := alert.NewStore(connStr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to alert store: %w", err)
+	}
+	defer alerts.Close()
+	portfolios, err := portfolio.NewStore(connStr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to portfolio store: %w", err)
+	}
+	defer portfolios.Close()
+	orders, err := trading.NewStore(connStr, startingCash)
+	if err != nil {
+		return fmt.Errorf("failed to connect to trading store: %w", err)
+	}
+	defer orders.Close()
+	keyStore, err := auth.NewKeyStore(connStr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to key store: %w", err)
+	}
+	defer keyStore.Close()
+	registry, err := symbols.NewRegistry(connStr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to symbol registry: %w", err)
+	}
+	defer registry.Close()
+
+	for name, migrate := range map[string]func() error{
+		"alert":     alerts.AutoMigrate,
+		"portfolio": portfolios.AutoMigrate,
+		"trading":   orders.AutoMigrate,
+		"key":       keyStore.AutoMigrate,
+		"symbol":    registry.AutoMigrate,
+	} {
+		if err := migrate(); err != nil {
+			return fmt.Errorf("failed to migrate %s store: %w", name, err)
+		}
+	}
+
+	hist, err := history.NewWriter(connStr, 30*24*time.Hour, 0)
+	if err != nil {
+		return fmt.Errorf("failed to connect to history store: %w", err)
+	}
+	defer hist.Close()
+	historyReader, err := history.NewReader(connStr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to history store: %w", err)
+	}
+	defer historyReader.Close()
+
+	// 7. Start the processor
+	writer, err := processor.NewRedisWriter(redisAddr, dayLocation)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	watchdog := processor.NewWatchdog(sla, writer, 5*time.Second)
+	consumer := processor.NewConsumer(b, publisher, ticksTopic, writer, hist, watchdog, thresholds, workers)
+	processorDone := make(chan struct{})
+	go hist.Run(ctx, time.Hour)
+	go watchdog.Run(ctx)
+	go func() {
+		defer close(processorDone)
+		if err := consumer.Start(ctx); err != nil {
+			slog.Error("Processor failed", "error", err)
+			cancel()
+		}
+	}()
+
+	// 8. Start the trading, portfolio and alert services on one gRPC server.
+	// The alert service and the gateway reach the others through it.
+	selfAddr := "localhost:" + grpcPort
+	events := trading.NewEventPublisher(publisher, "order_events")
+	engine := trading.NewEngine(b, publisher, ticksTopic, orders, model, events)
+	go func() {
+		if err := engine.Start(ctx); err != nil {
+			slog.Error("Matching engine failed", "error", err)
+			cancel()
+		}
+	}()
+
+	quotes, err := portfolio.NewRedisQuotes(redisAddr)
+	if err != nil {
+		return err
+	}
+	defer quotes.Close()
+	tracker := portfolio.NewTracker(b, ticksTopic, quotes)
+	go func() {
+		if err := tracker.Start(ctx); err != nil {
+			slog.Error("Tick tracker failed", "error", err)
+			cancel()
+		}
+	}()
+
+	tradingConn, err := grpc.NewClient(selfAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer tradingConn.Close()
+
+	broker := alert.NewBroker()
+	notifiers := []alert.Notifier{alert.NewLogNotifier(), broker}
+	valuer := alert.NewPortfolioValuer(portfolios, quotes)
+	actions := alert.NewActionRunner(alerts, pbt.NewTradingServiceClient(tradingConn), 30*time.Second, notifiers...)
+	alertConsumer := alert.NewConsumer(b, publisher, ticksTopic, alerts, nil, valuer, actions, thresholds, workers, notifiers...)
+	go func() {
+		if err := alertConsumer.Start(ctx); err != nil {
+			slog.Error("Alert Consumer failed", "error", err)
+			cancel()
+		}
+	}()
+	go alert.NewSweeper(alerts, 30*time.Second).Start(ctx)
+	go actions.Start(ctx)
+
+	grpcServer := grpc.NewServer(
+		// Detect clients that vanished from long-lived streams
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    30 * time.Second,
+			Timeout: 10 * time.Second,
+		}),
+	)
+	pba.RegisterAlertServiceServer(grpcServer, alert.NewServer(alerts, valuer, actions, alert.NewBacktester(historyReader, nil), broker, 100))
+	pbw.RegisterWatchlistServiceServer(grpcServer, alert.NewWatchlistServer(alerts))
+	pbp.RegisterPortfolioServiceServer(grpcServer, portfolio.NewServer(portfolios, tracker, time.Second))
+	pbt.RegisterTradingServiceServer(grpcServer, trading.NewServer(orders, events))
+	reflection.Register(grpcServer)
+
+	listener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %s: %w", grpcPort, err)
+	}
+	go func() {
+		slog.Info("gRPC server listening", "port", grpcPort)
+		if err := grpcServer.Serve(listener); err != nil {
+			slog.Error("Failed to serve gRPC", "error", err)
+			cancel()
+		}
+	}()
+	defer grpcServer.GracefulStop()
+
+	// 9. Start the ingestor, following the symbol registry
+	client := ingestor.NewClient(apiKey, baseSymbols, publisher)
+	if err := client.Start(); err != nil {
+		return fmt.Errorf("failed to start ingestor: %w", err)
+	}
+	defer client.Close()
+	go client.SyncSubscriptions(ctx, registry, 10*time.Second)
+
+	// 10. Start the gateway
+	redisClient, err := gateway.NewRedisClient(redisAddr)
+	if err != nil {
+		return err
+	}
+	defer redisClient.Close()
+	alertClient, err := gateway.NewAlertClient(selfAddr)
+	if err != nil {
+		return err
+	}
+	defer alertClient.Close()
+	watchlistClient, err := gateway.NewWatchlistClient(selfAddr)
+	if err != nil {
+		return err
+	}
+	defer watchlistClient.Close()
+	portfolioClient, err := gateway.NewPortfolioClient(selfAddr)
+	if err != nil {
+		return err
+	}
+	defer portfolioClient.Close()
+	tradingClient, err := gateway.NewTradingClient(selfAddr)
+	if err != nil {
+		return err
+	}
+	defer tradingClient.Close()
+	historyClient, err := gateway.NewHistoryClient(connStr, 5000)
+	if err != nil {
+		return err
+	}
+	defer historyClient.Close()
+
+	hub := gateway.NewHub(redisClient)
+	go func() {
+		if err := hub.Run(ctx); err != nil {
+			slog.Error("Price hub failed", "error", err)
+		}
+	}()
+
+	spec, err := gateway.LoadSpec()
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+	limiter := ratelimit.NewMemoryLimiter()
+	limits := make(map[string]ratelimit.Limit)
+	for class, v := range map[string]string{"ip": "100/s:200", "read": "20/s:40", "write": "30/m:10", "stream": "10/m:5"} {
+		if limits[class], err = ratelimit.ParseLimit(v); err != nil {
+			return err
+		}
+	}
+
+	router := gin.New()
+	router.Use(apierror.RequestID(), auth.TokenFromQuery(), gin.Logger())
+	router.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		apierror.Abort(c, http.StatusInternalServerError, "internal error")
+	}))
+	handler := gateway.NewHandler(redisClient, alertClient, watchlistClient, portfolioClient, tradingClient, historyClient, hub, sla, gateway.StaleModeFlag, 15*time.Second)
+	gateway.RegisterRoutes(router, handler, spec, gateway.RouteMiddleware{
+		IPLimit: ratelimit.Middleware(limiter, "ip", limits["ip"], ratelimit.ByIP),
+		Auth:    auth.NewAuthenticator(keyStore, nil).Middleware(),
+		Read:    ratelimit.Middleware(limiter, "read", limits["read"], ratelimit.ByCaller),
+		Write:   ratelimit.Middleware(limiter, "write", limits["write"], ratelimit.ByCaller),
+		Stream:  ratelimit.Middleware(limiter, "stream", limits["stream"], ratelimit.ByCaller),
+	})
+
+	server := &http.Server{Addr: ":" + port, Handler: router}
+	go func() {
+		slog.Info("API Gateway listening", "port", port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Failed to start server", "error", err)
+			cancel()
+		}
+	}()
+
+	debug := lag.NewDebugServer(":"+debugPort, consumer.Lag(), alertConsumer.Lag(), engine.Lag())
+	go func() {
+		slog.Info("Debug server listening", "port", debugPort)
+		if err := debug.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Debug server failed", "error", err)
+		}
+	}()
+	defer debug.Close()
+
+	slog.Info("GoStocks is fully running", "symbols", baseSymbols)
+
+	// 11. Wait for shutdown signal
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case sig := <-stop:
+		slog.Info("Shutdown signal received", "signal", sig)
+	case <-ctx.Done():
+		slog.Info("Context cancelled, shutting down")
+	}
+
+	// Graceful shutdown; the deferred closes run in reverse, the embedded
+	// Postgres last
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Error shutting down API Gateway", "error", err)
+	}
+	cancel()
+	<-processorDone
+	slog.Info("GoStocks stopped")
+	return nil
+}
+
+// envOr reads a setting from the environment, or returns def if it is unset.
+func envOr(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+// envInt reads a positive integer from the environment.
+func envInt(name string, def int) (int, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid %s: %q", name, v)
+	}
+	return n, nil
+}
+
+// envFloat reads a non-negative number from the environment.
+func envFloat(name string, def float64) (float64, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return def, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 {
+		return 0, fmt.Errorf("invalid %s: %q", name, v)
+	}
+	return f, nil
+}
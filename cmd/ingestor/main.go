@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -9,7 +10,9 @@ import (
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/tiongMax/gostocks/internal/bus"
 	"github.com/tiongMax/gostocks/internal/ingestor"
+	"github.com/tiongMax/gostocks/internal/symbols"
 )
 
 func main() {
@@ -33,15 +36,42 @@ func main() {
 	if kafkaBrokers == "" {
 		kafkaBrokers = "localhost:9092"
 	}
-	brokers := strings.Split(kafkaBrokers, ",")
+	kafka := bus.NewKafka(strings.Split(kafkaBrokers, ","))
+
+	// Symbols streamed from startup; watchlist symbols are added at runtime
+	baseSymbols := []string{"AAPL", "BINANCE:BTCUSDT", "IC MARKETS:1"}
+	if v := os.Getenv("SYMBOLS"); v != "" {
+		list, err := symbols.NormalizeAll(strings.Split(v, ","))
+		if err != nil {
+			slog.Error("Invalid SYMBOLS", "error", err)
+			os.Exit(1)
+		}
+		baseSymbols = list
+	}
+
+	connStr := os.Getenv("DATABASE_URL")
+	if connStr == "" {
+		connStr = "user=user password=password dbname=gostocks sslmode=disable host=127.0.0.1 port=5433"
+	}
+
+	// Symbol registry poll interval (0 = only stream SYMBOLS)
+	syncInterval := 10 * time.Second
+	if v := os.Getenv("SYMBOL_SYNC_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			slog.Error("Invalid SYMBOL_SYNC_INTERVAL", "value", v)
+			os.Exit(1)
+		}
+		syncInterval = d
+	}
 
 	// 3. Initialize Client
-	symbols := []string{"AAPL", "BINANCE:BTCUSDT", "IC MARKETS:1"}
-	client, err := ingestor.NewClient(apiKey, symbols, brokers)
+	publisher, err := kafka.NewPublisher()
 	if err != nil {
-		slog.Error("Failed to create ingestor client", "error", err)
+		slog.Error("Failed to connect to Kafka", "error", err)
 		os.Exit(1)
 	}
+	client := ingestor.NewClient(apiKey, baseSymbols, publisher)
 
 	// 4. Start Client
 	if err := client.Start(); err != nil {
@@ -49,7 +79,26 @@ func main() {
 		os.Exit(1)
 	}
 
-	slog.Info("Ingestor service started", "symbols", symbols)
+	slog.Info("Ingestor service started", "symbols", baseSymbols)
+
+	// Follow the symbol registry so that new watchlist symbols are streamed
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if syncInterval > 0 {
+		registry, err := symbols.NewRegistry(connStr)
+		if err != nil {
+			slog.Error("Failed to connect to symbol registry", "error", err)
+			os.Exit(1)
+		}
+		defer registry.Close()
+		if err := registry.AutoMigrate(); err != nil {
+			slog.Error("Failed to migrate symbol registry", "error", err)
+			os.Exit(1)
+		}
+
+		go client.SyncSubscriptions(ctx, registry, syncInterval)
+		slog.Info("Following symbol registry", "interval", syncInterval)
+	}
 
 	// 5. Wait for Shutdown Signal
 	stop := make(chan os.Signal, 1)
@@ -59,9 +108,13 @@ func main() {
 	slog.Info("Shutdown signal received", "signal", sig)
 
 	// 6. Graceful Shutdown
+	cancel()
 	if err := client.Close(); err != nil {
 		slog.Error("Error closing client", "error", err)
 	}
+	if err := publisher.Close(); err != nil {
+		slog.Error("Error closing publisher", "error", err)
+	}
 
 	time.Sleep(500 * time.Millisecond)
 	slog.Info("Ingestor service stopped")
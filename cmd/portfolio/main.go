@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/bus"
+	"github.com/tiongMax/gostocks/internal/portfolio"
+	pb "github.com/tiongMax/gostocks/proto/portfolio"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	// Configure JSON logger
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+
+	// 1. Database Connection String
+	connStr := os.Getenv("DATABASE_URL")
+	if connStr == "" {
+		connStr = "user=user password=password dbname=gostocks sslmode=disable host=127.0.0.1 port=5433"
+	}
+
+	// 2. gRPC Port
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "50052"
+	}
+
+	// 3. Redis and Kafka Configuration
+	redisAddr := os.Getenv("REDIS_ADDR")
+	if redisAddr == "" {
+		redisAddr = "localhost:6379"
+	}
+
+	kafkaBrokers := os.Getenv("KAFKA_BROKERS")
+	if kafkaBrokers == "" {
+		kafkaBrokers = "localhost:9092"
+	}
+	kafka := bus.NewKafka(strings.Split(kafkaBrokers, ","))
+
+	kafkaTopic := os.Getenv("KAFKA_TOPIC")
+	if kafkaTopic == "" {
+		kafkaTopic = "market_ticks"
+	}
+
+	// Minimum interval between valuations on a WatchPortfolio stream
+	streamInterval := time.Second
+	if v := os.Getenv("PORTFOLIO_STREAM_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			slog.Error("Invalid PORTFOLIO_STREAM_INTERVAL", "value", v)
+			os.Exit(1)
+		}
+		streamInterval = d
+	}
+
+	// 4. Connect to Database and migrate
+	slog.Info("Connecting to database...")
+	store, err := portfolio.NewStore(connStr)
+	if err != nil {
+		slog.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	if err := store.AutoMigrate(); err != nil {
+		slog.Error("Failed to auto-migrate schema", "error", err)
+		os.Exit(1)
+	}
+
+	// 5. Connect to Redis (latest prices and session opens)
+	quotes, err := portfolio.NewRedisQuotes(redisAddr)
+	if err != nil {
+		slog.Error("Failed to connect to Redis", "error", err)
+		os.Exit(1)
+	}
+	defer quotes.Close()
+
+	// 6. Follow the tick stream
+	ctx, cancel := context.WithCancel(context.Background())
+	tracker := portfolio.NewTracker(kafka, kafkaTopic, quotes)
+	go func() {
+		if err := tracker.Start(ctx); err != nil {
+			slog.Error("Tick tracker failed", "error", err)
+			cancel()
+		}
+	}()
+
+	// 7. Create gRPC Server
+	grpcServer := grpc.NewServer(
+		// Detect clients that vanished from long-lived WatchPortfolio streams
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    30 * time.Second,
+			Timeout: 10 * time.Second,
+		}),
+	)
+	pb.RegisterPortfolioServiceServer(grpcServer, portfolio.NewServer(store, tracker, streamInterval))
+	reflection.Register(grpcServer)
+
+	// 8. Start gRPC Listener
+	listener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		slog.Error("Failed to listen on port", "port", grpcPort, "error", err)
+		os.Exit(1)
+	}
+
+	go func() {
+		slog.Info("Portfolio Service gRPC server listening", "port", grpcPort)
+		if err := grpcServer.Serve(listener); err != nil {
+			slog.Error("Failed to serve gRPC", "error", err)
+			cancel()
+		}
+	}()
+
+	// 9. Wait for shutdown signal
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case sig := <-stop:
+		slog.Info("Shutdown signal received", "signal", sig)
+	case <-ctx.Done():
+		slog.Info("Context cancelled, shutting down")
+	}
+
+	// Graceful shutdown
+	cancel()
+	grpcServer.GracefulStop()
+	slog.Info("Portfolio Service stopped")
+}
@@ -2,13 +2,22 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"runtime"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/tiongMax/gostocks/internal/bus"
+	"github.com/tiongMax/gostocks/internal/freshness"
+	"github.com/tiongMax/gostocks/internal/history"
+	"github.com/tiongMax/gostocks/internal/lag"
 	"github.com/tiongMax/gostocks/internal/processor"
 )
 
@@ -27,13 +36,91 @@ func main() {
 	if kafkaBrokers == "" {
 		kafkaBrokers = "localhost:9092"
 	}
-	brokers := strings.Split(kafkaBrokers, ",")
+	kafka := bus.NewKafka(strings.Split(kafkaBrokers, ","))
+
+	redisAddr := os.Getenv("REDIS_ADDR")
+	if redisAddr == "" {
+		redisAddr = "localhost:6379"
+	}
+
+	// Day stats (open/high/low/volume) follow the exchange's trading day
+	dayTimezone := os.Getenv("DAY_STATS_TIMEZONE")
+	if dayTimezone == "" {
+		dayTimezone = "America/New_York"
+	}
+	dayLocation, err := time.LoadLocation(dayTimezone)
+	if err != nil {
+		slog.Error("Invalid DAY_STATS_TIMEZONE", "value", dayTimezone, "error", err)
+		os.Exit(1)
+	}
+
+	sla, err := freshness.ParseSLA(os.Getenv("FRESHNESS_SLA"), os.Getenv("FRESHNESS_SLA_OVERRIDES"), time.Minute)
+	if err != nil {
+		slog.Error("Invalid freshness configuration", "error", err)
+		os.Exit(1)
+	}
+
+	connStr := os.Getenv("DATABASE_URL")
+	if connStr == "" {
+		connStr = "user=user password=password dbname=gostocks sslmode=disable host=127.0.0.1 port=5433"
+	}
+
+	// Consumer lag past which ticks are conflated, and where it is served
+	thresholds, err := lag.ParseThresholds(os.Getenv("LAG_OFFSET_THRESHOLD"), os.Getenv("LAG_TIME_THRESHOLD"), lag.DefaultThresholds)
+	if err != nil {
+		slog.Error("Invalid lag thresholds", "error", err)
+		os.Exit(1)
+	}
+	debugPort := os.Getenv("DEBUG_PORT")
+	if debugPort == "" {
+		debugPort = "6061"
+	}
+
+	// Ticks of different symbols are processed concurrently within a partition
+	workers := runtime.GOMAXPROCS(0)
+	if v := os.Getenv("CONSUMER_WORKERS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			slog.Error("Invalid CONSUMER_WORKERS", "value", v)
+			os.Exit(1)
+		}
+		workers = n
+	}
+
+	tickRetention := parseRetention("TICK_RETENTION", 30*24*time.Hour)
+	candleRetention := parseRetention("CANDLE_RETENTION", 0)
+
+	// 3. Connect to Redis (Speed Layer)
+	slog.Info("Connecting to Redis...")
+	writer, err := processor.NewRedisWriter(redisAddr, dayLocation)
+	if err != nil {
+		slog.Error("Failed to connect to Redis", "error", err)
+		os.Exit(1)
+	}
+	defer writer.Close()
+
+	// 4. Connect to Postgres (History Store)
+	slog.Info("Connecting to history store...")
+	hist, err := history.NewWriter(connStr, tickRetention, candleRetention)
+	if err != nil {
+		slog.Error("Failed to connect to history store", "error", err)
+		os.Exit(1)
+	}
+	defer hist.Close()
+
+	// 5. Initialize Consumer
+	dlq, err := kafka.NewPublisher()
+	if err != nil {
+		slog.Error("Failed to connect to Kafka", "error", err)
+		os.Exit(1)
+	}
+	defer dlq.Close()
 
-	// 3. Initialize Consumer
 	slog.Info("Starting Processor Service...")
-	consumer := processor.NewConsumer(brokers, "market_ticks")
+	watchdog := processor.NewWatchdog(sla, writer, 5*time.Second)
+	consumer := processor.NewConsumer(kafka, dlq, "market_ticks", writer, hist, watchdog, thresholds, workers)
 
-	// 4. Handle Shutdown Signals
+	// 6. Handle Shutdown Signals
 	ctx, cancel := context.WithCancel(context.Background())
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -44,7 +131,19 @@ func main() {
 		cancel()
 	}()
 
-	// 5. Start Processing
+	// 7. Start Processing
+	go hist.Run(ctx, time.Hour)
+	go watchdog.Run(ctx)
+
+	debug := lag.NewDebugServer(":"+debugPort, consumer.Lag())
+	go func() {
+		slog.Info("Debug server listening", "port", debugPort)
+		if err := debug.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Debug server failed", "error", err)
+		}
+	}()
+	defer debug.Close()
+
 	if err := consumer.Start(ctx); err != nil {
 		slog.Error("Processor failed", "error", err)
 		os.Exit(1)
@@ -52,3 +151,18 @@ func main() {
 
 	slog.Info("Processor Service stopped successfully")
 }
+
+// parseRetention reads a retention period from the environment.
+// "0" keeps data forever.
+func parseRetention(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		slog.Error("Invalid retention", "variable", name, "value", v)
+		os.Exit(1)
+	}
+	return d
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/tiongMax/gostocks/internal/bus"
+	"github.com/tiongMax/gostocks/internal/lag"
+	"github.com/tiongMax/gostocks/internal/trading"
+	pb "github.com/tiongMax/gostocks/proto/trading"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	// Configure JSON logger
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+
+	// 1. Database Connection String
+	connStr := os.Getenv("DATABASE_URL")
+	if connStr == "" {
+		connStr = "user=user password=password dbname=gostocks sslmode=disable host=127.0.0.1 port=5433"
+	}
+
+	// 2. gRPC Port
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "50053"
+	}
+
+	// 3. Kafka Configuration
+	kafkaBrokers := os.Getenv("KAFKA_BROKERS")
+	if kafkaBrokers == "" {
+		kafkaBrokers = "localhost:9092"
+	}
+	kafka := bus.NewKafka(strings.Split(kafkaBrokers, ","))
+
+	kafkaTopic := os.Getenv("KAFKA_TOPIC")
+	if kafkaTopic == "" {
+		kafkaTopic = "market_ticks"
+	}
+
+	eventsTopic := os.Getenv("ORDER_EVENTS_TOPIC")
+	if eventsTopic == "" {
+		eventsTopic = "order_events"
+	}
+
+	// Where the matching engine's lag and fill counts are served
+	debugPort := os.Getenv("DEBUG_PORT")
+	if debugPort == "" {
+		debugPort = "6063"
+	}
+
+	// Paper account and execution settings
+	startingCash := parseAmount("PAPER_STARTING_CASH", 100000)
+	model := trading.ExecutionModel{
+		SlippageBps: parseAmount("PAPER_SLIPPAGE_BPS", 5),
+		FeePerOrder: parseAmount("PAPER_FEE_PER_ORDER", 0),
+		FeeBps:      parseAmount("PAPER_FEE_BPS", 0),
+	}
+
+	// 4. Connect to Database and migrate
+	slog.Info("Connecting to database...")
+	store, err := trading.NewStore(connStr, startingCash)
+	if err != nil {
+		slog.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	if err := store.AutoMigrate(); err != nil {
+		slog.Error("Failed to auto-migrate schema", "error", err)
+		os.Exit(1)
+	}
+
+	// 5. Connect the producer of order events and dead letters
+	publisher, err := kafka.NewPublisher()
+	if err != nil {
+		slog.Error("Failed to connect to Kafka", "error", err)
+		os.Exit(1)
+	}
+	defer publisher.Close()
+	events := trading.NewEventPublisher(publisher, eventsTopic)
+
+	// 6. Start the matching engine
+	ctx, cancel := context.WithCancel(context.Background())
+	engine := trading.NewEngine(kafka, publisher, kafkaTopic, store, model, events)
+	go func() {
+		slog.Info("Starting matching engine", "slippage_bps", model.SlippageBps,
+			"fee_per_order", model.FeePerOrder, "fee_bps", model.FeeBps)
+		if err := engine.Start(ctx); err != nil {
+			slog.Error("Matching engine failed", "error", err)
+			cancel()
+		}
+	}()
+
+	debug := lag.NewDebugServer(":"+debugPort, engine.Lag())
+	go func() {
+		slog.Info("Debug server listening", "port", debugPort)
+		if err := debug.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Debug server failed", "error", err)
+		}
+	}()
+	defer debug.Close()
+
+	// 7. Create gRPC Server
+	grpcServer := grpc.NewServer()
+	pb.RegisterTradingServiceServer(grpcServer, trading.NewServer(store, events))
+	reflection.Register(grpcServer)
+
+	// 8. Start gRPC Listener
+	listener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		slog.Error("Failed to listen on port", "port", grpcPort, "error", err)
+		os.Exit(1)
+	}
+
+	go func() {
+		slog.Info("Trading Service gRPC server listening", "port", grpcPort)
+		if err := grpcServer.Serve(listener); err != nil {
+			slog.Error("Failed to serve gRPC", "error", err)
+			cancel()
+		}
+	}()
+
+	// 9. Wait for shutdown signal
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case sig := <-stop:
+		slog.Info("Shutdown signal received", "signal", sig)
+	case <-ctx.Done():
+		slog.Info("Context cancelled, shutting down")
+	}
+
+	// Graceful shutdown
+	cancel()
+	grpcServer.GracefulStop()
+	slog.Info("Trading Service stopped")
+}
+
+// parseAmount reads a non-negative number from the environment, exiting on
+// invalid values.
+func parseAmount(name string, def float64) float64 {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 {
+		slog.Error("Invalid "+name, "value", v)
+		os.Exit(1)
+	}
+	return f
+}
@@ -0,0 +1,235 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/rpcerror"
+	"github.com/tiongMax/gostocks/internal/symbols"
+	"github.com/tiongMax/gostocks/internal/trading"
+	pb "github.com/tiongMax/gostocks/proto/alert"
+	pbt "github.com/tiongMax/gostocks/proto/trading"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxActionAttempts bounds how often an order is retried while the
+	// Trading Service is unreachable before the action is marked FAILED.
+	maxActionAttempts = 5
+
+	// actionTimeout bounds a single PlaceOrder call.
+	actionTimeout = 5 * time.Second
+
+	// actionBatchSize caps the pending actions retried per pass.
+	actionBatchSize = 100
+)
+
+// ActionRunner places the paper orders of triggered alerts through the
+// Trading Service. A trigger with an action is delivered to the notifiers
+// once its order was placed or refused, so failures reach users the same
+// way triggers do.
+//
+// Every order is placed with a client order ID derived from the trigger,
+// which the Trading Service deduplicates: retrying a trigger after a lost
+// response or a crash never places a second order.
+type ActionRunner struct {
+	store     *Store
+	trading   pbt.TradingServiceClient
+	notifiers []Notifier
+	interval  time.Duration
+}
+
+// NewActionRunner creates an ActionRunner that retries pending actions every
+// interval and delivers resolved triggers through each of the notifiers.
+func NewActionRunner(store *Store, trading pbt.TradingServiceClient, interval time.Duration, notifiers ...Notifier) *ActionRunner {
+	return &ActionRunner{
+		store:     store,
+		trading:   trading,
+		notifiers: notifiers,
+		interval:  interval,
+	}
+}
+
+// Start retries actions left pending until the context is cancelled. Only
+// triggers older than the interval are picked up, leaving recent ones to the
+// consumer that recorded them.
+func (r *ActionRunner) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.retry(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *ActionRunner) retry(ctx context.Context) {
+	triggers, err := r.store.PendingActions(time.Now().Add(-r.interval), actionBatchSize)
+	if err != nil {
+		slog.Error("Failed to load pending actions", "error", err)
+		return
+	}
+	for i := range triggers {
+		t := &triggers[i]
+		r.Run(ctx, &t.Alert, t)
+	}
+}
+
+// Run places the order of a recorded trigger and stores the outcome. If the
+// Trading Service could not be reached the action stays pending for Start to
+// retry; otherwise the trigger is delivered.
+func (r *ActionRunner) Run(ctx context.Context, alert *Alert, trigger *AlertTrigger) {
+	result := r.place(ctx, alert, trigger)
+	recorded, err := r.store.RecordActionResult(trigger.ID, result)
+	if err != nil {
+		slog.Error("Failed to record action result", "trigger_id", trigger.ID, "error", err)
+		return
+	}
+	if !recorded {
+		// A concurrent run resolved the action and delivered the trigger
+		return
+	}
+	trigger.Action = result
+
+	switch result.Status {
+	case ActionPending:
+		slog.Warn("Order action will be retried",
+			"trigger_id", trigger.ID, "attempts", result.Attempts, "error", result.Error)
+		return
+	case ActionFailed:
+		slog.Warn("Order action failed", "trigger_id", trigger.ID, "user_id", alert.UserID, "error", result.Error)
+	}
+	deliver(ctx, r.store, r.notifiers, alert, trigger)
+}
+
+// place sends the trigger's order to the Trading Service.
+func (r *ActionRunner) place(ctx context.Context, alert *Alert, trigger *AlertTrigger) ActionResult {
+	ctx, cancel := context.WithTimeout(ctx, actionTimeout)
+	defer cancel()
+
+	a := alert.Action
+	result := trigger.Action
+	result.Attempts++
+	order, err := r.trading.PlaceOrder(ctx, &pbt.PlaceOrderRequest{
+		UserId:        int32(alert.UserID),
+		Symbol:        a.Symbol,
+		Side:          pbt.Side(pbt.Side_value[a.Side]),
+		Type:          pbt.OrderType(pbt.OrderType_value[a.Type]),
+		Quantity:      a.Quantity,
+		LimitPrice:    a.LimitPrice,
+		StopPrice:     a.StopPrice,
+		ClientOrderId: actionOrderID(trigger.ID),
+	})
+	switch {
+	case err == nil:
+		result.Status, result.OrderID, result.Error = ActionPlaced, order.Id, ""
+	case retryable(err) && result.Attempts < maxActionAttempts:
+		result.Error = status.Convert(err).Message()
+	default:
+		result.Status, result.Error = ActionFailed, status.Convert(err).Message()
+	}
+	return result
+}
+
+// actionOrderID is the client order ID of a trigger's order.
+func actionOrderID(triggerID int64) string {
+	return fmt.Sprintf("alert-trigger-%d", triggerID)
+}
+
+// retryable reports whether a PlaceOrder error may succeed on a later
+// attempt, as opposed to the order being refused.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+// orderAction validates the action of a new alert. The symbol defaults to
+// the alert's, or for portfolio alerts to the symbol of the rule.
+func orderAction(a *pb.OrderAction, alert *Alert) (OrderAction, error) {
+	symbol := a.Symbol
+	if symbol == "" {
+		symbol = alert.Symbol
+		if alert.Kind == KindPortfolio {
+			symbol = alert.Portfolio.Symbol
+		}
+	}
+	symbol, err := symbols.Normalize(symbol)
+	if err != nil {
+		return OrderAction{}, rpcerror.InvalidField("action.symbol", err.Error())
+	}
+
+	action := OrderAction{
+		Symbol:     symbol,
+		Quantity:   a.Quantity,
+		LimitPrice: a.LimitPrice,
+		StopPrice:  a.StopPrice,
+	}
+	switch a.Side {
+	case pbt.Side_BUY:
+		action.Side = trading.SideBuy
+	case pbt.Side_SELL:
+		action.Side = trading.SideSell
+	}
+	switch a.Type {
+	case pbt.OrderType_MARKET:
+		action.Type = trading.TypeMarket
+	case pbt.OrderType_LIMIT:
+		action.Type = trading.TypeLimit
+	case pbt.OrderType_STOP:
+		action.Type = trading.TypeStop
+	case pbt.OrderType_STOP_LIMIT:
+		action.Type = trading.TypeStopLimit
+	}
+
+	// The order is checked now with the Trading Service's rules, rather than
+	// failing when the alert triggers
+	if err := trading.ValidateOrder(action.Side, action.Type, action.Quantity, action.LimitPrice, action.StopPrice); err != nil {
+		var invalid *trading.OrderError
+		if errors.As(err, &invalid) {
+			return OrderAction{}, rpcerror.InvalidField("action."+invalid.Field, invalid.Description)
+		}
+		return OrderAction{}, rpcerror.InvalidField("action", err.Error())
+	}
+	return action, nil
+}
+
+// actionToProto converts an alert's action to its proto form, or nil if the
+// alert has none.
+func actionToProto(a OrderAction) *pb.OrderAction {
+	if !a.Set() {
+		return nil
+	}
+	return &pb.OrderAction{
+		Symbol:     a.Symbol,
+		Side:       pbt.Side(pbt.Side_value[a.Side]),
+		Type:       pbt.OrderType(pbt.OrderType_value[a.Type]),
+		Quantity:   a.Quantity,
+		LimitPrice: a.LimitPrice,
+		StopPrice:  a.StopPrice,
+	}
+}
+
+// actionResultToProto converts a trigger's action outcome to its proto form,
+// or nil if the alert had no action.
+func actionResultToProto(r ActionResult) *pb.ActionResult {
+	if r.Status == "" {
+		return nil
+	}
+	return &pb.ActionResult{
+		Status:   pb.ActionStatus(pb.ActionStatus_value["ACTION_"+r.Status]),
+		OrderId:  r.OrderID,
+		Error:    r.Error,
+		Attempts: int32(r.Attempts),
+	}
+}
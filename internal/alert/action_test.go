@@ -0,0 +1,97 @@
+package alert
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/tiongMax/gostocks/proto/alert"
+	pbt "github.com/tiongMax/gostocks/proto/trading"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeTrading answers PlaceOrder with err, or with order 42 if err is nil.
+type fakeTrading struct {
+	pbt.TradingServiceClient
+	err error
+	req *pbt.PlaceOrderRequest
+}
+
+func (f *fakeTrading) PlaceOrder(ctx context.Context, req *pbt.PlaceOrderRequest, opts ...grpc.CallOption) (*pbt.Order, error) {
+	f.req = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pbt.Order{Id: 42}, nil
+}
+
+func TestActionRunnerPlace(t *testing.T) {
+	alert := &Alert{UserID: 7, Action: OrderAction{Symbol: "AAPL", Side: "BUY", Type: "MARKET", Quantity: 10}}
+
+	tests := []struct {
+		name     string
+		err      error
+		attempts int
+		want     ActionResult
+	}{
+		{"placed", nil, 0, ActionResult{Status: ActionPlaced, OrderID: 42, Attempts: 1}},
+		{"unreachable is retried", status.Error(codes.Unavailable, "connection refused"), 0,
+			ActionResult{Status: ActionPending, Error: "connection refused", Attempts: 1}},
+		{"unreachable on the last attempt", status.Error(codes.Unavailable, "connection refused"), maxActionAttempts - 1,
+			ActionResult{Status: ActionFailed, Error: "connection refused", Attempts: maxActionAttempts}},
+		{"refused", status.Error(codes.FailedPrecondition, "quantity exceeds the position held"), 0,
+			ActionResult{Status: ActionFailed, Error: "quantity exceeds the position held", Attempts: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trading := &fakeTrading{err: tt.err}
+			r := NewActionRunner(nil, trading, 0)
+			trigger := &AlertTrigger{ID: 9, Action: ActionResult{Status: ActionPending, Attempts: tt.attempts}}
+
+			got := r.place(context.Background(), alert, trigger)
+			if got != tt.want {
+				t.Errorf("place() = %+v, want %+v", got, tt.want)
+			}
+			if trading.req.ClientOrderId != "alert-trigger-9" || trading.req.UserId != 7 || trading.req.Side != pbt.Side_BUY {
+				t.Errorf("PlaceOrder request = %v", trading.req)
+			}
+		})
+	}
+}
+
+func TestOrderAction(t *testing.T) {
+	priceAlert := &Alert{Kind: KindPrice, Symbol: "AAPL"}
+	weightAlert := &Alert{Kind: KindPortfolio, Portfolio: PortfolioRule{Metric: MetricPositionWeight, Symbol: "NVDA"}}
+	pnlAlert := &Alert{Kind: KindPortfolio, Portfolio: PortfolioRule{Metric: MetricUnrealizedPnL}}
+
+	tests := []struct {
+		name   string
+		action *pb.OrderAction
+		alert  *Alert
+		want   OrderAction
+		ok     bool
+	}{
+		{"market buy of the alert's symbol", &pb.OrderAction{Side: pbt.Side_BUY, Type: pbt.OrderType_MARKET, Quantity: 10}, priceAlert,
+			OrderAction{Symbol: "AAPL", Side: "BUY", Type: "MARKET", Quantity: 10}, true},
+		{"sell of the weighted position", &pb.OrderAction{Side: pbt.Side_SELL, Type: pbt.OrderType_LIMIT, Quantity: 5, LimitPrice: 900}, weightAlert,
+			OrderAction{Symbol: "NVDA", Side: "SELL", Type: "LIMIT", Quantity: 5, LimitPrice: 900}, true},
+		{"explicit symbol", &pb.OrderAction{Symbol: "msft", Side: pbt.Side_SELL, Type: pbt.OrderType_STOP, Quantity: 1, StopPrice: 300}, pnlAlert,
+			OrderAction{Symbol: "MSFT", Side: "SELL", Type: "STOP", Quantity: 1, StopPrice: 300}, true},
+		{"no symbol for portfolio alert", &pb.OrderAction{Side: pbt.Side_SELL, Type: pbt.OrderType_MARKET, Quantity: 1}, pnlAlert, OrderAction{}, false},
+		{"missing side", &pb.OrderAction{Type: pbt.OrderType_MARKET, Quantity: 1}, priceAlert, OrderAction{}, false},
+		{"zero quantity", &pb.OrderAction{Side: pbt.Side_BUY, Type: pbt.OrderType_MARKET}, priceAlert, OrderAction{}, false},
+		{"limit without price", &pb.OrderAction{Side: pbt.Side_BUY, Type: pbt.OrderType_LIMIT, Quantity: 1}, priceAlert, OrderAction{}, false},
+		{"market with stop price", &pb.OrderAction{Side: pbt.Side_BUY, Type: pbt.OrderType_MARKET, Quantity: 1, StopPrice: 10}, priceAlert, OrderAction{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := orderAction(tt.action, tt.alert)
+			if (err == nil) != tt.ok || got != tt.want {
+				t.Errorf("orderAction() = %+v, %v; want %+v, ok %v", got, err, tt.want, tt.ok)
+			}
+		})
+	}
+}
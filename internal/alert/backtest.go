@@ -0,0 +1,130 @@
+package alert
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/history"
+)
+
+// maxBacktestPoints caps the ticks or candles replayed by one backtest.
+const maxBacktestPoints = 100000
+
+// PricePoint is a price observed at a point in time.
+type PricePoint struct {
+	Time  time.Time
+	Price float64
+}
+
+// BacktestTrigger is a trigger an alert would have recorded.
+type BacktestTrigger struct {
+	Time  time.Time
+	Price float64
+}
+
+// BacktestResult lists the hypothetical triggers of a backtest, oldest
+// first, with statistics on the points replayed.
+type BacktestResult struct {
+	Triggers       []BacktestTrigger
+	Points         int // Points evaluated
+	PointsInWindow int // Of which inside the alert's window
+	PointsMatched  int // Of which meeting the condition
+	MinPrice       float64
+	MaxPrice       float64
+	Truncated      bool      // Stopped at maxBacktestPoints
+	EvaluatedTo    time.Time // Time of the last point evaluated
+}
+
+// Backtest replays points, oldest first, through a price alert using the
+// same Matches evaluation as the live consumer. A live alert fires once; to
+// show how often it would have fired, the backtest re-arms it whenever a
+// point inside its window no longer meets the condition. The alert's status
+// and expiry are ignored.
+func Backtest(alert Alert, points []PricePoint, cal *HolidayCalendar) *BacktestResult {
+	alert.Status = StatusActive
+	alert.ExpiresAt = nil
+
+	r := &BacktestResult{Points: len(points)}
+	if len(points) == 0 {
+		return r
+	}
+	r.MinPrice, r.MaxPrice = math.Inf(1), math.Inf(-1)
+	r.EvaluatedTo = points[len(points)-1].Time
+
+	armed := true
+	for _, p := range points {
+		r.MinPrice = math.Min(r.MinPrice, p.Price)
+		r.MaxPrice = math.Max(r.MaxPrice, p.Price)
+		if !alert.ActiveAt(p.Time, cal) {
+			continue
+		}
+		r.PointsInWindow++
+		if !alert.Matches(p.Price, p.Time, cal) {
+			armed = true
+			continue
+		}
+		r.PointsMatched++
+		if armed {
+			r.Triggers = append(r.Triggers, BacktestTrigger{Time: p.Time, Price: p.Price})
+			armed = false
+		}
+	}
+	return r
+}
+
+// candlePoints converts candles to the prices an alert with the given
+// condition would have seen first: the high for ABOVE and the low for BELOW.
+func candlePoints(candles []history.Candle, condition string) []PricePoint {
+	points := make([]PricePoint, len(candles))
+	for i, c := range candles {
+		price := c.High
+		if condition == "BELOW" {
+			price = c.Low
+		}
+		points[i] = PricePoint{Time: c.Start, Price: price}
+	}
+	return points
+}
+
+// Backtester replays stored history through alerts.
+type Backtester struct {
+	history  *history.Reader
+	calendar *HolidayCalendar
+}
+
+// NewBacktester creates a Backtester reading ticks and candles from reader.
+// The holiday calendar may be nil if no alert uses SkipHolidays.
+func NewBacktester(reader *history.Reader, calendar *HolidayCalendar) *Backtester {
+	return &Backtester{history: reader, calendar: calendar}
+}
+
+// Run backtests a price alert over the ticks of its symbol in [from, to), or
+// over candles of the given interval if it is not 0.
+func (b *Backtester) Run(ctx context.Context, alert Alert, from, to time.Time, interval time.Duration) (*BacktestResult, error) {
+	var points []PricePoint
+	if interval == 0 {
+		ticks, err := b.history.Ticks(ctx, alert.Symbol, from, to, maxBacktestPoints+1)
+		if err != nil {
+			return nil, err
+		}
+		points = make([]PricePoint, len(ticks))
+		for i, t := range ticks {
+			points[i] = PricePoint{Time: t.Time, Price: t.Price}
+		}
+	} else {
+		candles, err := b.history.Candles(ctx, alert.Symbol, interval, from, to, maxBacktestPoints+1)
+		if err != nil {
+			return nil, err
+		}
+		points = candlePoints(candles, alert.Condition)
+	}
+
+	truncated := len(points) > maxBacktestPoints
+	if truncated {
+		points = points[:maxBacktestPoints]
+	}
+	r := Backtest(alert, points, b.calendar)
+	r.Truncated = truncated
+	return r, nil
+}
@@ -0,0 +1,64 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/history"
+)
+
+func TestBacktest(t *testing.T) {
+	// Monday 2026-03-02, one point per hour from 13:00 UTC (08:00 in New York)
+	start := time.Date(2026, 3, 2, 13, 0, 0, 0, time.UTC)
+	prices := []float64{149, 151, 152, 148, 150, 147, 155}
+	points := make([]PricePoint, len(prices))
+	for i, p := range prices {
+		points[i] = PricePoint{Time: start.Add(time.Duration(i) * time.Hour), Price: p}
+	}
+
+	tests := []struct {
+		name     string
+		alert    Alert
+		triggers []float64
+		inWindow int
+		matched  int
+	}{
+		{"re-armed after each crossing", Alert{Condition: "ABOVE", TargetPrice: 150}, []float64{151, 150, 155}, 7, 4},
+		{"below", Alert{Condition: "BELOW", TargetPrice: 148}, []float64{148, 147}, 7, 2},
+		{"never met", Alert{Condition: "ABOVE", TargetPrice: 200}, nil, 7, 0},
+		{"market hours only", Alert{Condition: "ABOVE", TargetPrice: 150, Window: USMarketHours}, []float64{152, 150, 155}, 5, 3},
+		{"status and expiry are ignored", Alert{Condition: "ABOVE", TargetPrice: 150, Status: StatusTriggered, ExpiresAt: &start}, []float64{151, 150, 155}, 7, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Backtest(tt.alert, points, nil)
+			if len(r.Triggers) != len(tt.triggers) {
+				t.Fatalf("got %d triggers %v, want %v", len(r.Triggers), r.Triggers, tt.triggers)
+			}
+			for i, want := range tt.triggers {
+				if r.Triggers[i].Price != want {
+					t.Errorf("trigger %d price = %v, want %v", i, r.Triggers[i].Price, want)
+				}
+			}
+			if r.Points != len(points) || r.PointsInWindow != tt.inWindow || r.PointsMatched != tt.matched {
+				t.Errorf("points = %d/%d/%d, want %d/%d/%d", r.Points, r.PointsInWindow, r.PointsMatched, len(points), tt.inWindow, tt.matched)
+			}
+			if r.MinPrice != 147 || r.MaxPrice != 155 || !r.EvaluatedTo.Equal(points[len(points)-1].Time) {
+				t.Errorf("range = %v..%v to %v", r.MinPrice, r.MaxPrice, r.EvaluatedTo)
+			}
+		})
+	}
+}
+
+func TestCandlePoints(t *testing.T) {
+	start := time.Date(2026, 3, 2, 14, 30, 0, 0, time.UTC)
+	candles := []history.Candle{{Start: start, Open: 150, High: 153, Low: 147, Close: 151}}
+
+	if p := candlePoints(candles, "ABOVE"); p[0].Price != 153 || !p[0].Time.Equal(start) {
+		t.Errorf("ABOVE point = %+v, want the high", p[0])
+	}
+	if p := candlePoints(candles, "BELOW"); p[0].Price != 147 {
+		t.Errorf("BELOW point = %+v, want the low", p[0])
+	}
+}
@@ -0,0 +1,93 @@
+package alert
+
+import (
+	"context"
+	"sync"
+)
+
+// Broker wakes up WatchAlerts streams when a trigger is recorded.
+// It is registered as the "stream" notification channel.
+//
+// The broker carries no payload: a woken stream reads everything after its
+// cursor from the trigger history. A subscriber's wake-up channel holds at most
+// one pending signal, so a slow client costs nothing while it catches up and
+// cannot lose events that were published in the meantime.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+// Subscription receives wake-ups for triggers matching its filter.
+type Subscription struct {
+	broker  *Broker
+	userID  int
+	symbols map[string]bool
+	wake    chan struct{}
+}
+
+// NewBroker creates an empty Broker.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscribe registers interest in triggers for a user (0 = all users) and
+// set of symbols (empty = all symbols). Call Close when done.
+func (b *Broker) Subscribe(userID int, symbols []string) *Subscription {
+	sub := &Subscription{
+		broker: b,
+		userID: userID,
+		wake:   make(chan struct{}, 1),
+	}
+	if len(symbols) > 0 {
+		sub.symbols = make(map[string]bool, len(symbols))
+		for _, s := range symbols {
+			sub.symbols[s] = true
+		}
+	}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+	return sub
+}
+
+// C returns the channel signalled when matching triggers may be available.
+func (s *Subscription) C() <-chan struct{} {
+	return s.wake
+}
+
+// Close unregisters the subscription.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	delete(s.broker.subs, s)
+	s.broker.mu.Unlock()
+}
+
+func (s *Subscription) matches(t *AlertTrigger) bool {
+	if s.userID > 0 && s.userID != t.UserID {
+		return false
+	}
+	return s.symbols == nil || s.symbols[t.Symbol]
+}
+
+func (b *Broker) Channel() string {
+	return "stream"
+}
+
+// Notify wakes every subscription matching the trigger without blocking.
+func (b *Broker) Notify(ctx context.Context, alert *Alert, trigger *AlertTrigger) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		if !sub.matches(trigger) {
+			continue
+		}
+		select {
+		case sub.wake <- struct{}{}:
+		default:
+			// A wake-up is already pending; the stream will see this trigger too.
+		}
+	}
+	return nil
+}
@@ -5,148 +5,385 @@ import (
 	"log/slog"
 	"time"
 
-	"github.com/IBM/sarama"
+	"github.com/tiongMax/gostocks/internal/bus"
+	"github.com/tiongMax/gostocks/internal/lag"
+	"github.com/tiongMax/gostocks/internal/portfolio"
 	stock "github.com/tiongMax/gostocks/proto/stock"
-	"google.golang.org/protobuf/proto"
 )
 
-// Consumer listens to Kafka and triggers alerts based on price conditions.
+// conflateLimit is the most ticks taken at once by a degraded partition.
+const conflateLimit = 1000
+
+// Consumer listens to the tick topic and triggers alerts based on price
+// conditions.
 type Consumer struct {
-	brokers []string
-	topic   string
-	store   *Store
-	groupID string
+	sub        bus.Subscriber
+	dlq        bus.Publisher
+	topic      string
+	store      *Store
+	calendar   *HolidayCalendar
+	portfolios *PortfolioValuer
+	actions    *ActionRunner
+	notifiers  []Notifier
+	groupID    string
+	monitor    *lag.Monitor
+	workers    int
 }
 
-// NewConsumer creates a new Kafka consumer for the Alert Service.
-func NewConsumer(brokers []string, topic string, store *Store) *Consumer {
+// NewConsumer creates a new tick consumer for the Alert Service.
+// The holiday calendar may be nil if no alert uses SkipHolidays, the
+// portfolio valuer nil to leave portfolio alerts unevaluated, and the action
+// runner nil to leave order actions pending.
+// Every trigger is delivered through each of the given notifiers.
+// Ticks that cannot be decoded or checked are dead-lettered through dlq.
+// Partitions lagging past thresholds are degraded. Each claimed partition
+// checks ticks on up to workers goroutines.
+func NewConsumer(sub bus.Subscriber, dlq bus.Publisher, topic string, store *Store, calendar *HolidayCalendar, portfolios *PortfolioValuer, actions *ActionRunner, thresholds lag.Thresholds, workers int, notifiers ...Notifier) *Consumer {
+	groupID := "alert-service-group"
 	return &Consumer{
-		brokers: brokers,
-		topic:   topic,
-		store:   store,
-		groupID: "alert-service-group",
+		sub:        sub,
+		dlq:        dlq,
+		topic:      topic,
+		store:      store,
+		calendar:   calendar,
+		portfolios: portfolios,
+		actions:    actions,
+		notifiers:  notifiers,
+		groupID:    groupID,
+		monitor:    lag.NewMonitor(groupID, thresholds),
+		workers:    workers,
 	}
 }
 
-// Start begins consuming messages from Kafka and checking alerts.
-func (c *Consumer) Start(ctx context.Context) error {
-	config := sarama.NewConfig()
-	config.Consumer.Return.Errors = true
-	config.Consumer.Offsets.Initial = sarama.OffsetOldest
-
-	group, err := sarama.NewConsumerGroup(c.brokers, c.groupID, config)
-	if err != nil {
-		return err
-	}
-	defer group.Close()
-
-	slog.Info("Connected to Kafka Consumer Group", "group", c.groupID, "topic", c.topic)
+// Lag returns the lag monitor of the consumer's partitions.
+func (c *Consumer) Lag() *lag.Monitor {
+	return c.monitor
+}
 
+// Start begins consuming ticks and checking alerts until ctx is done.
+func (c *Consumer) Start(ctx context.Context) error {
 	handler := &AlertGroupHandler{
-		store: c.store,
+		store:      c.store,
+		calendar:   c.calendar,
+		portfolios: c.portfolios,
+		actions:    c.actions,
+		notifiers:  c.notifiers,
+		dlq:        bus.NewDeadLetter(c.dlq, c.groupID, bus.DefaultRetry),
+		monitor:    c.monitor,
+		workers:    c.workers,
 	}
 
-	for {
-		if err := group.Consume(ctx, []string{c.topic}, handler); err != nil {
-			slog.Error("Error from consumer group", "error", err)
-			return err
-		}
-		if ctx.Err() != nil {
-			return nil
-		}
-	}
+	return c.sub.Subscribe(ctx, bus.Subscription{
+		Topics: []string{c.topic},
+		Group:  c.groupID,
+		Start:  bus.Oldest,
+	}, handler)
 }
 
-// AlertGroupHandler implements sarama.ConsumerGroupHandler
+// AlertGroupHandler checks the ticks of the claimed partitions against
+// alerts.
 type AlertGroupHandler struct {
-	store *Store
+	store      *Store
+	calendar   *HolidayCalendar
+	portfolios *PortfolioValuer
+	actions    *ActionRunner
+	notifiers  []Notifier
+	dlq        *bus.DeadLetter
+	monitor    *lag.Monitor
+	workers    int
 }
 
-func (h *AlertGroupHandler) Setup(sarama.ConsumerGroupSession) error {
-	return nil
+// alertCheck checks a tick against alerts.
+type alertCheck struct {
+	tick *stock.StockTick
+	msg  *bus.Message
 }
 
-func (h *AlertGroupHandler) Cleanup(sarama.ConsumerGroupSession) error {
-	return nil
-}
+// Consume checks every tick of a claim against alerts, on a pool of workers:
+// concurrently across symbols and in order for each. A tick is committed
+// once it and every tick before it are checked. Store errors are retried;
+// ticks that cannot be decoded, or still fail after retrying, are
+// dead-lettered, so that every tick is committed once it is dealt with.
+// When the claim is revoked, the checks running finish and what is done is
+// committed.
+//
+// While the partition is degraded, the ticks waiting to be checked are taken
+// at once and only the latest of each symbol is checked. Alerts on a price
+// that a symbol crossed and left again within them may then not fire.
+func (h *AlertGroupHandler) Consume(claim bus.Claim) error {
+	track := h.monitor.Track(claim)
+	defer track.Release()
 
-func (h *AlertGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	// Metrics
-	tickCount := 0
-	alertsTriggered := 0
-	ticker := time.NewTicker(10 * time.Second)
-	defer ticker.Stop()
+	pool := bus.NewPool(claim.Context(), h.workers, h.check)
+	defer func() {
+		pool.Close()
+		if done := pool.Committable(); done != nil {
+			claim.Commit(done)
+		}
+	}()
 
 	msgChan := claim.Messages()
-
 	for {
 		select {
 		case msg, ok := <-msgChan:
 			if !ok {
 				return nil
 			}
-
-			// 1. Deserialize the tick
-			var tick stock.StockTick
-			if err := proto.Unmarshal(msg.Value, &tick); err != nil {
-				slog.Error("Error unmarshaling message", "error", err)
-				continue
+			msgs := []*bus.Message{msg}
+			if track.Degraded() {
+				msgs, ok = lag.Drain(msg, msgChan, conflateLimit)
 			}
-			tickCount++
-
-			// 2. Check alerts for this symbol
-			triggered, err := h.checkAlerts(tick.Symbol, tick.Price)
-			if err != nil {
-				slog.Error("Error checking alerts", "error", err)
-				continue
+			own := msgs[:0]
+			for _, msg := range msgs {
+				pool.Track(msg)
+				if h.dlq.Owns(msg) {
+					own = append(own, msg)
+					continue
+				}
+				// Redriven to another group
+				pool.Done(msg)
+				track.Done(msg, time.Time{})
 			}
-			alertsTriggered += triggered
+			msgs = own
+			superseded := lag.Superseded(msgs)
+			skipped := make(map[string][]*bus.Message) // Superseded ticks by key
+
+			for i, msg := range msgs {
+				if superseded[i] {
+					// Done with the latest tick of its symbol
+					skipped[msg.Key] = append(skipped[msg.Key], msg)
+					track.Conflated(1)
+					continue
+				}
+				covered := append(skipped[msg.Key], msg)
+				delete(skipped, msg.Key)
 
-			// Mark message
-			session.MarkMessage(msg, "")
+				// 1. Deserialize the tick
+				tick, err := bus.Decode[stock.StockTick](msg)
+				if err != nil {
+					if h.dlq.Reject(claim.Context(), msg, err) != nil {
+						return nil
+					}
+					pool.Done(covered...)
+					track.Done(msg, time.Time{})
+					continue
+				}
 
-		case <-ticker.C:
-			if tickCount > 0 || alertsTriggered > 0 {
-				slog.Info("Metrics", "ticks_processed", tickCount, "alerts_triggered", alertsTriggered)
-				tickCount = 0
-				alertsTriggered = 0
+				// 2. Check alerts for this symbol
+				if !pool.Submit(msg.Key, alertCheck{tick: tick, msg: msg}, covered...) {
+					return nil
+				}
+				track.Done(msg, eventTime(tick, msg))
+			}
+			if !ok {
+				return nil
 			}
 
-		case <-session.Context().Done():
-			return nil
+		case <-pool.Progress():
+			// Commit messages
+			claim.Commit(pool.Committable())
 		}
 	}
 }
 
-// checkAlerts compares the current price against all active alerts for a symbol.
-func (h *AlertGroupHandler) checkAlerts(symbol string, price float64) (int, error) {
+// check checks a tick against alerts, retrying it on store errors and
+// dead-lettering it once the retries run out. It returns nil once the tick
+// may be committed. Triggers are recorded once per alert, so a retry does
+// not fire an alert twice.
+func (h *AlertGroupHandler) check(ctx context.Context, c alertCheck) error {
+	return h.dlq.Process(ctx, c.msg, func(ctx context.Context) error {
+		triggered, err := h.checkAlerts(ctx, c.tick, c.msg)
+		h.monitor.Add("alerts_triggered", int64(triggered))
+		return err
+	})
+}
+
+// eventTime returns when a tick traded, or when it was published if the feed
+// did not say.
+func eventTime(tick *stock.StockTick, msg *bus.Message) time.Time {
+	if tick.Timestamp == 0 {
+		return msg.Timestamp
+	}
+	return time.UnixMilli(tick.Timestamp)
+}
+
+// checkAlerts compares the tick price against all active alerts for its symbol,
+// then checks the portfolio alerts of every portfolio trading the symbol.
+// Alerts that have expired or whose window does not contain the tick time are skipped.
+// Each trigger is recorded with the bus position of the tick that caused it,
+// where it was first consumed if it was redriven. A store error does not stop
+// the other alerts from firing; the first one is returned, so that the tick
+// is retried.
+func (h *AlertGroupHandler) checkAlerts(ctx context.Context, tick *stock.StockTick, msg *bus.Message) (int, error) {
+	symbol, price := tick.Symbol, tick.Price
 	alerts, err := h.store.GetActiveAlertsBySymbol(symbol)
 	if err != nil {
 		return 0, err
 	}
 
+	tickTime := time.UnixMilli(tick.Timestamp)
+	if tick.Timestamp == 0 {
+		tickTime = time.Now()
+	}
+	partition, offset := bus.Origin(msg)
+
+	triggered := 0
+	var firstErr error
+	for i := range alerts {
+		alert := &alerts[i]
+		if !alert.Matches(price, tickTime, h.calendar) {
+			continue
+		}
+
+		trigger := &AlertTrigger{
+			AlertID:       alert.ID,
+			UserID:        alert.UserID,
+			Kind:          KindPrice,
+			Symbol:        symbol,
+			Condition:     alert.Condition,
+			TargetPrice:   alert.TargetPrice,
+			TriggerPrice:  price,
+			TickTimestamp: tickTime,
+			Partition:     partition,
+			Offset:        offset,
+		}
+		fired, err := h.fire(ctx, alert, trigger)
+		if fired {
+			triggered++
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	n, err := h.checkPortfolioAlerts(ctx, tick, tickTime, msg)
+	if firstErr == nil {
+		firstErr = err
+	}
+	return triggered + n, firstErr
+}
+
+// checkPortfolioAlerts compares the live valuations of the portfolios trading
+// the tick's symbol against their active portfolio alerts. Only portfolios
+// with an active alert are looked up, and each is valued once per tick,
+// however many alerts watch it. Like checkAlerts, it returns the first
+// store or valuation error once the other alerts are checked.
+func (h *AlertGroupHandler) checkPortfolioAlerts(ctx context.Context, tick *stock.StockTick, tickTime time.Time, msg *bus.Message) (int, error) {
+	if h.portfolios == nil {
+		return 0, nil
+	}
+	alerts, err := h.store.GetActivePortfolioAlerts()
+	if err != nil || len(alerts) == 0 {
+		return 0, err
+	}
+
+	watched := make([]int, 0, len(alerts))
+	for i := range alerts {
+		watched = append(watched, alerts[i].Portfolio.PortfolioID)
+	}
+	ids, err := h.portfolios.store.PortfoliosTrading(tick.Symbol, watched)
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	trading := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		trading[id] = true
+	}
+
+	partition, offset := bus.Origin(msg)
+	valuations := make(map[int]*portfolio.Valuation)
 	triggered := 0
-	for _, alert := range alerts {
-		if ShouldTriggerAlert(alert.Condition, alert.TargetPrice, price) {
-			// Mark as triggered in database
-			if err := h.store.MarkAlertTriggered(alert.ID); err != nil {
-				slog.Error("Failed to mark alert as triggered", "alert_id", alert.ID, "error", err)
-				continue
+	var firstErr error
+	for i := range alerts {
+		alert := &alerts[i]
+		id := alert.Portfolio.PortfolioID
+		if !trading[id] || !alert.ActiveAt(tickTime, h.calendar) {
+			continue
+		}
+
+		v, ok := valuations[id]
+		if !ok {
+			v, err = h.portfolios.valueAt(ctx, id, tick.Symbol, tick.Price, tickTime)
+			if err != nil {
+				slog.Error("Failed to value portfolio", "portfolio_id", id, "error", err)
+				if firstErr == nil {
+					firstErr = err
+				}
 			}
+			valuations[id] = v
+		}
+		if v == nil {
+			continue
+		}
 
-			// Log the trigger
-			slog.Info("🔔 ALERT TRIGGERED!",
-				"user_id", alert.UserID,
-				"symbol", symbol,
-				"price", price,
-				"condition", alert.Condition,
-				"target_price", alert.TargetPrice)
+		value, ok := alert.Portfolio.MetricValue(v)
+		if !ok || !ShouldTriggerAlert(alert.Condition, alert.Portfolio.Threshold, value) {
+			continue
+		}
 
+		trigger := &AlertTrigger{
+			AlertID:       alert.ID,
+			UserID:        alert.UserID,
+			Kind:          KindPortfolio,
+			Symbol:        tick.Symbol,
+			Condition:     alert.Condition,
+			TargetPrice:   alert.Portfolio.Threshold,
+			TriggerPrice:  value,
+			Portfolio:     alert.Portfolio,
+			TickTimestamp: tickTime,
+			Partition:     partition,
+			Offset:        offset,
+		}
+		fired, err := h.fire(ctx, alert, trigger)
+		if fired {
 			triggered++
 		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return triggered, firstErr
+}
+
+// fire marks an alert as triggered, records the trigger and delivers it.
+// Triggers of alerts with an order action are recorded with the action
+// pending and delivered by the action runner once the order is placed.
+// It reports whether this call recorded the trigger, and the store error if
+// it could not be recorded.
+func (h *AlertGroupHandler) fire(ctx context.Context, alert *Alert, trigger *AlertTrigger) (bool, error) {
+	if alert.Action.Set() {
+		trigger.Action.Status = ActionPending
 	}
 
-	return triggered, nil
+	channels := make([]string, len(h.notifiers))
+	for i, n := range h.notifiers {
+		channels[i] = n.Channel()
+	}
+
+	// Mark as triggered and record history in one transaction
+	recorded, err := h.store.MarkAlertTriggered(trigger, channels)
+	if err != nil {
+		slog.Error("Failed to mark alert as triggered", "alert_id", alert.ID, "error", err)
+		return false, err
+	}
+	if !recorded {
+		// Another consumer or an earlier delivery of this tick got there first
+		return false, nil
+	}
+
+	if alert.Action.Set() && h.actions != nil {
+		h.actions.Run(ctx, alert, trigger)
+		return true, nil
+	}
+	deliver(ctx, h.store, h.notifiers, alert, trigger)
+	return true, nil
+}
+
+// Matches reports whether a price observed at t fires a price alert: the
+// alert must be active at t and the price must meet its condition. The
+// consumer evaluates live ticks with it and Backtest replays history with it.
+func (a *Alert) Matches(price float64, t time.Time, cal *HolidayCalendar) bool {
+	return a.ActiveAt(t, cal) && ShouldTriggerAlert(a.Condition, a.TargetPrice, price)
 }
 
 // ShouldTriggerAlert contains the pure logic for checking if an alert condition is met.
@@ -1,7 +1,20 @@
 package alert
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/tiongMax/gostocks/internal/bus"
+	"github.com/tiongMax/gostocks/internal/lag"
+	stock "github.com/tiongMax/gostocks/proto/stock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 func TestShouldTriggerAlert(t *testing.T) {
@@ -65,3 +78,63 @@ func TestShouldTriggerAlert(t *testing.T) {
 		})
 	}
 }
+
+// recordingPublisher keeps the messages published to it.
+type recordingPublisher struct {
+	mu   sync.Mutex
+	msgs []*bus.Message
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, msgs ...*bus.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.msgs = append(p.msgs, msgs...)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error { return nil }
+
+func TestCheckRetriesStoreErrors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The alert matches on every attempt, but it cannot be marked as
+	// triggered while the database is failing
+	const attempts = 3
+	for range attempts {
+		mock.ExpectQuery(`SELECT \* FROM "alerts"`).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "user_id", "kind", "symbol", "target_price", "condition", "status"}).
+				AddRow(1, 7, KindPrice, "AAPL", 150, "ABOVE", StatusActive))
+		mock.ExpectBegin()
+		mock.ExpectExec(`pg_advisory_xact_lock`).WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+	}
+
+	dead := &recordingPublisher{}
+	h := &AlertGroupHandler{
+		store:   &Store{db: gdb},
+		dlq:     bus.NewDeadLetter(dead, "alert-service-group", bus.RetryPolicy{Attempts: attempts, Backoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+		monitor: lag.NewMonitor("alert-service-group", lag.Thresholds{}),
+	}
+	tick := &stock.StockTick{Symbol: "AAPL", Price: 151, Timestamp: time.Now().UnixMilli()}
+	msg := &bus.Message{Topic: "market_ticks", Key: "AAPL", Partition: 0, Offset: 42}
+
+	// The tick is only committable once it is dead-lettered
+	if err := h.check(context.Background(), alertCheck{tick: tick, msg: msg}); err != nil {
+		t.Fatalf("check() = %v, want nil", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("tick not retried %d times: %v", attempts, err)
+	}
+	if len(dead.msgs) != 1 || dead.msgs[0].Headers[bus.HeaderAttempts] != "3" ||
+		!strings.Contains(dead.msgs[0].Headers[bus.HeaderError], "connection reset") {
+		t.Fatalf("dead letters = %+v, want the tick after %d attempts", dead.msgs, attempts)
+	}
+}
@@ -0,0 +1,80 @@
+package alert
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Notifier delivers alert triggers to users over a single channel.
+type Notifier interface {
+	// Channel is the name recorded in the trigger's notification status.
+	Channel() string
+	Notify(ctx context.Context, alert *Alert, trigger *AlertTrigger) error
+}
+
+// LogNotifier writes triggers to the structured log.
+type LogNotifier struct{}
+
+// NewLogNotifier creates a notifier for the "log" channel.
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Channel() string {
+	return "log"
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, alert *Alert, trigger *AlertTrigger) error {
+	switch trigger.Action.Status {
+	case ActionPlaced:
+		slog.Info("Alert placed order",
+			"alert_id", alert.ID,
+			"trigger_id", trigger.ID,
+			"user_id", alert.UserID,
+			"order_id", trigger.Action.OrderID)
+	case ActionFailed:
+		slog.Warn("Alert failed to place order",
+			"alert_id", alert.ID,
+			"trigger_id", trigger.ID,
+			"user_id", alert.UserID,
+			"error", trigger.Action.Error)
+	}
+
+	if alert.Kind == KindPortfolio {
+		slog.Info("🔔 PORTFOLIO ALERT TRIGGERED!",
+			"alert_id", alert.ID,
+			"trigger_id", trigger.ID,
+			"user_id", alert.UserID,
+			"portfolio_id", alert.Portfolio.PortfolioID,
+			"metric", alert.Portfolio.Metric,
+			"value", trigger.TriggerPrice,
+			"condition", alert.Condition,
+			"threshold", alert.Portfolio.Threshold,
+			"symbol", trigger.Symbol)
+		return nil
+	}
+	slog.Info("🔔 ALERT TRIGGERED!",
+		"alert_id", alert.ID,
+		"trigger_id", trigger.ID,
+		"user_id", alert.UserID,
+		"symbol", alert.Symbol,
+		"price", trigger.TriggerPrice,
+		"condition", alert.Condition,
+		"target_price", alert.TargetPrice)
+	return nil
+}
+
+// deliver sends a trigger on every channel and records the outcome.
+func deliver(ctx context.Context, store *Store, notifiers []Notifier, alert *Alert, trigger *AlertTrigger) {
+	for _, n := range notifiers {
+		status, errMsg := NotificationSent, ""
+		if err := n.Notify(ctx, alert, trigger); err != nil {
+			slog.Error("Failed to deliver notification",
+				"trigger_id", trigger.ID, "channel", n.Channel(), "error", err)
+			status, errMsg = NotificationFailed, err.Error()
+		}
+		if err := store.UpdateNotificationStatus(trigger.ID, n.Channel(), status, errMsg); err != nil {
+			slog.Error("Failed to record notification status", "trigger_id", trigger.ID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/portfolio"
+)
+
+// PortfolioValuer values portfolios for portfolio alerts.
+type PortfolioValuer struct {
+	store  *portfolio.Store
+	quotes portfolio.QuoteSource
+}
+
+// NewPortfolioValuer creates a valuer reading portfolios from store and
+// prices from quotes.
+func NewPortfolioValuer(store *portfolio.Store, quotes portfolio.QuoteSource) *PortfolioValuer {
+	return &PortfolioValuer{store: store, quotes: quotes}
+}
+
+// Owns reports whether portfolioID exists and belongs to userID.
+func (p *PortfolioValuer) Owns(portfolioID, userID int) (bool, error) {
+	if _, err := p.store.GetPortfolio(portfolioID, userID); err != nil {
+		if errors.Is(err, portfolio.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// valueAt values a portfolio with the tick's price overlaid on the latest
+// quotes. It returns nil if the portfolio no longer exists or a position has
+// no price, as alerting on a partial valuation would mislead.
+func (p *PortfolioValuer) valueAt(ctx context.Context, portfolioID int, symbol string, price float64, at time.Time) (*portfolio.Valuation, error) {
+	pf, err := p.store.GetPortfolio(portfolioID, 0)
+	if err != nil {
+		if errors.Is(err, portfolio.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	quotes := tickQuotes{base: p.quotes, symbol: symbol, price: price, time: at}
+	v, err := portfolio.Evaluate(ctx, p.store, quotes, pf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to value portfolio %d: %w", portfolioID, err)
+	}
+	if len(v.Unpriced) > 0 {
+		slog.Debug("Skipping portfolio alerts on unpriced positions", "portfolio_id", portfolioID, "unpriced", v.Unpriced)
+		return nil, nil
+	}
+	return v, nil
+}
+
+// tickQuotes overlays the tick being processed on a quote source, whose copy
+// of its price may not have caught up yet.
+type tickQuotes struct {
+	base   portfolio.QuoteSource
+	symbol string
+	price  float64
+	time   time.Time
+}
+
+func (q tickQuotes) Quotes(ctx context.Context, symbols []string) (map[string]portfolio.Quote, error) {
+	quotes, err := q.base.Quotes(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range symbols {
+		if s != q.symbol {
+			continue
+		}
+		quote := quotes[s]
+		if !q.time.Before(quote.Time) {
+			quote.Price, quote.Time = q.price, q.time
+			quotes[s] = quote
+		}
+	}
+	return quotes, nil
+}
+
+// MetricValue returns the rule's metric in a valuation, or false if the
+// metric is unknown. Weights are in percent; a position the portfolio does
+// not hold weighs 0.
+func (r PortfolioRule) MetricValue(v *portfolio.Valuation) (float64, bool) {
+	switch r.Metric {
+	case MetricUnrealizedPnL:
+		return v.UnrealizedPnL, true
+	case MetricDayChange:
+		return v.DayChange, true
+	case MetricDayChangePercent:
+		return v.DayChangePercent, true
+	case MetricMarketValue:
+		return v.MarketValue, true
+	case MetricPositionWeight:
+		if pos := v.Position(r.Symbol); pos != nil {
+			return pos.Weight * 100, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,44 @@
+package alert
+
+import (
+	"testing"
+
+	"github.com/tiongMax/gostocks/internal/portfolio"
+)
+
+func TestPortfolioRuleMetricValue(t *testing.T) {
+	v := &portfolio.Valuation{
+		MarketValue:      20000,
+		UnrealizedPnL:    -5500,
+		DayChange:        -400,
+		DayChangePercent: -1.96,
+		Positions: []portfolio.PositionValue{
+			{Symbol: "AAPL", Weight: 0.7},
+			{Symbol: "NVDA", Weight: 0.3},
+		},
+	}
+
+	tests := []struct {
+		name string
+		rule PortfolioRule
+		want float64
+		ok   bool
+	}{
+		{"unrealized P&L", PortfolioRule{Metric: MetricUnrealizedPnL}, -5500, true},
+		{"day change", PortfolioRule{Metric: MetricDayChange}, -400, true},
+		{"day change percent", PortfolioRule{Metric: MetricDayChangePercent}, -1.96, true},
+		{"market value", PortfolioRule{Metric: MetricMarketValue}, 20000, true},
+		{"position weight in percent", PortfolioRule{Metric: MetricPositionWeight, Symbol: "NVDA"}, 30, true},
+		{"weight of position not held", PortfolioRule{Metric: MetricPositionWeight, Symbol: "TSLA"}, 0, true},
+		{"unknown metric", PortfolioRule{Metric: "BETA"}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.rule.MetricValue(v)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("MetricValue() = %v, %v; want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
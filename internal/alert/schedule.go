@@ -0,0 +1,207 @@
+package alert
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Window restricts alert evaluation to a recurring local-time window,
+// e.g. weekdays 09:30–16:00 America/New_York. The zero value is always open.
+type Window struct {
+	Timezone     string `json:"timezone,omitempty"`      // IANA zone, empty means UTC
+	Start        string `json:"start,omitempty"`         // "HH:MM" local time (inclusive)
+	End          string `json:"end,omitempty"`           // "HH:MM" local time (exclusive)
+	Weekdays     int    `json:"weekdays,omitempty"`      // Bitmask, bit n = time.Weekday(n); 0 means every day
+	SkipHolidays bool   `json:"skip_holidays,omitempty"` // Closed on dates in the holiday calendar
+}
+
+// USMarketHours is the regular trading session of US equity exchanges.
+var USMarketHours = Window{
+	Timezone:     "America/New_York",
+	Start:        "09:30",
+	End:          "16:00",
+	Weekdays:     WeekdayMask(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday),
+	SkipHolidays: true,
+}
+
+// WeekdayMask builds a Window.Weekdays bitmask from the given days.
+func WeekdayMask(days ...time.Weekday) int {
+	mask := 0
+	for _, d := range days {
+		mask |= 1 << uint(d)
+	}
+	return mask
+}
+
+// IsZero reports whether the window places no restriction on evaluation.
+func (w Window) IsZero() bool {
+	return w.Start == "" && w.End == "" && w.Weekdays == 0 && !w.SkipHolidays
+}
+
+// Validate checks that the timezone and clock times can be parsed.
+func (w Window) Validate() error {
+	if _, err := w.location(); err != nil {
+		return err
+	}
+	if (w.Start == "") != (w.End == "") {
+		return fmt.Errorf("window start and end must be set together")
+	}
+	if w.Start != "" {
+		start, err := parseClock(w.Start)
+		if err != nil {
+			return fmt.Errorf("invalid window start: %w", err)
+		}
+		end, err := parseClock(w.End)
+		if err != nil {
+			return fmt.Errorf("invalid window end: %w", err)
+		}
+		if start == end {
+			return fmt.Errorf("window start and end must differ")
+		}
+	}
+	if w.Weekdays < 0 || w.Weekdays >= 1<<7 {
+		return fmt.Errorf("invalid weekday mask %d", w.Weekdays)
+	}
+	return nil
+}
+
+// Contains reports whether t falls inside the window. Times are converted to
+// the window's timezone first, so DST transitions are handled by the zone
+// database. A window whose end is before its start spans midnight and is
+// attributed to the day it opened on.
+func (w Window) Contains(t time.Time, cal *HolidayCalendar) bool {
+	if w.IsZero() {
+		return true
+	}
+
+	loc, err := w.location()
+	if err != nil {
+		return false
+	}
+	local := t.In(loc)
+	day := local
+
+	if w.Start != "" {
+		start, err := parseClock(w.Start)
+		if err != nil {
+			return false
+		}
+		end, err := parseClock(w.End)
+		if err != nil {
+			return false
+		}
+
+		minute := local.Hour()*60 + local.Minute()
+		if start < end {
+			if minute < start || minute >= end {
+				return false
+			}
+		} else {
+			switch {
+			case minute >= start:
+			case minute < end:
+				day = local.AddDate(0, 0, -1)
+			default:
+				return false
+			}
+		}
+	}
+
+	if w.Weekdays != 0 && w.Weekdays&(1<<uint(day.Weekday())) == 0 {
+		return false
+	}
+	if w.SkipHolidays && cal.IsHoliday(day) {
+		return false
+	}
+	return true
+}
+
+func (w Window) location() (*time.Location, error) {
+	if w.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", w.Timezone, err)
+	}
+	return loc, nil
+}
+
+// parseClock converts "HH:MM" to minutes after midnight.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// HolidayCalendar is a set of local dates on which windows with
+// SkipHolidays are closed. A nil calendar has no holidays.
+type HolidayCalendar struct {
+	dates map[string]string // "2006-01-02" -> holiday name
+}
+
+// LoadHolidayCalendar reads a calendar file with one date per line in
+// YYYY-MM-DD form, optionally followed by a name. Blank lines and lines
+// starting with '#' are ignored.
+func LoadHolidayCalendar(path string) (*HolidayCalendar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open holiday calendar: %w", err)
+	}
+	defer f.Close()
+
+	cal := &HolidayCalendar{dates: make(map[string]string)}
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		date, name, _ := strings.Cut(line, " ")
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid date %q", path, lineNo, date)
+		}
+		cal.dates[date] = strings.TrimSpace(name)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read holiday calendar: %w", err)
+	}
+	return cal, nil
+}
+
+// IsHoliday reports whether the calendar date of t (in t's location) is a holiday.
+func (c *HolidayCalendar) IsHoliday(t time.Time) bool {
+	if c == nil {
+		return false
+	}
+	_, ok := c.dates[t.Format(time.DateOnly)]
+	return ok
+}
+
+// Len returns the number of dates in the calendar.
+func (c *HolidayCalendar) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.dates)
+}
+
+// ActiveAt reports whether the alert should be evaluated against a tick at t:
+// it must still be ACTIVE, not past its expiry, and inside its window.
+func (a *Alert) ActiveAt(t time.Time, cal *HolidayCalendar) bool {
+	if a.Status != StatusActive {
+		return false
+	}
+	if a.ExpiresAt != nil && !t.Before(*a.ExpiresAt) {
+		return false
+	}
+	return a.Window.Contains(t, cal)
+}
@@ -0,0 +1,178 @@
+package alert
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWindowContains(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+
+	cal := &HolidayCalendar{dates: map[string]string{"2026-11-26": "Thanksgiving Day"}}
+
+	overnight := Window{
+		Timezone: "America/New_York",
+		Start:    "20:00",
+		End:      "04:00",
+		Weekdays: WeekdayMask(time.Friday),
+	}
+
+	tests := []struct {
+		name     string
+		window   Window
+		at       time.Time
+		expected bool
+	}{
+		{
+			name:     "zero window - always open",
+			window:   Window{},
+			at:       time.Date(2026, 3, 7, 3, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "market hours - open",
+			window:   USMarketHours,
+			at:       time.Date(2026, 3, 4, 10, 0, 0, 0, ny),
+			expected: true,
+		},
+		{
+			name:     "market hours - before open",
+			window:   USMarketHours,
+			at:       time.Date(2026, 3, 4, 9, 29, 0, 0, ny),
+			expected: false,
+		},
+		{
+			name:     "market hours - close is exclusive",
+			window:   USMarketHours,
+			at:       time.Date(2026, 3, 4, 16, 0, 0, 0, ny),
+			expected: false,
+		},
+		{
+			name:     "market hours - weekend",
+			window:   USMarketHours,
+			at:       time.Date(2026, 3, 7, 11, 0, 0, 0, ny),
+			expected: false,
+		},
+		{
+			name:     "market hours - holiday",
+			window:   USMarketHours,
+			at:       time.Date(2026, 11, 26, 11, 0, 0, 0, ny),
+			expected: false,
+		},
+		{
+			// 13:45 UTC is 09:45 EDT after the March 8 DST change, but 08:45 EST before it.
+			name:     "market hours - UTC tick after DST change",
+			window:   USMarketHours,
+			at:       time.Date(2026, 3, 9, 13, 45, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "market hours - UTC tick before DST change",
+			window:   USMarketHours,
+			at:       time.Date(2026, 3, 6, 13, 45, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "overnight - evening of opening day",
+			window:   overnight,
+			at:       time.Date(2026, 3, 6, 22, 0, 0, 0, ny),
+			expected: true,
+		},
+		{
+			name:     "overnight - after midnight belongs to previous day",
+			window:   overnight,
+			at:       time.Date(2026, 3, 7, 2, 0, 0, 0, ny),
+			expected: true,
+		},
+		{
+			name:     "overnight - after midnight of wrong day",
+			window:   overnight,
+			at:       time.Date(2026, 3, 6, 2, 0, 0, 0, ny),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.window.Contains(tt.at, cal)
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestAlertActiveAt(t *testing.T) {
+	now := time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC)
+	expiry := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		alert    Alert
+		expected bool
+	}{
+		{
+			name:     "active without expiry",
+			alert:    Alert{Status: StatusActive},
+			expected: true,
+		},
+		{
+			name:     "active before expiry",
+			alert:    Alert{Status: StatusActive, ExpiresAt: &expiry},
+			expected: true,
+		},
+		{
+			name:     "expiry already passed",
+			alert:    Alert{Status: StatusActive, ExpiresAt: &now},
+			expected: false,
+		},
+		{
+			name:     "expired status",
+			alert:    Alert{Status: StatusExpired},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.alert.ActiveAt(now, nil)
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestLoadHolidayCalendar(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.txt")
+	content := "# comment\n\n2026-12-25 Christmas Day\n2026-07-03\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cal, err := LoadHolidayCalendar(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cal.Len() != 2 {
+		t.Errorf("expected 2 dates, got %d", cal.Len())
+	}
+	if !cal.IsHoliday(time.Date(2026, 12, 25, 12, 0, 0, 0, time.UTC)) {
+		t.Error("expected 2026-12-25 to be a holiday")
+	}
+	if cal.IsHoliday(time.Date(2026, 12, 24, 12, 0, 0, 0, time.UTC)) {
+		t.Error("expected 2026-12-24 not to be a holiday")
+	}
+
+	if err := os.WriteFile(path, []byte("12/25/2026\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadHolidayCalendar(path); err == nil {
+		t.Error("expected error for malformed date")
+	}
+}
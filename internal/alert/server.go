@@ -2,61 +2,196 @@ package alert
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/tiongMax/gostocks/internal/history"
+	"github.com/tiongMax/gostocks/internal/rpcerror"
+	"github.com/tiongMax/gostocks/internal/symbols"
 	pb "github.com/tiongMax/gostocks/proto/alert"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// watchHeartbeatInterval is how often idle WatchAlerts streams get a heartbeat.
+// Each heartbeat also re-reads the history, picking up triggers recorded by
+// other Alert Service instances.
+const watchHeartbeatInterval = 15 * time.Second
+
 // Server implements the AlertService gRPC server.
 type Server struct {
 	pb.UnimplementedAlertServiceServer
-	store *Store
+	store           *Store
+	portfolios      *PortfolioValuer
+	actions         *ActionRunner
+	backtests       *Backtester
+	broker          *Broker
+	maxActiveAlerts int
 }
 
 // NewServer creates a new gRPC Alert Server with the given store.
-func NewServer(store *Store) *Server {
-	return &Server{store: store}
+// Portfolio alerts are rejected if portfolios is nil, order actions if
+// actions is nil, and backtests if backtests is nil.
+// The broker must also be registered as a notifier on the consumer so that
+// WatchAlerts streams are woken when triggers are recorded.
+// maxActiveAlerts caps each user's active alerts; 0 means no limit.
+func NewServer(store *Store, portfolios *PortfolioValuer, actions *ActionRunner, backtests *Backtester, broker *Broker, maxActiveAlerts int) *Server {
+	return &Server{
+		store:           store,
+		portfolios:      portfolios,
+		actions:         actions,
+		backtests:       backtests,
+		broker:          broker,
+		maxActiveAlerts: maxActiveAlerts,
+	}
 }
 
-// CreateAlert creates a new price alert for a user.
+// CreateAlert creates a new price or portfolio alert for a user.
 func (s *Server) CreateAlert(ctx context.Context, req *pb.CreateAlertRequest) (*pb.CreateAlertResponse, error) {
 	// Validate request
 	if req.UserId <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "user_id must be positive")
+		return nil, rpcerror.InvalidField("user_id", "must be positive")
 	}
-	if req.Symbol == "" {
-		return nil, status.Error(codes.InvalidArgument, "symbol is required")
+
+	alert := &Alert{UserID: int(req.UserId)}
+	switch req.Kind {
+	case pb.AlertKind_KIND_UNSPECIFIED, pb.AlertKind_PRICE:
+		if req.Symbol == "" {
+			return nil, rpcerror.InvalidField("symbol", "is required")
+		}
+		if req.TargetPrice <= 0 {
+			return nil, rpcerror.InvalidField("target_price", "must be positive")
+		}
+		if req.Portfolio != nil {
+			return nil, rpcerror.InvalidField("portfolio", "must not be set for PRICE alerts")
+		}
+		alert.Kind = KindPrice
+		alert.Symbol = strings.ToUpper(req.Symbol)
+		alert.TargetPrice = req.TargetPrice
+	case pb.AlertKind_PORTFOLIO:
+		if req.Symbol != "" {
+			return nil, rpcerror.InvalidField("symbol", "must not be set for PORTFOLIO alerts")
+		}
+		if req.TargetPrice != 0 {
+			return nil, rpcerror.InvalidField("target_price", "must not be set for PORTFOLIO alerts")
+		}
+		rule, err := s.portfolioRule(int(req.UserId), req.Portfolio)
+		if err != nil {
+			return nil, err
+		}
+		alert.Kind = KindPortfolio
+		alert.Portfolio = rule
+	default:
+		return nil, rpcerror.InvalidField("kind", "must be PRICE or PORTFOLIO")
 	}
-	if req.TargetPrice <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "target_price must be positive")
+
+	if req.Action != nil {
+		if s.actions == nil {
+			return nil, status.Error(codes.Unimplemented, "order actions are not enabled")
+		}
+		action, err := orderAction(req.Action, alert)
+		if err != nil {
+			return nil, err
+		}
+		alert.Action = action
 	}
+
 	if req.Condition == pb.AlertCondition_CONDITION_UNSPECIFIED {
-		return nil, status.Error(codes.InvalidArgument, "condition must be ABOVE or BELOW")
+		return nil, rpcerror.InvalidField("condition", "must be ABOVE or BELOW")
 	}
 
-	// Convert proto condition to string
-	condition := conditionToString(req.Condition)
+	if req.ExpiresAt < 0 {
+		return nil, rpcerror.InvalidField("expires_at", "must not be negative")
+	}
+	if req.ExpiresAt > 0 && !time.Unix(req.ExpiresAt, 0).After(time.Now()) {
+		return nil, rpcerror.InvalidField("expires_at", "must be in the future")
+	}
+	if req.MarketHoursOnly && req.Window != nil {
+		return nil, rpcerror.InvalidField("window", "must not be set with market_hours_only")
+	}
 
-	// Create alert in database
-	alert, err := s.store.CreateAlert(
-		int(req.UserId),
-		strings.ToUpper(req.Symbol),
-		req.TargetPrice,
-		condition,
-	)
+	window, err := windowFromProto(req.Window)
 	if err != nil {
+		return nil, rpcerror.InvalidField("window", err.Error())
+	}
+	if req.MarketHoursOnly {
+		window = USMarketHours
+	}
+
+	// Create alert in database
+	alert.Condition = conditionToString(req.Condition)
+	alert.Window = window
+	if req.ExpiresAt > 0 {
+		expiresAt := time.Unix(req.ExpiresAt, 0).UTC()
+		alert.ExpiresAt = &expiresAt
+	}
+	if err := s.store.CreateAlert(alert, s.maxActiveAlerts); err != nil {
+		if errors.Is(err, ErrAlertQuotaExceeded) {
+			return nil, status.Errorf(codes.ResourceExhausted, "user already has %d active alerts", s.maxActiveAlerts)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to create alert: %v", err)
 	}
 
+	message := fmt.Sprintf("Alert created: %s %s $%.2f", alert.Symbol, alert.Condition, alert.TargetPrice)
+	if alert.Kind == KindPortfolio {
+		r := alert.Portfolio
+		message = fmt.Sprintf("Alert created: portfolio %d %s %s %.2f", r.PortfolioID, r.Metric, alert.Condition, r.Threshold)
+		if r.Metric == MetricPositionWeight {
+			message = fmt.Sprintf("Alert created: portfolio %d %s weight %s %.2f%%", r.PortfolioID, r.Symbol, alert.Condition, r.Threshold)
+		}
+	}
+	if a := alert.Action; a.Set() {
+		message += fmt.Sprintf(", then %s %g %s %s", a.Side, a.Quantity, a.Symbol, a.Type)
+	}
 	return &pb.CreateAlertResponse{
 		AlertId: int32(alert.ID),
-		Message: fmt.Sprintf("Alert created: %s %s $%.2f", alert.Symbol, condition, alert.TargetPrice),
+		Message: message,
 	}, nil
 }
 
+// portfolioRule validates the rule of a portfolio alert, which must name one
+// of the user's portfolios.
+func (s *Server) portfolioRule(userID int, r *pb.PortfolioRule) (PortfolioRule, error) {
+	if r == nil {
+		return PortfolioRule{}, rpcerror.InvalidField("portfolio", "is required for PORTFOLIO alerts")
+	}
+	if s.portfolios == nil {
+		return PortfolioRule{}, status.Error(codes.Unimplemented, "portfolio alerts are not enabled")
+	}
+	if r.PortfolioId <= 0 {
+		return PortfolioRule{}, rpcerror.InvalidField("portfolio.portfolio_id", "must be positive")
+	}
+	if r.Metric == pb.PortfolioMetric_METRIC_UNSPECIFIED || pb.PortfolioMetric_name[int32(r.Metric)] == "" {
+		return PortfolioRule{}, rpcerror.InvalidField("portfolio.metric", "is required")
+	}
+
+	rule := PortfolioRule{PortfolioID: int(r.PortfolioId), Metric: r.Metric.String(), Threshold: r.Threshold}
+	if rule.Metric == MetricPositionWeight {
+		symbol, err := symbols.Normalize(r.Symbol)
+		if err != nil {
+			return PortfolioRule{}, rpcerror.InvalidField("portfolio.symbol", "is required for POSITION_WEIGHT")
+		}
+		if r.Threshold < 0 || r.Threshold > 100 {
+			return PortfolioRule{}, rpcerror.InvalidField("portfolio.threshold", "must be a percentage between 0 and 100")
+		}
+		rule.Symbol = symbol
+	} else if r.Symbol != "" {
+		return PortfolioRule{}, rpcerror.InvalidField("portfolio.symbol", "is only used by POSITION_WEIGHT")
+	}
+
+	owns, err := s.portfolios.Owns(rule.PortfolioID, userID)
+	if err != nil {
+		return PortfolioRule{}, status.Errorf(codes.Internal, "failed to look up portfolio: %v", err)
+	}
+	if !owns {
+		return PortfolioRule{}, rpcerror.FieldError(codes.NotFound, "portfolio.portfolio_id", "is not one of the user's portfolios")
+	}
+	return rule, nil
+}
+
 // GetAlerts retrieves alerts based on filter criteria.
 func (s *Server) GetAlerts(ctx context.Context, req *pb.GetAlertsRequest) (*pb.GetAlertsResponse, error) {
 	// Fetch alerts from database
@@ -76,6 +211,14 @@ func (s *Server) GetAlerts(ctx context.Context, req *pb.GetAlertsRequest) (*pb.G
 			Condition:   stringToCondition(a.Condition),
 			Triggered:   a.Triggered,
 			CreatedAt:   a.CreatedAt.Unix(),
+			Status:      stringToStatus(a.Status),
+			Window:      windowToProto(a.Window),
+			Kind:        kindToProto(a.Kind),
+			Portfolio:   ruleToProto(a.Kind, a.Portfolio),
+			Action:      actionToProto(a.Action),
+		}
+		if a.ExpiresAt != nil {
+			pbAlerts[i].ExpiresAt = a.ExpiresAt.Unix()
 		}
 	}
 
@@ -84,6 +227,302 @@ func (s *Server) GetAlerts(ctx context.Context, req *pb.GetAlertsRequest) (*pb.G
 	}, nil
 }
 
+// Page size limits for ListAlertTriggers.
+const (
+	defaultTriggerPageSize = 50
+	maxTriggerPageSize     = 500
+)
+
+// ListAlertTriggers retrieves a page of trigger history, newest first.
+func (s *Server) ListAlertTriggers(ctx context.Context, req *pb.ListAlertTriggersRequest) (*pb.ListAlertTriggersResponse, error) {
+	if req.From < 0 {
+		return nil, rpcerror.InvalidField("from", "must not be negative")
+	}
+	if req.To < 0 {
+		return nil, rpcerror.InvalidField("to", "must not be negative")
+	}
+	if req.From > 0 && req.To > 0 && req.From >= req.To {
+		return nil, rpcerror.InvalidField("from", "must be before to")
+	}
+
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize < 0:
+		return nil, rpcerror.InvalidField("page_size", "must not be negative")
+	case pageSize == 0:
+		pageSize = defaultTriggerPageSize
+	case pageSize > maxTriggerPageSize:
+		pageSize = maxTriggerPageSize
+	}
+
+	filter := TriggerFilter{
+		UserID:  int(req.UserId),
+		AlertID: int(req.AlertId),
+		Limit:   pageSize + 1, // One extra row tells us whether another page exists
+	}
+	if req.Symbol != "" {
+		filter.Symbols = []string{strings.ToUpper(req.Symbol)}
+	}
+	if req.From > 0 {
+		filter.From = time.UnixMilli(req.From)
+	}
+	if req.To > 0 {
+		filter.To = time.UnixMilli(req.To)
+	}
+	if req.PageToken != "" {
+		beforeID, err := strconv.ParseInt(req.PageToken, 10, 64)
+		if err != nil || beforeID <= 0 {
+			return nil, rpcerror.InvalidField("page_token", "is invalid")
+		}
+		filter.BeforeID = beforeID
+	}
+
+	triggers, err := s.store.ListAlertTriggers(filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list alert triggers: %v", err)
+	}
+
+	resp := &pb.ListAlertTriggersResponse{}
+	if len(triggers) > pageSize {
+		triggers = triggers[:pageSize]
+		resp.NextPageToken = strconv.FormatInt(triggers[pageSize-1].ID, 10)
+	}
+
+	resp.Triggers = make([]*pb.AlertTrigger, len(triggers))
+	for i := range triggers {
+		resp.Triggers[i] = triggerToProto(&triggers[i])
+	}
+	return resp, nil
+}
+
+// WatchAlerts streams trigger events to the client. Events after req.Cursor are
+// replayed from the trigger history first, so a client that reconnects with the
+// last cursor it saw misses nothing.
+func (s *Server) WatchAlerts(req *pb.WatchAlertsRequest, stream pb.AlertService_WatchAlertsServer) error {
+	symbols := make([]string, len(req.Symbols))
+	for i, sym := range req.Symbols {
+		symbols[i] = strings.ToUpper(sym)
+	}
+
+	// Subscribe before reading the cursor so no wake-up can be missed
+	sub := s.broker.Subscribe(int(req.UserId), symbols)
+	defer sub.Close()
+
+	cursor := req.Cursor
+	switch {
+	case cursor < 0:
+		return rpcerror.InvalidField("cursor", "must not be negative")
+	case cursor == 0:
+		latest, err := s.store.LatestTriggerID()
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read cursor: %v", err)
+		}
+		cursor = latest
+	}
+
+	filter := TriggerFilter{
+		UserID:  int(req.UserId),
+		Symbols: symbols,
+		Resume:  true,
+		Limit:   maxTriggerPageSize,
+	}
+
+	// catchUp sends every matching trigger after the cursor, a page at a time.
+	catchUp := func() error {
+		for {
+			filter.AfterID = cursor
+			triggers, err := s.store.ListAlertTriggers(filter)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to read triggers: %v", err)
+			}
+			for i := range triggers {
+				event := &pb.AlertEvent{
+					Cursor: triggers[i].ID,
+					Event:  &pb.AlertEvent_Trigger{Trigger: triggerToProto(&triggers[i])},
+				}
+				if err := stream.Send(event); err != nil {
+					return err
+				}
+				cursor = triggers[i].ID
+			}
+			if len(triggers) < filter.Limit {
+				return nil
+			}
+		}
+	}
+
+	if err := catchUp(); err != nil {
+		return err
+	}
+
+	heartbeat := time.NewTicker(watchHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-sub.C():
+			if err := catchUp(); err != nil {
+				return err
+			}
+
+		case <-heartbeat.C:
+			if err := catchUp(); err != nil {
+				return err
+			}
+			event := &pb.AlertEvent{
+				Cursor: cursor,
+				Event:  &pb.AlertEvent_Heartbeat{Heartbeat: &pb.Heartbeat{Timestamp: time.Now().UnixMilli()}},
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// BacktestAlert replays a symbol's stored ticks or candles through a price
+// alert and returns every trigger it would have recorded.
+func (s *Server) BacktestAlert(ctx context.Context, req *pb.BacktestAlertRequest) (*pb.BacktestAlertResponse, error) {
+	if s.backtests == nil {
+		return nil, status.Error(codes.Unimplemented, "backtests are not enabled")
+	}
+
+	symbol, err := symbols.Normalize(req.Symbol)
+	if err != nil {
+		return nil, rpcerror.InvalidField("symbol", err.Error())
+	}
+	if req.TargetPrice <= 0 {
+		return nil, rpcerror.InvalidField("target_price", "must be positive")
+	}
+	if req.Condition == pb.AlertCondition_CONDITION_UNSPECIFIED {
+		return nil, rpcerror.InvalidField("condition", "must be ABOVE or BELOW")
+	}
+	if req.MarketHoursOnly && req.Window != nil {
+		return nil, rpcerror.InvalidField("window", "must not be set with market_hours_only")
+	}
+	window, err := windowFromProto(req.Window)
+	if err != nil {
+		return nil, rpcerror.InvalidField("window", err.Error())
+	}
+	if req.MarketHoursOnly {
+		window = USMarketHours
+	}
+
+	if req.From <= 0 {
+		return nil, rpcerror.InvalidField("from", "must be positive")
+	}
+	if req.To < 0 {
+		return nil, rpcerror.InvalidField("to", "must not be negative")
+	}
+	from, to := time.UnixMilli(req.From), time.Now()
+	if req.To > 0 {
+		to = time.UnixMilli(req.To)
+	}
+	if !from.Before(to) {
+		return nil, rpcerror.InvalidField("from", "must be before to")
+	}
+
+	var interval time.Duration
+	if req.Interval != "" && req.Interval != "tick" {
+		interval, err = history.ParseInterval(req.Interval)
+		if err != nil {
+			return nil, rpcerror.InvalidField("interval", "must be tick, 1m, 5m, 15m, 30m, 1h, 4h or 1d")
+		}
+	}
+
+	alert := Alert{
+		Kind:        KindPrice,
+		Symbol:      symbol,
+		TargetPrice: req.TargetPrice,
+		Condition:   conditionToString(req.Condition),
+		Window:      window,
+	}
+	result, err := s.backtests.Run(ctx, alert, from, to, interval)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to backtest alert: %v", err)
+	}
+
+	resp := &pb.BacktestAlertResponse{
+		Triggers: make([]*pb.BacktestTrigger, len(result.Triggers)),
+		Summary: &pb.BacktestSummary{
+			TriggerCount:   int32(len(result.Triggers)),
+			Points:         int64(result.Points),
+			PointsInWindow: int64(result.PointsInWindow),
+			PointsMatched:  int64(result.PointsMatched),
+			Truncated:      result.Truncated,
+		},
+	}
+	for i, t := range result.Triggers {
+		resp.Triggers[i] = &pb.BacktestTrigger{Timestamp: t.Time.UnixMilli(), Price: t.Price}
+	}
+	if result.Points > 0 {
+		resp.Summary.MinPrice = result.MinPrice
+		resp.Summary.MaxPrice = result.MaxPrice
+		resp.Summary.EvaluatedTo = result.EvaluatedTo.UnixMilli()
+	}
+	if n := len(result.Triggers); n > 0 {
+		resp.Summary.FirstTriggerAt = result.Triggers[0].Time.UnixMilli()
+		resp.Summary.LastTriggerAt = result.Triggers[n-1].Time.UnixMilli()
+	}
+	return resp, nil
+}
+
+// triggerToProto converts a trigger history entry to its proto form.
+func triggerToProto(t *AlertTrigger) *pb.AlertTrigger {
+	notifications := make([]*pb.NotificationStatus, len(t.Notifications))
+	for i, n := range t.Notifications {
+		notifications[i] = &pb.NotificationStatus{
+			Channel:   n.Channel,
+			Status:    n.Status,
+			Error:     n.Error,
+			UpdatedAt: n.UpdatedAt.UnixMilli(),
+		}
+	}
+
+	return &pb.AlertTrigger{
+		Id:            t.ID,
+		AlertId:       int32(t.AlertID),
+		UserId:        int32(t.UserID),
+		Symbol:        t.Symbol,
+		Condition:     stringToCondition(t.Condition),
+		TargetPrice:   t.TargetPrice,
+		TriggerPrice:  t.TriggerPrice,
+		TickTimestamp: t.TickTimestamp.UnixMilli(),
+		Partition:     t.Partition,
+		Offset:        t.Offset,
+		TriggeredAt:   t.TriggeredAt.UnixMilli(),
+		Notifications: notifications,
+		Kind:          kindToProto(t.Kind),
+		Portfolio:     ruleToProto(t.Kind, t.Portfolio),
+		Action:        actionResultToProto(t.Action),
+	}
+}
+
+// kindToProto converts an alert kind to its proto enum.
+func kindToProto(kind string) pb.AlertKind {
+	if kind == KindPortfolio {
+		return pb.AlertKind_PORTFOLIO
+	}
+	return pb.AlertKind_PRICE
+}
+
+// ruleToProto converts the rule of a portfolio alert to its proto form, or
+// nil for other kinds.
+func ruleToProto(kind string, r PortfolioRule) *pb.PortfolioRule {
+	if kind != KindPortfolio {
+		return nil
+	}
+	return &pb.PortfolioRule{
+		PortfolioId: int32(r.PortfolioID),
+		Metric:      pb.PortfolioMetric(pb.PortfolioMetric_value[r.Metric]),
+		Threshold:   r.Threshold,
+		Symbol:      r.Symbol,
+	}
+}
+
 // conditionToString converts proto AlertCondition to database string.
 func conditionToString(c pb.AlertCondition) string {
 	switch c {
@@ -107,3 +546,67 @@ func stringToCondition(s string) pb.AlertCondition {
 		return pb.AlertCondition_CONDITION_UNSPECIFIED
 	}
 }
+
+// stringToStatus converts database status string to proto AlertStatus.
+func stringToStatus(s string) pb.AlertStatus {
+	switch s {
+	case StatusActive:
+		return pb.AlertStatus_ACTIVE
+	case StatusTriggered:
+		return pb.AlertStatus_TRIGGERED
+	case StatusExpired:
+		return pb.AlertStatus_EXPIRED
+	default:
+		return pb.AlertStatus_STATUS_UNSPECIFIED
+	}
+}
+
+// windowFromProto converts and validates a proto ActiveWindow.
+// A nil window places no restriction on evaluation.
+func windowFromProto(w *pb.ActiveWindow) (Window, error) {
+	if w == nil {
+		return Window{}, nil
+	}
+
+	days := make([]time.Weekday, 0, len(w.Weekdays))
+	for _, d := range w.Weekdays {
+		if d < 0 || d > 6 {
+			return Window{}, fmt.Errorf("weekday %d out of range 0-6", d)
+		}
+		days = append(days, time.Weekday(d))
+	}
+
+	window := Window{
+		Timezone:     w.Timezone,
+		Start:        w.Start,
+		End:          w.End,
+		Weekdays:     WeekdayMask(days...),
+		SkipHolidays: w.SkipHolidays,
+	}
+	if err := window.Validate(); err != nil {
+		return Window{}, err
+	}
+	return window, nil
+}
+
+// windowToProto converts a Window to its proto form, or nil if unrestricted.
+func windowToProto(w Window) *pb.ActiveWindow {
+	if w.IsZero() && w.Timezone == "" {
+		return nil
+	}
+
+	var days []int32
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if w.Weekdays&(1<<uint(d)) != 0 {
+			days = append(days, int32(d))
+		}
+	}
+
+	return &pb.ActiveWindow{
+		Timezone:     w.Timezone,
+		Start:        w.Start,
+		End:          w.End,
+		Weekdays:     days,
+		SkipHolidays: w.SkipHolidays,
+	}
+}
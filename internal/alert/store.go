@@ -1,8 +1,11 @@
 package alert
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/tiongMax/gostocks/internal/symbols"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -30,9 +33,16 @@ func (s *Store) Close() error {
 
 // AutoMigrate automatically migrates the database schema using GORM models.
 func (s *Store) AutoMigrate() error {
-	if err := s.db.AutoMigrate(&User{}, &Alert{}); err != nil {
+	if err := s.db.AutoMigrate(&User{}, &Alert{}, &AlertTrigger{}, &TriggerNotification{}, &Watchlist{}, &WatchlistSymbol{}, &symbols.Subscription{}); err != nil {
 		return fmt.Errorf("failed to auto migrate schema: %w", err)
 	}
+
+	// Backfill the status column for alerts that triggered before it existed.
+	if err := s.db.Model(&Alert{}).
+		Where("triggered = ? AND status = ?", true, StatusActive).
+		Update("status", StatusTriggered).Error; err != nil {
+		return fmt.Errorf("failed to backfill alert status: %w", err)
+	}
 	return nil
 }
 
@@ -57,40 +67,219 @@ func (s *Store) GetUserByUsername(username string) (*User, error) {
 	return &user, nil
 }
 
-// CreateAlert creates a new alert for a user.
-func (s *Store) CreateAlert(userID int, symbol string, targetPrice float64, condition string) (*Alert, error) {
-	alert := Alert{
-		UserID:      userID,
-		Symbol:      symbol,
-		TargetPrice: targetPrice,
-		Condition:   condition,
+// ErrAlertQuotaExceeded is returned when a user already has the maximum
+// number of active alerts.
+var ErrAlertQuotaExceeded = errors.New("active alert quota exceeded")
+
+// alertQuotaLock is the advisory lock class held per user while checking the
+// active alert quota; the user ID is the second key.
+const alertQuotaLock = 0x67735f71 // "gs_q"
+
+// CreateAlert inserts a new alert. The alert's ID and CreatedAt are filled in.
+// If maxActive is positive and the user already has that many active alerts,
+// it returns ErrAlertQuotaExceeded.
+func (s *Store) CreateAlert(alert *Alert, maxActive int) error {
+	if alert.Status == "" {
+		alert.Status = StatusActive
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if maxActive > 0 {
+			// Serialize creates per user so concurrent requests cannot both
+			// take the last slot.
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", alertQuotaLock, alert.UserID).Error; err != nil {
+				return err
+			}
+			var active int64
+			if err := tx.Model(&Alert{}).
+				Where("user_id = ? AND status = ?", alert.UserID, StatusActive).
+				Count(&active).Error; err != nil {
+				return err
+			}
+			if active >= int64(maxActive) {
+				return ErrAlertQuotaExceeded
+			}
+		}
+		return tx.Create(alert).Error
+	})
+	if errors.Is(err, ErrAlertQuotaExceeded) {
+		return err
 	}
-	if err := s.db.Create(&alert).Error; err != nil {
-		return nil, fmt.Errorf("failed to create alert: %w", err)
+	if err != nil {
+		return fmt.Errorf("failed to create alert: %w", err)
 	}
-	return &alert, nil
+	return nil
 }
 
-// GetActiveAlerts retrieves all untriggered alerts.
+// GetActiveAlerts retrieves all alerts in the ACTIVE state.
 func (s *Store) GetActiveAlerts() ([]Alert, error) {
 	var alerts []Alert
-	if err := s.db.Where("triggered = ?", false).Find(&alerts).Error; err != nil {
+	if err := s.db.Where("status = ?", StatusActive).Find(&alerts).Error; err != nil {
 		return nil, fmt.Errorf("failed to query active alerts: %w", err)
 	}
 	return alerts, nil
 }
 
-// MarkAlertTriggered marks an alert as triggered.
-func (s *Store) MarkAlertTriggered(alertID int) error {
-	if err := s.db.Model(&Alert{}).Where("id = ?", alertID).Update("triggered", true).Error; err != nil {
-		return fmt.Errorf("failed to mark alert as triggered: %w", err)
+// MarkAlertTriggered atomically moves the trigger's alert from ACTIVE to
+// TRIGGERED and records the trigger with a PENDING notification per channel.
+// It returns false without recording anything if the alert was no longer active,
+// which makes redelivered ticks harmless.
+func (s *Store) MarkAlertTriggered(trigger *AlertTrigger, channels []string) (bool, error) {
+	recorded := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Serialize trigger inserts so IDs become visible in commit order.
+		// WatchAlerts relies on this to resume from a trigger ID without gaps.
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", triggerInsertLock).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&Alert{}).
+			Where("id = ? AND status = ?", trigger.AlertID, StatusActive).
+			Updates(map[string]interface{}{"triggered": true, "status": StatusTriggered})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		trigger.Notifications = make([]TriggerNotification, len(channels))
+		for i, ch := range channels {
+			trigger.Notifications[i] = TriggerNotification{Channel: ch, Status: NotificationPending}
+		}
+		if err := tx.Omit("Alert").Create(trigger).Error; err != nil {
+			return err
+		}
+		recorded = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to mark alert as triggered: %w", err)
+	}
+	return recorded, nil
+}
+
+// UpdateNotificationStatus records the delivery outcome of a trigger on one channel.
+func (s *Store) UpdateNotificationStatus(triggerID int64, channel, status, errMsg string) error {
+	err := s.db.Model(&TriggerNotification{}).
+		Where("trigger_id = ? AND channel = ?", triggerID, channel).
+		Updates(map[string]interface{}{"status": status, "error": errMsg}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update notification status: %w", err)
 	}
 	return nil
 }
 
+// RecordActionResult stores the outcome of a trigger's order action. Only
+// pending actions are updated; it returns false if the action had already
+// been resolved, e.g. by a concurrent retry.
+func (s *Store) RecordActionResult(triggerID int64, result ActionResult) (bool, error) {
+	res := s.db.Model(&AlertTrigger{}).
+		Where("id = ? AND action_status = ?", triggerID, ActionPending).
+		Updates(map[string]interface{}{
+			"action_status":   result.Status,
+			"action_order_id": result.OrderID,
+			"action_error":    result.Error,
+			"action_attempts": result.Attempts,
+		})
+	if res.Error != nil {
+		return false, fmt.Errorf("failed to record action result: %w", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// PendingActions returns up to limit triggers recorded before the given time
+// whose order action is still pending, oldest first, with their alerts.
+func (s *Store) PendingActions(before time.Time, limit int) ([]AlertTrigger, error) {
+	var triggers []AlertTrigger
+	if err := s.db.Preload("Alert").
+		Where("action_status = ? AND triggered_at < ?", ActionPending, before).
+		Order("id ASC").Limit(limit).Find(&triggers).Error; err != nil {
+		return nil, fmt.Errorf("failed to query pending actions: %w", err)
+	}
+	return triggers, nil
+}
+
+// triggerInsertLock is the advisory lock key held while recording a trigger.
+const triggerInsertLock = 0x67735f7472696767 // "gs_trigg"
+
+// TriggerFilter selects trigger history entries. Zero values match everything.
+type TriggerFilter struct {
+	UserID   int
+	AlertID  int
+	Symbols  []string
+	From     time.Time // Inclusive lower bound on TriggeredAt
+	To       time.Time // Exclusive upper bound on TriggeredAt
+	BeforeID int64     // Pagination cursor: only triggers with a smaller ID
+	Resume   bool      // Only triggers after AfterID, oldest first, even when AfterID is 0
+	AfterID  int64     // Resume cursor, used with Resume
+	Limit    int
+}
+
+// ListAlertTriggers returns triggers matching the filter with their
+// notification statuses. Results are newest first, unless the filter
+// resumes from a cursor, in which case they are oldest first.
+func (s *Store) ListAlertTriggers(filter TriggerFilter) ([]AlertTrigger, error) {
+	query := s.db.Model(&AlertTrigger{}).Preload("Notifications")
+
+	if filter.UserID > 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.AlertID > 0 {
+		query = query.Where("alert_id = ?", filter.AlertID)
+	}
+	if len(filter.Symbols) > 0 {
+		query = query.Where("symbol IN ?", filter.Symbols)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("triggered_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("triggered_at < ?", filter.To)
+	}
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+	order := "id DESC"
+	if filter.Resume {
+		query = query.Where("id > ?", filter.AfterID)
+		order = "id ASC"
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var triggers []AlertTrigger
+	if err := query.Order(order).Find(&triggers).Error; err != nil {
+		return nil, fmt.Errorf("failed to query alert triggers: %w", err)
+	}
+	return triggers, nil
+}
+
+// ExpireAlerts moves every active alert whose expiry is at or before now into
+// the EXPIRED state and returns how many were updated.
+func (s *Store) ExpireAlerts(now time.Time) (int64, error) {
+	result := s.db.Model(&Alert{}).
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at <= ?", StatusActive, now).
+		Update("status", StatusExpired)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to expire alerts: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// LatestTriggerID returns the highest trigger ID recorded so far, or 0 if none.
+func (s *Store) LatestTriggerID() (int64, error) {
+	var id int64
+	if err := s.db.Model(&AlertTrigger{}).Select("COALESCE(MAX(id), 0)").Scan(&id).Error; err != nil {
+		return 0, fmt.Errorf("failed to query latest trigger: %w", err)
+	}
+	return id, nil
+}
+
 // GetAlertsByUser retrieves alerts for a specific user.
 // If userID is 0, retrieves alerts for all users.
-// If activeOnly is true, only non-triggered alerts are returned.
+// If activeOnly is true, only alerts in the ACTIVE state are returned.
 func (s *Store) GetAlertsByUser(userID int, activeOnly bool) ([]Alert, error) {
 	var alerts []Alert
 	query := s.db.Model(&Alert{})
@@ -99,7 +288,7 @@ func (s *Store) GetAlertsByUser(userID int, activeOnly bool) ([]Alert, error) {
 		query = query.Where("user_id = ?", userID)
 	}
 	if activeOnly {
-		query = query.Where("triggered = ?", false)
+		query = query.Where("status = ?", StatusActive)
 	}
 
 	if err := query.Find(&alerts).Error; err != nil {
@@ -108,12 +297,21 @@ func (s *Store) GetAlertsByUser(userID int, activeOnly bool) ([]Alert, error) {
 	return alerts, nil
 }
 
-// GetActiveAlertsBySymbol retrieves all untriggered alerts for a specific symbol.
+// GetActiveAlertsBySymbol retrieves all active alerts for a specific symbol.
 // This is optimized for the trigger logic to check only relevant alerts.
 func (s *Store) GetActiveAlertsBySymbol(symbol string) ([]Alert, error) {
 	var alerts []Alert
-	if err := s.db.Where("symbol = ? AND triggered = ?", symbol, false).Find(&alerts).Error; err != nil {
+	if err := s.db.Where("symbol = ? AND status = ? AND kind = ?", symbol, StatusActive, KindPrice).Find(&alerts).Error; err != nil {
 		return nil, fmt.Errorf("failed to query alerts for symbol %s: %w", symbol, err)
 	}
 	return alerts, nil
 }
+
+// GetActivePortfolioAlerts retrieves all active portfolio alerts.
+func (s *Store) GetActivePortfolioAlerts() ([]Alert, error) {
+	var alerts []Alert
+	if err := s.db.Where("kind = ? AND status = ?", KindPortfolio, StatusActive).Find(&alerts).Error; err != nil {
+		return nil, fmt.Errorf("failed to query portfolio alerts: %w", err)
+	}
+	return alerts, nil
+}
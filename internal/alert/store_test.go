@@ -0,0 +1,89 @@
+package alert
+
+import (
+	"os"
+	"testing"
+)
+
+// testStore connects to the database in TEST_DATABASE_URL and empties the
+// alert tables, or skips the test if it is not set.
+func testStore(t *testing.T) *Store {
+	t.Helper()
+	connStr := os.Getenv("TEST_DATABASE_URL")
+	if connStr == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	store, err := NewStore(connStr)
+	if err != nil {
+		t.Fatalf("NewStore() = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	if err := store.AutoMigrate(); err != nil {
+		t.Fatalf("AutoMigrate() = %v", err)
+	}
+	if err := store.db.Exec("TRUNCATE trigger_notifications, alert_triggers, alerts, users RESTART IDENTITY CASCADE").Error; err != nil {
+		t.Fatalf("failed to empty tables: %v", err)
+	}
+	return store
+}
+
+func TestListAlertTriggersResume(t *testing.T) {
+	store := testStore(t)
+
+	userID, err := store.CreateUser("watcher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := func(symbol string) int64 {
+		t.Helper()
+		alert := &Alert{UserID: userID, Kind: KindPrice, Symbol: symbol, TargetPrice: 100, Condition: "ABOVE"}
+		if err := store.CreateAlert(alert, 0); err != nil {
+			t.Fatal(err)
+		}
+		trigger := &AlertTrigger{AlertID: alert.ID, UserID: userID, Symbol: symbol, TriggerPrice: 101, TargetPrice: 100, Condition: "ABOVE"}
+		if ok, err := store.MarkAlertTriggered(trigger, nil); err != nil || !ok {
+			t.Fatalf("MarkAlertTriggered() = %v, %v", ok, err)
+		}
+		return trigger.ID
+	}
+
+	// A stream opened before any trigger exists resumes from cursor 0, and
+	// every wake-up reads a page of one after its cursor, as WatchAlerts does
+	cursor, err := store.LatestTriggerID()
+	if err != nil || cursor != 0 {
+		t.Fatalf("LatestTriggerID() = %d, %v, want 0", cursor, err)
+	}
+	var sent []int64
+	wake := func() {
+		t.Helper()
+		for {
+			triggers, err := store.ListAlertTriggers(TriggerFilter{UserID: userID, Resume: true, AfterID: cursor, Limit: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, tr := range triggers {
+				sent = append(sent, tr.ID)
+				cursor = tr.ID
+			}
+			if len(triggers) < 1 {
+				return
+			}
+		}
+	}
+
+	first, second := record("AAPL"), record("MSFT")
+	wake()
+	third := record("GOOG")
+	wake()
+
+	want := []int64{first, second, third}
+	if len(sent) != len(want) {
+		t.Fatalf("sent triggers %v, want %v", sent, want)
+	}
+	for i := range want {
+		if sent[i] != want[i] {
+			t.Fatalf("sent triggers %v, want %v", sent, want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package alert
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Sweeper periodically moves alerts past their expiry into the EXPIRED state.
+type Sweeper struct {
+	store    *Store
+	interval time.Duration
+}
+
+// NewSweeper creates a Sweeper that runs every interval.
+func NewSweeper(store *Store, interval time.Duration) *Sweeper {
+	return &Sweeper{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Start runs the sweep loop until the context is cancelled.
+func (s *Sweeper) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.sweep()
+	for {
+		select {
+		case <-ticker.C:
+			s.sweep()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Sweeper) sweep() {
+	expired, err := s.store.ExpireAlerts(time.Now())
+	if err != nil {
+		slog.Error("Failed to expire alerts", "error", err)
+		return
+	}
+	if expired > 0 {
+		slog.Info("Expired alerts", "count", expired)
+	}
+}
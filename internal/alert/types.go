@@ -4,6 +4,28 @@ import (
 	"time"
 )
 
+// Alert lifecycle states.
+const (
+	StatusActive    = "ACTIVE"
+	StatusTriggered = "TRIGGERED"
+	StatusExpired   = "EXPIRED"
+)
+
+// Alert kinds.
+const (
+	KindPrice     = "PRICE"
+	KindPortfolio = "PORTFOLIO"
+)
+
+// Portfolio metrics a portfolio alert can watch.
+const (
+	MetricUnrealizedPnL    = "UNREALIZED_PNL"
+	MetricDayChange        = "DAY_CHANGE"
+	MetricDayChangePercent = "DAY_CHANGE_PERCENT"
+	MetricMarketValue      = "MARKET_VALUE"
+	MetricPositionWeight   = "POSITION_WEIGHT" // Percent, of Symbol
+)
+
 type User struct {
 	ID        int       `json:"id" gorm:"primaryKey"`
 	Username  string    `json:"username" gorm:"unique;not null"`
@@ -11,12 +33,118 @@ type User struct {
 }
 
 type Alert struct {
-	ID          int       `json:"id" gorm:"primaryKey"`
-	UserID      int       `json:"user_id"`
-	Symbol      string    `json:"symbol" gorm:"not null"`
-	TargetPrice float64   `json:"target_price" gorm:"not null"`
-	Condition   string    `json:"condition" gorm:"not null"` // "ABOVE" or "BELOW"
-	Triggered   bool      `json:"triggered" gorm:"default:false"`
-	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
-	User        User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ID          int           `json:"id" gorm:"primaryKey"`
+	UserID      int           `json:"user_id"`
+	Kind        string        `json:"kind" gorm:"not null;default:PRICE"` // "PRICE" or "PORTFOLIO"
+	Symbol      string        `json:"symbol" gorm:"not null"`             // Empty for portfolio alerts
+	TargetPrice float64       `json:"target_price" gorm:"not null"`
+	Condition   string        `json:"condition" gorm:"not null"` // "ABOVE" or "BELOW"
+	Triggered   bool          `json:"triggered" gorm:"default:false"`
+	Status      string        `json:"status" gorm:"not null;default:ACTIVE;index"` // "ACTIVE", "TRIGGERED" or "EXPIRED"
+	ExpiresAt   *time.Time    `json:"expires_at,omitempty" gorm:"index"`           // nil means never expires
+	Window      Window        `json:"window" gorm:"embedded;embeddedPrefix:window_"`
+	Portfolio   PortfolioRule `json:"portfolio" gorm:"embedded;embeddedPrefix:portfolio_"`
+	Action      OrderAction   `json:"action" gorm:"embedded;embeddedPrefix:action_"`
+	CreatedAt   time.Time     `json:"created_at" gorm:"autoCreateTime"`
+	User        User          `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// PortfolioRule is the subject of a portfolio alert: the alert fires when the
+// metric of the portfolio's live valuation crosses the threshold.
+type PortfolioRule struct {
+	PortfolioID int     `json:"portfolio_id,omitempty" gorm:"index"`
+	Metric      string  `json:"metric,omitempty"`
+	Threshold   float64 `json:"threshold"`
+	Symbol      string  `json:"symbol,omitempty"` // Position for POSITION_WEIGHT
+}
+
+// OrderAction is a paper order placed when an alert triggers. Side is empty
+// for alerts without an action.
+type OrderAction struct {
+	Symbol     string  `json:"symbol,omitempty"`
+	Side       string  `json:"side,omitempty"` // "BUY" or "SELL"
+	Type       string  `json:"type,omitempty"` // "MARKET", "LIMIT", "STOP" or "STOP_LIMIT"
+	Quantity   float64 `json:"quantity,omitempty"`
+	LimitPrice float64 `json:"limit_price,omitempty"`
+	StopPrice  float64 `json:"stop_price,omitempty"`
+}
+
+// Set reports whether the alert has an action.
+func (a OrderAction) Set() bool {
+	return a.Side != ""
+}
+
+// Order action states.
+const (
+	ActionPending = "PENDING"
+	ActionPlaced  = "PLACED"
+	ActionFailed  = "FAILED"
+)
+
+// ActionResult is the outcome of a trigger's order action. Status is empty
+// if the alert has no action.
+type ActionResult struct {
+	Status   string `json:"status,omitempty" gorm:"index"` // "PENDING", "PLACED" or "FAILED"
+	OrderID  int64  `json:"order_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Attempts int    `json:"attempts" gorm:"not null;default:0"`
+}
+
+// Notification delivery states.
+const (
+	NotificationPending = "PENDING"
+	NotificationSent    = "SENT"
+	NotificationFailed  = "FAILED"
+)
+
+// AlertTrigger records a single firing of an alert and the tick that caused it.
+// For portfolio alerts TargetPrice holds the rule's threshold and TriggerPrice
+// the metric's value.
+type AlertTrigger struct {
+	ID            int64                 `json:"id" gorm:"primaryKey"`
+	AlertID       int                   `json:"alert_id" gorm:"not null;index"`
+	UserID        int                   `json:"user_id" gorm:"not null;index"`
+	Kind          string                `json:"kind" gorm:"not null;default:PRICE"`
+	Symbol        string                `json:"symbol" gorm:"not null"`
+	Condition     string                `json:"condition" gorm:"not null"`
+	TargetPrice   float64               `json:"target_price"`
+	TriggerPrice  float64               `json:"trigger_price" gorm:"not null"`
+	Portfolio     PortfolioRule         `json:"portfolio" gorm:"embedded;embeddedPrefix:portfolio_"`
+	Action        ActionResult          `json:"action" gorm:"embedded;embeddedPrefix:action_"`
+	TickTimestamp time.Time             `json:"tick_timestamp"`
+	Partition     int32                 `json:"partition"` // Kafka partition of the tick
+	Offset        int64                 `json:"offset"`    // Kafka offset of the tick
+	TriggeredAt   time.Time             `json:"triggered_at" gorm:"autoCreateTime;index"`
+	Notifications []TriggerNotification `json:"notifications" gorm:"foreignKey:TriggerID;constraint:OnDelete:CASCADE;"`
+	Alert         Alert                 `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TriggerNotification tracks delivery of a trigger on one notification channel.
+type TriggerNotification struct {
+	ID        int64     `json:"-" gorm:"primaryKey"`
+	TriggerID int64     `json:"-" gorm:"not null;uniqueIndex:idx_trigger_channel"`
+	Channel   string    `json:"channel" gorm:"not null;uniqueIndex:idx_trigger_channel"`
+	Status    string    `json:"status" gorm:"not null"` // "PENDING", "SENT" or "FAILED"
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Watchlist is a user's named list of symbols.
+type Watchlist struct {
+	ID        int               `json:"id" gorm:"primaryKey"`
+	UserID    int               `json:"user_id" gorm:"not null;uniqueIndex:idx_watchlist_user_name"`
+	Name      string            `json:"name" gorm:"not null;uniqueIndex:idx_watchlist_user_name"`
+	Symbols   []WatchlistSymbol `json:"symbols" gorm:"foreignKey:WatchlistID;constraint:OnDelete:CASCADE;"`
+	CreatedAt time.Time         `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time         `json:"updated_at" gorm:"autoUpdateTime"`
+	User      User              `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// WatchlistSymbol is one member of a watchlist. Position keeps members in the
+// order they were added.
+type WatchlistSymbol struct {
+	WatchlistID int       `json:"-" gorm:"primaryKey"`
+	Symbol      string    `json:"symbol" gorm:"primaryKey"`
+	Position    int       `json:"-" gorm:"not null"`
+	AddedAt     time.Time `json:"added_at" gorm:"autoCreateTime"`
 }
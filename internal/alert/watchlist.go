@@ -0,0 +1,262 @@
+package alert
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/pgerror"
+	"github.com/tiongMax/gostocks/internal/symbols"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Watchlist limits.
+const (
+	MaxWatchlistsPerUser = 50
+	MaxWatchlistSymbols  = 200
+)
+
+var (
+	// ErrWatchlistNotFound is returned when a watchlist does not exist or
+	// belongs to another user.
+	ErrWatchlistNotFound = errors.New("watchlist not found")
+
+	// ErrWatchlistExists is returned when the user already has a watchlist
+	// with the requested name.
+	ErrWatchlistExists = errors.New("watchlist name already in use")
+
+	// ErrWatchlistLimit is returned when a user has too many watchlists or a
+	// watchlist would hold too many symbols.
+	ErrWatchlistLimit = errors.New("watchlist limit exceeded")
+)
+
+// watchlistLock is the advisory lock class held per user while creating a
+// watchlist, so that concurrent creates cannot exceed the per-user limit.
+const watchlistLock = 0x67735f77 // "gs_w"
+
+// CreateWatchlist inserts a watchlist and its symbols, which must already be
+// normalized, and registers the symbols for ingestion.
+func (s *Store) CreateWatchlist(w *Watchlist) error {
+	if len(w.Symbols) > MaxWatchlistSymbols {
+		return ErrWatchlistLimit
+	}
+	for i := range w.Symbols {
+		w.Symbols[i].Position = i
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", watchlistLock, w.UserID).Error; err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&Watchlist{}).Where("user_id = ?", w.UserID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= MaxWatchlistsPerUser {
+			return ErrWatchlistLimit
+		}
+
+		if err := tx.Create(w).Error; err != nil {
+			return err
+		}
+		return symbols.Register(tx, watchlistSymbols(w))
+	})
+	switch {
+	case errors.Is(err, ErrWatchlistLimit):
+		return err
+	case pgerror.IsUniqueViolation(err):
+		return ErrWatchlistExists
+	case err != nil:
+		return fmt.Errorf("failed to create watchlist: %w", err)
+	}
+	return nil
+}
+
+// GetWatchlist retrieves a watchlist with its symbols. A userID of 0 matches
+// any owner.
+func (s *Store) GetWatchlist(id, userID int) (*Watchlist, error) {
+	return getWatchlist(s.db, id, userID, false)
+}
+
+// getWatchlist loads a watchlist through db, locking its row when forUpdate
+// is set.
+func getWatchlist(db *gorm.DB, id, userID int, forUpdate bool) (*Watchlist, error) {
+	query := db.Preload("Symbols", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Where("id = ?", id)
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	var w Watchlist
+	if err := query.First(&w).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWatchlistNotFound
+		}
+		return nil, fmt.Errorf("failed to get watchlist: %w", err)
+	}
+	return &w, nil
+}
+
+// ListWatchlists retrieves a user's watchlists ordered by name, or every
+// user's if userID is 0.
+func (s *Store) ListWatchlists(userID int) ([]Watchlist, error) {
+	query := s.db.Preload("Symbols", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Order("user_id, name")
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	var lists []Watchlist
+	if err := query.Find(&lists).Error; err != nil {
+		return nil, fmt.Errorf("failed to list watchlists: %w", err)
+	}
+	return lists, nil
+}
+
+// RenameWatchlist changes a watchlist's name.
+func (s *Store) RenameWatchlist(id, userID int, name string) (*Watchlist, error) {
+	var w *Watchlist
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if w, err = getWatchlist(tx, id, userID, true); err != nil {
+			return err
+		}
+		w.Name = name
+		return tx.Model(w).Update("name", name).Error
+	})
+	switch {
+	case errors.Is(err, ErrWatchlistNotFound):
+		return nil, err
+	case pgerror.IsUniqueViolation(err):
+		return nil, ErrWatchlistExists
+	case err != nil:
+		return nil, fmt.Errorf("failed to rename watchlist: %w", err)
+	}
+	return w, nil
+}
+
+// DeleteWatchlist deletes a watchlist and its symbols.
+func (s *Store) DeleteWatchlist(id, userID int) error {
+	query := s.db.Where("id = ?", id)
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	result := query.Delete(&Watchlist{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete watchlist: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrWatchlistNotFound
+	}
+	return nil
+}
+
+// AddWatchlistSymbols appends normalized symbols to a watchlist, skipping
+// those already present, and registers them for ingestion.
+func (s *Store) AddWatchlistSymbols(id, userID int, syms []string) (*Watchlist, error) {
+	var w *Watchlist
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if w, err = getWatchlist(tx, id, userID, true); err != nil {
+			return err
+		}
+
+		present := make(map[string]bool, len(w.Symbols))
+		next := 0
+		for _, ws := range w.Symbols {
+			present[ws.Symbol] = true
+			next = ws.Position + 1
+		}
+		var added []WatchlistSymbol
+		for _, sym := range syms {
+			if !present[sym] {
+				present[sym] = true
+				added = append(added, WatchlistSymbol{WatchlistID: w.ID, Symbol: sym, Position: next})
+				next++
+			}
+		}
+		if len(added) == 0 {
+			return nil
+		}
+		if len(w.Symbols)+len(added) > MaxWatchlistSymbols {
+			return ErrWatchlistLimit
+		}
+
+		if err := tx.Create(&added).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(w).Update("updated_at", time.Now()).Error; err != nil {
+			return err
+		}
+		if err := symbols.Register(tx, syms); err != nil {
+			return err
+		}
+		w.Symbols = append(w.Symbols, added...)
+		return nil
+	})
+	if errors.Is(err, ErrWatchlistNotFound) || errors.Is(err, ErrWatchlistLimit) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to add watchlist symbols: %w", err)
+	}
+	return w, nil
+}
+
+// RemoveWatchlistSymbols removes symbols from a watchlist. Symbols that are
+// not in the watchlist are ignored. The symbols stay registered for
+// ingestion.
+func (s *Store) RemoveWatchlistSymbols(id, userID int, syms []string) (*Watchlist, error) {
+	var w *Watchlist
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if w, err = getWatchlist(tx, id, userID, true); err != nil {
+			return err
+		}
+		result := tx.Where("watchlist_id = ? AND symbol IN ?", w.ID, syms).Delete(&WatchlistSymbol{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Model(w).Update("updated_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		removed := make(map[string]bool, len(syms))
+		for _, sym := range syms {
+			removed[sym] = true
+		}
+		kept := w.Symbols[:0]
+		for _, ws := range w.Symbols {
+			if !removed[ws.Symbol] {
+				kept = append(kept, ws)
+			}
+		}
+		w.Symbols = kept
+		return nil
+	})
+	if errors.Is(err, ErrWatchlistNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove watchlist symbols: %w", err)
+	}
+	return w, nil
+}
+
+// watchlistSymbols returns the symbols of w in order.
+func watchlistSymbols(w *Watchlist) []string {
+	out := make([]string, len(w.Symbols))
+	for i, ws := range w.Symbols {
+		out[i] = ws.Symbol
+	}
+	return out
+}
@@ -0,0 +1,171 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tiongMax/gostocks/internal/rpcerror"
+	"github.com/tiongMax/gostocks/internal/symbols"
+	pb "github.com/tiongMax/gostocks/proto/watchlist"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxWatchlistNameLen caps watchlist names.
+const maxWatchlistNameLen = 64
+
+// WatchlistServer implements the WatchlistService gRPC server.
+type WatchlistServer struct {
+	pb.UnimplementedWatchlistServiceServer
+	store *Store
+}
+
+// NewWatchlistServer creates a WatchlistService server backed by store.
+func NewWatchlistServer(store *Store) *WatchlistServer {
+	return &WatchlistServer{store: store}
+}
+
+// CreateWatchlist creates a watchlist, optionally with initial symbols.
+func (s *WatchlistServer) CreateWatchlist(ctx context.Context, req *pb.CreateWatchlistRequest) (*pb.Watchlist, error) {
+	if req.UserId <= 0 {
+		return nil, rpcerror.InvalidField("user_id", "must be positive")
+	}
+	name, err := watchlistName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	syms, err := watchlistSymbolsArg(req.Symbols, false)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watchlist{UserID: int(req.UserId), Name: name}
+	for _, sym := range syms {
+		w.Symbols = append(w.Symbols, WatchlistSymbol{Symbol: sym})
+	}
+	if err := s.store.CreateWatchlist(w); err != nil {
+		return nil, watchlistError(err)
+	}
+	return watchlistToProto(w), nil
+}
+
+// GetWatchlist retrieves a single watchlist.
+func (s *WatchlistServer) GetWatchlist(ctx context.Context, req *pb.GetWatchlistRequest) (*pb.Watchlist, error) {
+	w, err := s.store.GetWatchlist(int(req.Id), int(req.UserId))
+	if err != nil {
+		return nil, watchlistError(err)
+	}
+	return watchlistToProto(w), nil
+}
+
+// ListWatchlists retrieves a user's watchlists, or every user's for user 0.
+func (s *WatchlistServer) ListWatchlists(ctx context.Context, req *pb.ListWatchlistsRequest) (*pb.ListWatchlistsResponse, error) {
+	lists, err := s.store.ListWatchlists(int(req.UserId))
+	if err != nil {
+		return nil, watchlistError(err)
+	}
+
+	resp := &pb.ListWatchlistsResponse{Watchlists: make([]*pb.Watchlist, len(lists))}
+	for i := range lists {
+		resp.Watchlists[i] = watchlistToProto(&lists[i])
+	}
+	return resp, nil
+}
+
+// RenameWatchlist changes a watchlist's name.
+func (s *WatchlistServer) RenameWatchlist(ctx context.Context, req *pb.RenameWatchlistRequest) (*pb.Watchlist, error) {
+	name, err := watchlistName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.store.RenameWatchlist(int(req.Id), int(req.UserId), name)
+	if err != nil {
+		return nil, watchlistError(err)
+	}
+	return watchlistToProto(w), nil
+}
+
+// DeleteWatchlist deletes a watchlist and its symbols.
+func (s *WatchlistServer) DeleteWatchlist(ctx context.Context, req *pb.DeleteWatchlistRequest) (*pb.DeleteWatchlistResponse, error) {
+	if err := s.store.DeleteWatchlist(int(req.Id), int(req.UserId)); err != nil {
+		return nil, watchlistError(err)
+	}
+	return &pb.DeleteWatchlistResponse{}, nil
+}
+
+// AddSymbols adds symbols to a watchlist and registers them for ingestion.
+func (s *WatchlistServer) AddSymbols(ctx context.Context, req *pb.UpdateSymbolsRequest) (*pb.Watchlist, error) {
+	syms, err := watchlistSymbolsArg(req.Symbols, true)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.store.AddWatchlistSymbols(int(req.Id), int(req.UserId), syms)
+	if err != nil {
+		return nil, watchlistError(err)
+	}
+	return watchlistToProto(w), nil
+}
+
+// RemoveSymbols removes symbols from a watchlist.
+func (s *WatchlistServer) RemoveSymbols(ctx context.Context, req *pb.UpdateSymbolsRequest) (*pb.Watchlist, error) {
+	syms, err := watchlistSymbolsArg(req.Symbols, true)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.store.RemoveWatchlistSymbols(int(req.Id), int(req.UserId), syms)
+	if err != nil {
+		return nil, watchlistError(err)
+	}
+	return watchlistToProto(w), nil
+}
+
+// watchlistName validates and trims a watchlist name.
+func watchlistName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", rpcerror.InvalidField("name", "is required")
+	}
+	if len(name) > maxWatchlistNameLen {
+		return "", rpcerror.InvalidField("name", fmt.Sprintf("must be at most %d characters", maxWatchlistNameLen))
+	}
+	return name, nil
+}
+
+// watchlistSymbolsArg normalizes the symbols of a request.
+func watchlistSymbolsArg(syms []string, required bool) ([]string, error) {
+	if required && len(syms) == 0 {
+		return nil, rpcerror.InvalidField("symbols", "is required")
+	}
+	out, err := symbols.NormalizeAll(syms)
+	if err != nil {
+		return nil, rpcerror.InvalidField("symbols", err.Error())
+	}
+	return out, nil
+}
+
+// watchlistError converts a store error to a gRPC status.
+func watchlistError(err error) error {
+	switch {
+	case errors.Is(err, ErrWatchlistNotFound):
+		return status.Error(codes.NotFound, "watchlist not found")
+	case errors.Is(err, ErrWatchlistExists):
+		return rpcerror.FieldError(codes.AlreadyExists, "name", "is already used by another watchlist")
+	case errors.Is(err, ErrWatchlistLimit):
+		return status.Errorf(codes.ResourceExhausted, "watchlists are limited to %d per user and %d symbols each", MaxWatchlistsPerUser, MaxWatchlistSymbols)
+	}
+	return status.Errorf(codes.Internal, "watchlist operation failed: %v", err)
+}
+
+// watchlistToProto converts a Watchlist to its proto form.
+func watchlistToProto(w *Watchlist) *pb.Watchlist {
+	return &pb.Watchlist{
+		Id:        int32(w.ID),
+		UserId:    int32(w.UserID),
+		Name:      w.Name,
+		Symbols:   watchlistSymbols(w),
+		CreatedAt: w.CreatedAt.Unix(),
+		UpdatedAt: w.UpdatedAt.Unix(),
+	}
+}
@@ -0,0 +1,105 @@
+// Package apierror defines the JSON error envelope returned by the gateway:
+//
+//	{"error": {"code": "INVALID_ARGUMENT", "message": "...", "details": [...], "request_id": "..."}}
+//
+// Codes use the gRPC status code names so errors relayed from backend
+// services and errors raised by the gateway itself share one vocabulary.
+package apierror
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Stable error codes.
+const (
+	CodeInvalidArgument    = "INVALID_ARGUMENT"
+	CodeFailedPrecondition = "FAILED_PRECONDITION"
+	CodeOutOfRange         = "OUT_OF_RANGE"
+	CodeUnauthenticated    = "UNAUTHENTICATED"
+	CodePermissionDenied   = "PERMISSION_DENIED"
+	CodeNotFound           = "NOT_FOUND"
+	CodeAlreadyExists      = "ALREADY_EXISTS"
+	CodeAborted            = "ABORTED"
+	CodeResourceExhausted  = "RESOURCE_EXHAUSTED"
+	CodeCancelled          = "CANCELLED"
+	CodeDeadlineExceeded   = "DEADLINE_EXCEEDED"
+	CodeUnimplemented      = "UNIMPLEMENTED"
+	CodeUnavailable        = "UNAVAILABLE"
+	CodeInternal           = "INTERNAL"
+	CodeUnknown            = "UNKNOWN"
+)
+
+// FieldViolation describes why a single request field is invalid.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// Error is the body of the "error" member of an error response.
+type Error struct {
+	Status    int              `json:"-"`
+	Code      string           `json:"code"`
+	Message   string           `json:"message"`
+	Details   []FieldViolation `json:"details,omitempty"`
+	RequestID string           `json:"request_id,omitempty"`
+}
+
+// New returns an error with the code conventionally used for the HTTP status.
+func New(status int, message string) *Error {
+	return &Error{Status: status, Code: CodeForStatus(status), Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// CodeForStatus returns the code for errors raised with an HTTP status.
+func CodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return CodeInvalidArgument
+	case http.StatusUnauthorized:
+		return CodeUnauthenticated
+	case http.StatusForbidden:
+		return CodePermissionDenied
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeAborted
+	case http.StatusPreconditionFailed:
+		return CodeFailedPrecondition
+	case http.StatusTooManyRequests:
+		return CodeResourceExhausted
+	case http.StatusNotImplemented:
+		return CodeUnimplemented
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeDeadlineExceeded
+	}
+	if status >= 500 {
+		return CodeInternal
+	}
+	return CodeUnknown
+}
+
+// Envelope wraps e for the response body, stamping the request ID.
+// Handlers that return extra members alongside the error add them to the map.
+func Envelope(c *gin.Context, e *Error) gin.H {
+	if e.RequestID == "" {
+		e.RequestID = RequestIDFrom(c)
+	}
+	return gin.H{"error": e}
+}
+
+// Respond writes e and aborts the remaining handlers.
+func Respond(c *gin.Context, e *Error) {
+	c.AbortWithStatusJSON(e.Status, Envelope(c, e))
+}
+
+// Abort responds with a plain error for the HTTP status.
+func Abort(c *gin.Context, status int, message string) {
+	Respond(c, New(status, message))
+}
@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// KeyPrefix starts every API key, so keys can be told apart from JWTs and
+// spotted by secret scanners.
+const KeyPrefix = "gsk_"
+
+// keyCacheTTL is how long a verified key is trusted without a database
+// lookup; revocation takes effect within this time.
+const keyCacheTTL = 30 * time.Second
+
+// ErrInvalidKey is returned for unknown or revoked API keys.
+var ErrInvalidKey = errors.New("invalid API key")
+
+// APIKey is a stored API key. Only the SHA-256 hash of the key is kept;
+// the key itself is shown once, when it is created.
+type APIKey struct {
+	ID        int        `json:"id" gorm:"primaryKey"`
+	UserID    int        `json:"user_id" gorm:"not null;index"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix" gorm:"not null"` // First characters of the key, for display
+	Hash      string     `json:"-" gorm:"not null;uniqueIndex"`
+	Scopes    string     `json:"scopes"` // Space-separated
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// KeyStore manages API keys in Postgres.
+type KeyStore struct {
+	db *gorm.DB
+
+	mu    sync.Mutex
+	cache map[string]cachedKey // hash -> identity
+}
+
+type cachedKey struct {
+	identity *Identity
+	expires  time.Time
+}
+
+// NewKeyStore connects to Postgres.
+func NewKeyStore(connStr string) (*KeyStore, error) {
+	db, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	return &KeyStore{db: db, cache: make(map[string]cachedKey)}, nil
+}
+
+// AutoMigrate creates the api_keys table if needed.
+func (s *KeyStore) AutoMigrate() error {
+	if err := s.db.AutoMigrate(&APIKey{}); err != nil {
+		return fmt.Errorf("failed to auto migrate schema: %w", err)
+	}
+	return nil
+}
+
+// CreateKey issues a new key for a user and returns it. The returned string
+// cannot be recovered later.
+func (s *KeyStore) CreateKey(userID int, name string, scopes []string) (string, *APIKey, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, err
+	}
+	raw := KeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	key := &APIKey{
+		UserID: userID,
+		Name:   name,
+		Prefix: raw[:len(KeyPrefix)+8],
+		Hash:   hashKey(raw),
+		Scopes: strings.Join(scopes, " "),
+	}
+	if err := s.db.Create(key).Error; err != nil {
+		return "", nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+	return raw, key, nil
+}
+
+// ListKeys returns a user's keys, including revoked ones.
+func (s *KeyStore) ListKeys(userID int) ([]APIKey, error) {
+	var keys []APIKey
+	if err := s.db.Where("user_id = ?", userID).Order("id").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeKey revokes a key by ID. Returns false if no active key had that ID.
+func (s *KeyStore) RevokeKey(id int) (bool, error) {
+	result := s.db.Model(&APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to revoke API key: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Authenticate returns the identity of an API key.
+func (s *KeyStore) Authenticate(ctx context.Context, raw string) (*Identity, error) {
+	if !strings.HasPrefix(raw, KeyPrefix) {
+		return nil, ErrInvalidKey
+	}
+	hash := hashKey(raw)
+	now := time.Now()
+
+	s.mu.Lock()
+	if c, ok := s.cache[hash]; ok && now.Before(c.expires) {
+		s.mu.Unlock()
+		return c.identity, nil
+	}
+	s.mu.Unlock()
+
+	var key APIKey
+	err := s.db.WithContext(ctx).Where("hash = ? AND revoked_at IS NULL", hash).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up API key: %w", err)
+	}
+
+	identity := &Identity{
+		UserID: int32(key.UserID),
+		KeyID:  key.ID,
+		Scopes: strings.Fields(key.Scopes),
+		Method: MethodAPIKey,
+	}
+
+	s.mu.Lock()
+	// Drop expired entries so revoked keys do not accumulate
+	for h, c := range s.cache {
+		if !now.Before(c.expires) {
+			delete(s.cache, h)
+		}
+	}
+	s.cache[hash] = cachedKey{identity: identity, expires: now.Add(keyCacheTTL)}
+	s.mu.Unlock()
+
+	return identity, nil
+}
+
+// Close closes the database connection.
+func (s *KeyStore) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// hashKey returns the stored form of a key. API keys carry 256 bits of
+// randomness, so a fast hash is enough; no salt or stretching is needed.
+func hashKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
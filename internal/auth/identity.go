@@ -0,0 +1,53 @@
+// Package auth authenticates gateway callers with API keys or JWTs.
+package auth
+
+import (
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScopeAdmin allows queries across users.
+const ScopeAdmin = "admin"
+
+// Authentication methods.
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+)
+
+// identityKey is the gin context key holding the caller's Identity.
+const identityKey = "auth.identity"
+
+// Identity is the authenticated caller.
+type Identity struct {
+	UserID int32
+	KeyID  int // API key used, if any
+	Scopes []string
+	Method string // MethodAPIKey or MethodJWT
+}
+
+// HasScope reports whether the identity was granted scope.
+func (id *Identity) HasScope(scope string) bool {
+	return slices.Contains(id.Scopes, scope)
+}
+
+// IsAdmin reports whether the identity may act on other users' data.
+func (id *Identity) IsAdmin() bool {
+	return id.HasScope(ScopeAdmin)
+}
+
+// SetIdentity stores the caller's identity on the request.
+func SetIdentity(c *gin.Context, id *Identity) {
+	c.Set(identityKey, id)
+}
+
+// FromContext returns the identity set by the middleware, or nil.
+func FromContext(c *gin.Context) *Identity {
+	v, ok := c.Get(identityKey)
+	if !ok {
+		return nil
+	}
+	id, _ := v.(*Identity)
+	return id
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func writeJWKS(t *testing.T, kid string, pub *rsa.PublicKey) string {
+	t.Helper()
+	set := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestJWTVerifier(t *testing.T) {
+	secret := []byte("test-secret")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := NewJWTVerifier(secret, writeJWKS(t, "k1", &rsaKey.PublicKey), "gostocks-test", "")
+	if err != nil {
+		t.Fatalf("NewJWTVerifier() error = %v", err)
+	}
+
+	now := time.Now()
+	claims := func(sub string, exp time.Time) jwt.MapClaims {
+		return jwt.MapClaims{"sub": sub, "iss": "gostocks-test", "exp": exp.Unix(), "scope": "alerts:write admin"}
+	}
+	sign := func(method jwt.SigningMethod, kid string, key interface{}, c jwt.MapClaims) string {
+		tok := jwt.NewWithClaims(method, c)
+		if kid != "" {
+			tok.Header["kid"] = kid
+		}
+		s, err := tok.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"HS256", sign(jwt.SigningMethodHS256, "", secret, claims("7", now.Add(time.Hour))), false},
+		{"RS256 with kid", sign(jwt.SigningMethodRS256, "k1", rsaKey, claims("7", now.Add(time.Hour))), false},
+		{"RS256 without kid uses the only key", sign(jwt.SigningMethodRS256, "", rsaKey, claims("7", now.Add(time.Hour))), false},
+		{"expired", sign(jwt.SigningMethodHS256, "", secret, claims("7", now.Add(-time.Hour))), true},
+		{"wrong secret", sign(jwt.SigningMethodHS256, "", []byte("other"), claims("7", now.Add(time.Hour))), true},
+		{"unknown RSA key", sign(jwt.SigningMethodRS256, "k2", otherKey, claims("7", now.Add(time.Hour))), true},
+		{"wrong issuer", sign(jwt.SigningMethodHS256, "", secret, jwt.MapClaims{"sub": "7", "iss": "evil", "exp": now.Add(time.Hour).Unix()}), true},
+		{"missing expiry", sign(jwt.SigningMethodHS256, "", secret, jwt.MapClaims{"sub": "7", "iss": "gostocks-test"}), true},
+		{"non-numeric subject", sign(jwt.SigningMethodHS256, "", secret, claims("alice", now.Add(time.Hour))), true},
+		{"unsigned", sign(jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, claims("7", now.Add(time.Hour))), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := v.Verify(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if id.UserID != 7 || !id.IsAdmin() || !id.HasScope("alerts:write") || id.Method != MethodJWT {
+				t.Errorf("Verify() = %+v, want user 7 with admin and alerts:write", id)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tiongMax/gostocks/internal/apierror"
+)
+
+// Authenticator resolves request credentials to an Identity.
+type Authenticator struct {
+	keys *KeyStore
+	jwt  *JWTVerifier
+}
+
+// NewAuthenticator creates an Authenticator. jwt may be nil to accept
+// API keys only.
+func NewAuthenticator(keys *KeyStore, jwt *JWTVerifier) *Authenticator {
+	return &Authenticator{keys: keys, jwt: jwt}
+}
+
+// Middleware rejects requests without valid credentials and stores the
+// caller's identity for FromContext.
+//
+// Credentials are read from "Authorization: Bearer <key or JWT>" or the
+// X-API-Key header. See TokenFromQuery for browser clients.
+func (a *Authenticator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		credential := credentialFrom(c)
+		if credential == "" {
+			abortUnauthorized(c, "credentials required")
+			return
+		}
+
+		var identity *Identity
+		var err error
+		switch {
+		case strings.HasPrefix(credential, KeyPrefix):
+			identity, err = a.keys.Authenticate(c.Request.Context(), credential)
+			if err != nil && !errors.Is(err, ErrInvalidKey) {
+				slog.Error("API key lookup failed", "error", err)
+				apierror.Abort(c, http.StatusInternalServerError, "authentication unavailable")
+				return
+			}
+		case a.jwt != nil:
+			identity, err = a.jwt.Verify(credential)
+		default:
+			err = errors.New("JWT authentication is not configured")
+		}
+		if err != nil {
+			slog.Debug("Authentication failed", "error", err)
+			abortUnauthorized(c, "invalid credentials")
+			return
+		}
+
+		SetIdentity(c, identity)
+		c.Next()
+	}
+}
+
+func credentialFrom(c *gin.Context) string {
+	if h := c.GetHeader("Authorization"); h != "" {
+		scheme, token, ok := strings.Cut(h, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+		return ""
+	}
+	return c.GetHeader("X-API-Key")
+}
+
+// TokenFromQuery moves an access_token query parameter into the
+// Authorization header. Browsers cannot set headers on WebSocket and
+// EventSource connections, so they pass the credential in the URL instead.
+// Register it before the request logger so the token is never logged.
+func TokenFromQuery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		q := c.Request.URL.Query()
+		token := q.Get("access_token")
+		if token == "" {
+			c.Next()
+			return
+		}
+
+		q.Del("access_token")
+		c.Request.URL.RawQuery = q.Encode()
+		if c.GetHeader("Authorization") == "" {
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+		c.Next()
+	}
+}
+
+func abortUnauthorized(c *gin.Context, msg string) {
+	c.Header("WWW-Authenticate", `Bearer realm="gostocks"`)
+	apierror.Abort(c, http.StatusUnauthorized, msg)
+}
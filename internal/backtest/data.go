@@ -0,0 +1,228 @@
+package backtest
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/history"
+	"github.com/tiongMax/gostocks/proto/stock"
+	"google.golang.org/protobuf/encoding/protodelim"
+)
+
+// maxEvents caps the ticks or candles of a symbol loaded for one run.
+const maxEvents = 5000000
+
+// Event is one tick or one candle of recorded market data.
+type Event struct {
+	Tick   *history.Tick
+	Candle *history.Candle
+}
+
+// Time returns the time of the tick, or the start of the candle.
+func (e Event) Time() time.Time {
+	if e.Candle != nil {
+		return e.Candle.Start
+	}
+	return e.Tick.Time
+}
+
+// Symbol returns the symbol traded.
+func (e Event) Symbol() string {
+	if e.Candle != nil {
+		return e.Candle.Symbol
+	}
+	return e.Tick.Symbol
+}
+
+// sortEvents orders events by time, then symbol, then tick sequence, so
+// every run replays the same data in the same order.
+func sortEvents(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if ta, tb := a.Time(), b.Time(); !ta.Equal(tb) {
+			return ta.Before(tb)
+		}
+		if sa, sb := a.Symbol(), b.Symbol(); sa != sb {
+			return sa < sb
+		}
+		return a.Tick != nil && b.Tick != nil && a.Tick.Seq < b.Tick.Seq
+	})
+}
+
+// LoadHistory reads the ticks of symbols in [from, to) from the time-series
+// store, or their candles if interval is not 0.
+func LoadHistory(ctx context.Context, r *history.Reader, symbols []string, from, to time.Time, interval time.Duration) ([]Event, error) {
+	var events []Event
+	for _, symbol := range symbols {
+		if interval == 0 {
+			ticks, err := r.Ticks(ctx, symbol, from, to, maxEvents+1)
+			if err != nil {
+				return nil, err
+			}
+			if len(ticks) > maxEvents {
+				return nil, fmt.Errorf("%s has more than %d ticks in the range; narrow it or use candles", symbol, maxEvents)
+			}
+			for i := range ticks {
+				events = append(events, Event{Tick: &ticks[i]})
+			}
+			continue
+		}
+
+		candles, err := r.Candles(ctx, symbol, interval, from, to, maxEvents+1)
+		if err != nil {
+			return nil, err
+		}
+		if len(candles) > maxEvents {
+			return nil, fmt.Errorf("%s has more than %d candles in the range; narrow it or use a coarser interval", symbol, maxEvents)
+		}
+		for i := range candles {
+			events = append(events, Event{Candle: &candles[i]})
+		}
+	}
+	sortEvents(events)
+	return events, nil
+}
+
+// A segment file records ticks as the ingestor publishes them to Kafka: a
+// sequence of StockTick messages, each prefixed with its length as a
+// varint. Files whose name ends in .gz are gzip-compressed.
+
+// ReadSegments reads the ticks of symbols in [from, to) from segment files.
+// All symbols are read if none are given; a zero to reads to the end.
+// Ticks with the same timestamp keep their order in the files.
+func ReadSegments(paths []string, symbols []string, from, to time.Time) ([]Event, error) {
+	want := make(map[string]bool, len(symbols))
+	for _, s := range symbols {
+		want[s] = true
+	}
+
+	var events []Event
+	var seq int64
+	for _, path := range paths {
+		err := readSegment(path, func(t *stock.StockTick) {
+			seq++
+			at := time.UnixMilli(t.Timestamp).UTC()
+			if len(want) > 0 && !want[t.Symbol] || at.Before(from) || !to.IsZero() && !at.Before(to) {
+				return
+			}
+			events = append(events, Event{Tick: &history.Tick{
+				Symbol: t.Symbol,
+				Time:   at,
+				Seq:    seq,
+				Price:  t.Price,
+				Volume: t.Volume,
+			}})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sortEvents(events)
+	return events, nil
+}
+
+func readSegment(path string, fn func(*stock.StockTick)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	br := bufio.NewReader(r)
+	for {
+		tick := &stock.StockTick{}
+		err := protodelim.UnmarshalFrom(br, tick)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		fn(tick)
+	}
+}
+
+// WriteSegment records ticks to a segment file, gzip-compressed if its name
+// ends in .gz.
+func WriteSegment(path string, ticks []history.Tick) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	var w io.Writer = f
+	var gz *gzip.Writer
+	if strings.HasSuffix(path, ".gz") {
+		gz = gzip.NewWriter(f)
+		w = gz
+	}
+	bw := bufio.NewWriter(w)
+	for _, t := range ticks {
+		_, err := protodelim.MarshalTo(bw, &stock.StockTick{
+			Symbol:    t.Symbol,
+			Price:     t.Price,
+			Timestamp: t.Time.UnixMilli(),
+			Volume:    t.Volume,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if gz != nil {
+		return gz.Close()
+	}
+	return nil
+}
+
+// Candles aggregates tick events into candles of the given interval, aligned
+// to the Unix epoch in UTC like the stored candles.
+func Candles(events []Event, interval time.Duration) []Event {
+	open := make(map[string]*history.Candle)
+	var candles []Event
+	for _, e := range events {
+		t := e.Tick
+		if t == nil {
+			continue
+		}
+		start := t.Time.Truncate(interval)
+		c, ok := open[t.Symbol]
+		if !ok || !c.Start.Equal(start) {
+			c = &history.Candle{Symbol: t.Symbol, Start: start, Open: t.Price, High: t.Price, Low: t.Price}
+			open[t.Symbol] = c
+			candles = append(candles, Event{Candle: c})
+		}
+		c.High = max(c.High, t.Price)
+		c.Low = min(c.Low, t.Price)
+		c.Close = t.Price
+		c.Volume += t.Volume
+		c.Trades++
+	}
+	sortEvents(candles)
+	return candles
+}
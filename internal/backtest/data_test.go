@@ -0,0 +1,65 @@
+package backtest
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/history"
+)
+
+func TestSegments(t *testing.T) {
+	start := time.Date(2026, 3, 2, 14, 30, 0, 0, time.UTC)
+	ticks := []history.Tick{
+		{Symbol: "AAPL", Time: start, Price: 100, Volume: 5},
+		{Symbol: "MSFT", Time: start, Price: 400, Volume: 1},
+		{Symbol: "AAPL", Time: start, Price: 101, Volume: 2},
+		{Symbol: "AAPL", Time: start.Add(time.Minute), Price: 102, Volume: 3},
+	}
+
+	for _, name := range []string{"ticks.seg", "ticks.seg.gz"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := WriteSegment(path, ticks); err != nil {
+				t.Fatal(err)
+			}
+
+			events, err := ReadSegments([]string{path}, []string{"AAPL"}, start, start.Add(time.Minute))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(events) != 2 || events[0].Tick.Price != 100 || events[1].Tick.Price != 101 {
+				t.Fatalf("events = %+v, want the first two AAPL ticks in file order", events)
+			}
+
+			all, err := ReadSegments([]string{path}, nil, time.Time{}, time.Time{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(all) != 4 || all[2].Symbol() != "MSFT" || !all[3].Time().Equal(start.Add(time.Minute)) {
+				t.Errorf("all events = %+v", all)
+			}
+		})
+	}
+}
+
+func TestCandles(t *testing.T) {
+	start := time.Date(2026, 3, 2, 14, 30, 0, 0, time.UTC)
+	events := ticks(start, 100, 103, 98, 101)
+	for i, e := range events {
+		e.Tick.Time = start.Add(time.Duration(i) * 20 * time.Second)
+	}
+
+	candles := Candles(events, time.Minute)
+	if len(candles) != 2 {
+		t.Fatalf("got %d candles, want 2", len(candles))
+	}
+	c := *candles[0].Candle
+	want := history.Candle{Symbol: "AAPL", Start: start, Open: 100, High: 103, Low: 98, Close: 98, Trades: 3}
+	if c != want {
+		t.Errorf("first candle = %+v, want %+v", c, want)
+	}
+	if c := candles[1].Candle; !c.Start.Equal(start.Add(time.Minute)) || c.Open != 101 || c.Trades != 1 {
+		t.Errorf("second candle = %+v", c)
+	}
+}
@@ -0,0 +1,203 @@
+package backtest
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/history"
+	"github.com/tiongMax/gostocks/internal/trading"
+)
+
+// tradingDaysPerYear annualizes the Sharpe ratio of daily returns.
+const tradingDaysPerYear = 252
+
+// Config sets up the simulated account of a run.
+type Config struct {
+	StartingCash float64
+	Execution    trading.ExecutionModel
+
+	// CurveInterval is the resolution of the equity curve; the last equity
+	// of each interval is kept. Zero means one point per minute.
+	CurveInterval time.Duration
+}
+
+// EquityPoint is the account's value at the end of an interval of the run.
+type EquityPoint struct {
+	Time     time.Time `json:"time"`
+	Equity   float64   `json:"equity"`
+	Drawdown float64   `json:"drawdown"` // Fraction below the running peak
+}
+
+// Metrics summarizes a run.
+type Metrics struct {
+	StartingCash float64 `json:"starting_cash"`
+	FinalEquity  float64 `json:"final_equity"`
+	TotalReturn  float64 `json:"total_return"` // Fraction of the starting cash
+	MaxDrawdown  float64 `json:"max_drawdown"` // Largest fall from a peak, as a fraction of it
+	Sharpe       float64 `json:"sharpe"`       // Annualized, from daily returns
+	Trades       int     `json:"trades"`       // Fills
+	WinRate      float64 `json:"win_rate"`     // Fraction of sells with a positive realized P&L
+	RealizedPnL  float64 `json:"realized_pnl"`
+	Fees         float64 `json:"fees"`
+	Rejected     int     `json:"rejected_orders"`
+	Cancelled    int     `json:"cancelled_orders"`
+	Events       int     `json:"events"` // Ticks or candles replayed
+}
+
+// Result is the outcome of a run. Strategy and Params are left for the
+// caller to describe the run.
+type Result struct {
+	Strategy string        `json:"strategy,omitempty"`
+	Params   Params        `json:"params,omitempty"`
+	From     time.Time     `json:"from"`
+	To       time.Time     `json:"to"`
+	Metrics  Metrics       `json:"metrics"`
+	Equity   []EquityPoint `json:"equity"`
+	Trades   []Trade       `json:"trades"`
+}
+
+// Run replays events, oldest first, through a strategy. Before the strategy
+// sees an event, the orders it placed earlier on the event's symbol are
+// matched against it: a tick at its price, a candle along its open, low,
+// high and close (open, high, low and close for a falling candle). Runs
+// depend only on their input, so the same events, config and strategy
+// always give the same result.
+func Run(ctx context.Context, cfg Config, s Strategy, events []Event) (*Result, error) {
+	interval := cfg.CurveInterval
+	if interval <= 0 {
+		interval = history.BaseInterval
+	}
+
+	b := newBroker(cfg.StartingCash, cfg.Execution)
+	r := &Result{Metrics: Metrics{StartingCash: cfg.StartingCash, Events: len(events)}}
+	peak := cfg.StartingCash
+	var last EquityPoint
+
+	for i, e := range events {
+		if i%4096 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		b.event, b.now = i, e.Time()
+
+		if e.Candle != nil {
+			c := *e.Candle
+			b.match(s, c.Symbol, candlePath(c))
+			b.prices[c.Symbol] = c.Close
+			s.OnCandle(b, c)
+		} else {
+			t := *e.Tick
+			b.match(s, t.Symbol, []float64{t.Price})
+			b.prices[t.Symbol] = t.Price
+			s.OnTick(b, t)
+		}
+
+		equity := b.Equity()
+		peak = math.Max(peak, equity)
+		drawdown := 0.0
+		if peak > 0 {
+			drawdown = (peak - equity) / peak
+		}
+		r.Metrics.MaxDrawdown = math.Max(r.Metrics.MaxDrawdown, drawdown)
+
+		if i > 0 && !b.now.Truncate(interval).Equal(last.Time.Truncate(interval)) {
+			r.Equity = append(r.Equity, last)
+		}
+		last = EquityPoint{Time: b.now, Equity: equity, Drawdown: drawdown}
+	}
+
+	if len(events) > 0 {
+		r.Equity = append(r.Equity, last)
+		r.From, r.To = events[0].Time(), last.Time
+	}
+	r.Trades = b.trades
+	summarize(r, b)
+	return r, nil
+}
+
+// candlePath is the order in which a candle's prices are assumed to have
+// traded: towards the nearer extreme first.
+func candlePath(c history.Candle) []float64 {
+	if c.Close < c.Open {
+		return []float64{c.Open, c.High, c.Low, c.Close}
+	}
+	return []float64{c.Open, c.Low, c.High, c.Close}
+}
+
+// summarize fills in the metrics of a finished run.
+func summarize(r *Result, b *Broker) {
+	m := &r.Metrics
+	m.FinalEquity = b.Equity()
+	if m.StartingCash > 0 {
+		m.TotalReturn = m.FinalEquity/m.StartingCash - 1
+	}
+	m.Trades = len(r.Trades)
+	m.Rejected, m.Cancelled = b.rejected, b.cancelled
+
+	sells, wins := 0, 0
+	for _, t := range r.Trades {
+		m.Fees += t.Fee
+		if t.Side == trading.SideSell {
+			sells++
+			m.RealizedPnL += t.RealizedPnL
+			if t.RealizedPnL > 0 {
+				wins++
+			}
+		}
+	}
+	if sells > 0 {
+		m.WinRate = float64(wins) / float64(sells)
+	}
+	m.Sharpe = sharpe(dailyReturns(m.StartingCash, r.Equity), tradingDaysPerYear)
+}
+
+// dailyReturns returns the change of the closing equity of each UTC day
+// with data from that of the previous one, the first day being measured
+// from the starting cash.
+func dailyReturns(start float64, curve []EquityPoint) []float64 {
+	var returns []float64
+	prev := start
+	for i, p := range curve {
+		if i+1 < len(curve) && sameDay(p.Time, curve[i+1].Time) {
+			continue
+		}
+		if prev > 0 {
+			returns = append(returns, p.Equity/prev-1)
+		}
+		prev = p.Equity
+	}
+	return returns
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.UTC().Date()
+	by, bm, bd := b.UTC().Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// sharpe returns the annualized Sharpe ratio of periodic returns with a zero
+// risk-free rate, or 0 if there are fewer than two returns or they do not
+// vary.
+func sharpe(returns []float64, periodsPerYear float64) float64 {
+	n := float64(len(returns))
+	if n < 2 {
+		return 0
+	}
+	mean := 0.0
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= n
+
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	sd := math.Sqrt(variance / (n - 1))
+	if sd == 0 {
+		return 0
+	}
+	return mean / sd * math.Sqrt(periodsPerYear)
+}
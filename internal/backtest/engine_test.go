@@ -0,0 +1,200 @@
+package backtest
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/history"
+	"github.com/tiongMax/gostocks/internal/trading"
+)
+
+// script submits the orders it was given when it sees the event of that index.
+type script struct {
+	orders map[int][]trading.Order
+	seen   int
+	fills  []Trade
+}
+
+func (s *script) step(b *Broker) {
+	for _, o := range s.orders[s.seen] {
+		if _, err := b.Submit(o); err != nil {
+			panic(err)
+		}
+	}
+	s.seen++
+}
+
+func (s *script) OnTick(b *Broker, t history.Tick)     { s.step(b) }
+func (s *script) OnCandle(b *Broker, c history.Candle) { s.step(b) }
+func (s *script) OnFill(b *Broker, t Trade)            { s.fills = append(s.fills, t) }
+
+func ticks(start time.Time, prices ...float64) []Event {
+	events := make([]Event, len(prices))
+	for i, p := range prices {
+		events[i] = Event{Tick: &history.Tick{Symbol: "AAPL", Time: start.Add(time.Duration(i) * time.Hour), Seq: int64(i), Price: p}}
+	}
+	return events
+}
+
+func TestRun(t *testing.T) {
+	start := time.Date(2026, 3, 2, 14, 30, 0, 0, time.UTC)
+	events := ticks(start, 100, 110, 90, 120, 130)
+	cfg := Config{StartingCash: 10000, Execution: trading.ExecutionModel{SlippageBps: 10, FeePerOrder: 1}}
+	newScript := func() *script {
+		return &script{orders: map[int][]trading.Order{
+			0: {{Symbol: "AAPL", Side: trading.SideBuy, Type: trading.TypeMarket, Quantity: 10}},
+			1: {{Symbol: "AAPL", Side: trading.SideSell, Type: trading.TypeStop, Quantity: 10, StopPrice: 95}},
+			3: {{Symbol: "AAPL", Side: trading.SideBuy, Type: trading.TypeMarket, Quantity: 1000}},
+		}}
+	}
+
+	s := newScript()
+	r, err := Run(context.Background(), cfg, s, events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The buy fills at the next tick, the stop at the drop to 90
+	if len(r.Trades) != 2 || len(s.fills) != 2 {
+		t.Fatalf("got trades %+v, fills %+v", r.Trades, s.fills)
+	}
+	buy, sell := r.Trades[0], r.Trades[1]
+	if math.Abs(buy.Price-110.11) > 1e-9 || !buy.Time.Equal(events[1].Time()) {
+		t.Errorf("buy = %+v, want 110.11 at the second tick", buy)
+	}
+	if math.Abs(sell.Price-89.91) > 1e-9 || !sell.Time.Equal(events[2].Time()) {
+		t.Errorf("sell = %+v, want 89.91 at the third tick", sell)
+	}
+	wantPnL := 10*89.91 - 1 - (10*110.11 + 1)
+	if math.Abs(sell.RealizedPnL-wantPnL) > 1e-9 {
+		t.Errorf("realized P&L = %v, want %v", sell.RealizedPnL, wantPnL)
+	}
+
+	m := r.Metrics
+	if math.Abs(m.FinalEquity-(10000+wantPnL)) > 1e-9 || m.Rejected != 1 || m.Trades != 2 || m.WinRate != 0 || m.Fees != 2 {
+		t.Errorf("metrics = %+v", m)
+	}
+	// The buy's costs never recover, so the peak is the starting cash
+	if wantDD := -wantPnL / 10000; math.Abs(m.MaxDrawdown-wantDD) > 1e-9 {
+		t.Errorf("max drawdown = %v, want %v", m.MaxDrawdown, wantDD)
+	}
+	if len(r.Equity) != len(events) || !r.From.Equal(start) || !r.To.Equal(events[4].Time()) {
+		t.Errorf("equity curve %+v from %v to %v", r.Equity, r.From, r.To)
+	}
+
+	again, err := Run(context.Background(), cfg, newScript(), events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r, again) {
+		t.Error("a second run gave a different result")
+	}
+}
+
+func TestRunCandlePath(t *testing.T) {
+	start := time.Date(2026, 3, 2, 14, 30, 0, 0, time.UTC)
+	candles := []history.Candle{
+		{Symbol: "AAPL", Start: start, Open: 100, High: 101, Low: 99, Close: 100},
+		{Symbol: "AAPL", Start: start.Add(time.Minute), Open: 100, High: 106, Low: 94, Close: 95},
+	}
+	events := []Event{{Candle: &candles[0]}, {Candle: &candles[1]}}
+
+	tests := []struct {
+		name  string
+		order trading.Order
+		price float64
+	}{
+		{"market fills at the open", trading.Order{Side: trading.SideBuy, Type: trading.TypeMarket}, 100},
+		{"limit buy fills at the low", trading.Order{Side: trading.SideBuy, Type: trading.TypeLimit, LimitPrice: 96}, 94},
+		{"stop buy triggers at the high first", trading.Order{Side: trading.SideBuy, Type: trading.TypeStop, StopPrice: 105}, 106},
+		{"stop sell triggers at the low", trading.Order{Side: trading.SideSell, Type: trading.TypeStop, StopPrice: 97}, 94},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := tt.order
+			o.Symbol, o.Quantity = "AAPL", 1
+			orders := []trading.Order{o}
+			if o.Side == trading.SideSell {
+				orders = []trading.Order{{Symbol: "AAPL", Side: trading.SideBuy, Type: trading.TypeLimit, Quantity: 1, LimitPrice: 99}, o}
+			}
+			r, err := Run(context.Background(), Config{StartingCash: 1000}, &script{orders: map[int][]trading.Order{0: orders}}, events)
+			if err != nil {
+				t.Fatal(err)
+			}
+			last := r.Trades[len(r.Trades)-1]
+			if last.Side != o.Side || last.Price != tt.price {
+				t.Errorf("trades = %+v, want %s at %v", r.Trades, o.Side, tt.price)
+			}
+		})
+	}
+}
+
+func TestSharpe(t *testing.T) {
+	day := time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+	curve := []EquityPoint{
+		{Time: day.Add(-time.Hour), Equity: 50},
+		{Time: day, Equity: 110},
+		{Time: day.Add(24 * time.Hour), Equity: 99},
+		{Time: day.Add(48 * time.Hour), Equity: 108.9},
+	}
+	returns := dailyReturns(100, curve)
+	want := []float64{0.1, -0.1, 0.1}
+	for i := range want {
+		if i >= len(returns) || math.Abs(returns[i]-want[i]) > 1e-9 {
+			t.Fatalf("daily returns = %v, want %v", returns, want)
+		}
+	}
+
+	mean, sd := 0.1/3, math.Sqrt((2*math.Pow(0.1-0.1/3, 2)+math.Pow(-0.1-0.1/3, 2))/2)
+	if got := sharpe(returns, 252); math.Abs(got-mean/sd*math.Sqrt(252)) > 1e-9 {
+		t.Errorf("sharpe = %v", got)
+	}
+	if got := sharpe([]float64{0.1}, 252); got != 0 {
+		t.Errorf("sharpe of one return = %v, want 0", got)
+	}
+}
+
+func TestSweep(t *testing.T) {
+	start := time.Date(2026, 3, 2, 14, 30, 0, 0, time.UTC)
+	var prices []float64
+	for i := 0; i < 200; i++ {
+		prices = append(prices, 100+10*math.Sin(float64(i)/7))
+	}
+	events := ticks(start, prices...)
+	cfg := Config{StartingCash: 10000, Execution: trading.ExecutionModel{SlippageBps: 5}}
+
+	grid := Grid(map[string][]float64{"slow": {20, 40}, "fast": {3, 5, 40}})
+	if len(grid) != 6 || grid[0]["fast"] != 3 || grid[0]["slow"] != 20 || grid[1]["slow"] != 40 {
+		t.Fatalf("grid = %v", grid)
+	}
+
+	newStrategy := func(p Params) (Strategy, error) { return NewStrategy("sma-cross", p) }
+	results := Sweep(context.Background(), cfg, events, newStrategy, grid, 4)
+	for i, sr := range results {
+		if !reflect.DeepEqual(sr.Params, grid[i]) {
+			t.Fatalf("result %d has params %v, want %v", i, sr.Params, grid[i])
+		}
+		if grid[i]["fast"] >= grid[i]["slow"] {
+			if sr.Err == nil {
+				t.Errorf("params %v: want an error", grid[i])
+			}
+			continue
+		}
+		s, _ := newStrategy(grid[i])
+		want, err := Run(context.Background(), cfg, s, events)
+		if err != nil || sr.Err != nil {
+			t.Fatalf("params %v: %v, %v", grid[i], err, sr.Err)
+		}
+		want.Params = grid[i]
+		if !reflect.DeepEqual(sr.Result, want) {
+			t.Errorf("params %v: sweep result differs from a single run", grid[i])
+		}
+		if sr.Result.Metrics.Trades == 0 {
+			t.Errorf("params %v: no trades", grid[i])
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package backtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strings"
+)
+
+const (
+	// chartPoints caps the points drawn per chart of the HTML report.
+	chartPoints = 1000
+
+	chartWidth  = 900
+	chartHeight = 240
+)
+
+// WriteJSON writes a run's result as indented JSON.
+func WriteJSON(w io.Writer, r *Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteHTML writes a self-contained HTML report of a run: its metrics, the
+// equity and drawdown curves, and the trades.
+func WriteHTML(w io.Writer, r *Result) error {
+	equity := make([]float64, len(r.Equity))
+	drawdown := make([]float64, len(r.Equity))
+	for i, p := range r.Equity {
+		equity[i], drawdown[i] = p.Equity, -p.Drawdown
+	}
+	return reportTemplate.Execute(w, map[string]interface{}{
+		"Result":   r,
+		"Params":   r.Params.String(),
+		"Equity":   polyline(equity),
+		"Drawdown": polyline(drawdown),
+		"Width":    chartWidth,
+		"Height":   chartHeight,
+	})
+}
+
+// polyline scales values to the chart and returns them as SVG polyline
+// points, keeping at most chartPoints evenly spaced values.
+func polyline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	step := max(1, (len(values)+chartPoints-1)/chartPoints)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	span := hi - lo
+	if span == 0 {
+		span = 1
+	}
+
+	var sb strings.Builder
+	n := float64(max(1, len(values)-1))
+	for i := 0; i < len(values); i += step {
+		x := float64(i) / n * chartWidth
+		y := chartHeight - (values[i]-lo)/span*chartHeight
+		fmt.Fprintf(&sb, "%.1f,%.1f ", x, y)
+	}
+	if (len(values)-1)%step != 0 {
+		fmt.Fprintf(&sb, "%d,%.1f", chartWidth, chartHeight-(values[len(values)-1]-lo)/span*chartHeight)
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"pct":   func(v float64) string { return fmt.Sprintf("%.2f%%", v*100) },
+	"money": func(v float64) string { return fmt.Sprintf("%.2f", v) },
+	"num":   func(v float64) string { return fmt.Sprintf("%g", v) },
+	"time": func(r *Result) string {
+		return r.From.UTC().Format("2006-01-02 15:04") + " – " + r.To.UTC().Format("2006-01-02 15:04") + " UTC"
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Backtest {{.Result.Strategy}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+td, th { padding: 4px 12px; border-bottom: 1px solid #ddd; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+svg { background: #fafafa; border: 1px solid #ddd; margin-bottom: 2em; }
+</style>
+</head>
+<body>
+<h1>Backtest {{.Result.Strategy}}</h1>
+<p>{{.Params}}</p>
+<p>{{time .Result}}, {{.Result.Metrics.Events}} events</p>
+
+{{with .Result.Metrics}}
+<table>
+<tr><td>Starting cash</td><td>{{money .StartingCash}}</td></tr>
+<tr><td>Final equity</td><td>{{money .FinalEquity}}</td></tr>
+<tr><td>Total return</td><td>{{pct .TotalReturn}}</td></tr>
+<tr><td>Max drawdown</td><td>{{pct .MaxDrawdown}}</td></tr>
+<tr><td>Sharpe ratio</td><td>{{printf "%.2f" .Sharpe}}</td></tr>
+<tr><td>Trades</td><td>{{.Trades}}</td></tr>
+<tr><td>Win rate</td><td>{{pct .WinRate}}</td></tr>
+<tr><td>Realized P&amp;L</td><td>{{money .RealizedPnL}}</td></tr>
+<tr><td>Fees</td><td>{{money .Fees}}</td></tr>
+<tr><td>Rejected orders</td><td>{{.Rejected}}</td></tr>
+<tr><td>Cancelled orders</td><td>{{.Cancelled}}</td></tr>
+</table>
+{{end}}
+
+<h2>Equity</h2>
+<svg width="{{.Width}}" height="{{.Height}}"><polyline fill="none" stroke="#2a6fdb" stroke-width="1.5" points="{{.Equity}}"/></svg>
+
+<h2>Drawdown</h2>
+<svg width="{{.Width}}" height="{{.Height}}"><polyline fill="none" stroke="#d9534f" stroke-width="1.5" points="{{.Drawdown}}"/></svg>
+
+<h2>Trades</h2>
+<table>
+<tr><th>Time</th><th>Order</th><th>Symbol</th><th>Side</th><th>Type</th><th>Quantity</th><th>Price</th><th>Fee</th><th>Realized P&amp;L</th></tr>
+{{range .Result.Trades}}<tr><td>{{.Time.UTC.Format "2006-01-02 15:04:05"}}</td><td>{{.OrderID}}</td><td>{{.Symbol}}</td><td>{{.Side}}</td><td>{{.Type}}</td><td>{{num .Quantity}}</td><td>{{printf "%.4f" .Price}}</td><td>{{money .Fee}}</td><td>{{if eq .Side "SELL"}}{{money .RealizedPnL}}{{end}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
@@ -0,0 +1,163 @@
+package backtest
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tiongMax/gostocks/internal/history"
+)
+
+// strategies are the built-in strategies, by name.
+var strategies = map[string]Factory{
+	"buy-and-hold": newBuyAndHold,
+	"sma-cross":    newSMACross,
+}
+
+// NewStrategy creates a built-in strategy by name.
+func NewStrategy(name string, p Params) (Strategy, error) {
+	f, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy %q", name)
+	}
+	return f(p)
+}
+
+// StrategyNames lists the built-in strategies.
+func StrategyNames() []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuyAndHold buys a fixed quantity of every symbol at its first price and
+// holds it. It is the baseline other strategies are compared with.
+type BuyAndHold struct {
+	Quantity float64
+	bought   map[string]bool
+}
+
+// newBuyAndHold reads the quantity parameter (default 10).
+func newBuyAndHold(p Params) (Strategy, error) {
+	q := p.Get("quantity", 10)
+	if q <= 0 {
+		return nil, fmt.Errorf("quantity must be positive")
+	}
+	return &BuyAndHold{Quantity: q, bought: make(map[string]bool)}, nil
+}
+
+func (s *BuyAndHold) OnTick(b *Broker, t history.Tick)     { s.buy(b, t.Symbol) }
+func (s *BuyAndHold) OnCandle(b *Broker, c history.Candle) { s.buy(b, c.Symbol) }
+func (s *BuyAndHold) OnFill(b *Broker, t Trade)            {}
+
+func (s *BuyAndHold) buy(b *Broker, symbol string) {
+	if s.bought[symbol] {
+		return
+	}
+	s.bought[symbol] = true
+	b.Buy(symbol, s.Quantity)
+}
+
+// SMACross holds a fixed quantity of a symbol while its fast simple moving
+// average of prices is above the slow one: it buys when the fast average
+// crosses above the slow one and sells the position when it crosses below.
+// Ticks are averaged by price and candles by close.
+type SMACross struct {
+	Fast, Slow int
+	Quantity   float64
+	symbols    map[string]*crossState
+}
+
+type crossState struct {
+	fast, slow *movingAverage
+	above      bool // Fast average above the slow one at the last price
+	seen       bool // above has been set
+}
+
+// newSMACross reads the fast (default 10) and slow (default 30) window
+// lengths and the quantity (default 10).
+func newSMACross(p Params) (Strategy, error) {
+	s := &SMACross{
+		Fast:     int(p.Get("fast", 10)),
+		Slow:     int(p.Get("slow", 30)),
+		Quantity: p.Get("quantity", 10),
+		symbols:  make(map[string]*crossState),
+	}
+	if s.Fast < 1 || s.Slow <= s.Fast {
+		return nil, fmt.Errorf("need 1 <= fast < slow, got fast %d and slow %d", s.Fast, s.Slow)
+	}
+	if s.Quantity <= 0 {
+		return nil, fmt.Errorf("quantity must be positive")
+	}
+	return s, nil
+}
+
+func (s *SMACross) OnTick(b *Broker, t history.Tick)     { s.update(b, t.Symbol, t.Price) }
+func (s *SMACross) OnCandle(b *Broker, c history.Candle) { s.update(b, c.Symbol, c.Close) }
+func (s *SMACross) OnFill(b *Broker, t Trade)            {}
+
+func (s *SMACross) update(b *Broker, symbol string, price float64) {
+	st, ok := s.symbols[symbol]
+	if !ok {
+		st = &crossState{fast: newMovingAverage(s.Fast), slow: newMovingAverage(s.Slow)}
+		s.symbols[symbol] = st
+	}
+	st.fast.add(price)
+	st.slow.add(price)
+	if !st.slow.full() {
+		return
+	}
+
+	above := st.fast.value() > st.slow.value()
+	crossed := st.seen && above != st.above
+	st.above, st.seen = above, true
+	if !crossed || pending(b, symbol) {
+		return
+	}
+	held := b.Position(symbol).Quantity
+	switch {
+	case above && held == 0:
+		b.Buy(symbol, s.Quantity)
+	case !above && held > 0:
+		b.Sell(symbol, held)
+	}
+}
+
+// pending reports whether a symbol has an open order.
+func pending(b *Broker, symbol string) bool {
+	for _, o := range b.OpenOrders() {
+		if o.Symbol == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// movingAverage is the simple moving average of the last n values.
+type movingAverage struct {
+	values []float64
+	next   int
+	count  int
+	sum    float64
+}
+
+func newMovingAverage(n int) *movingAverage {
+	return &movingAverage{values: make([]float64, n)}
+}
+
+func (m *movingAverage) add(v float64) {
+	m.sum += v - m.values[m.next]
+	m.values[m.next] = v
+	m.next = (m.next + 1) % len(m.values)
+	m.count = min(m.count+1, len(m.values))
+}
+
+func (m *movingAverage) full() bool {
+	return m.count == len(m.values)
+}
+
+func (m *movingAverage) value() float64 {
+	return m.sum / float64(m.count)
+}
@@ -0,0 +1,242 @@
+// Package backtest replays recorded market data through trading strategies.
+// Orders are matched with the execution model of live paper trading and
+// booked with the same ledger, so a strategy sees the fills it would get in
+// the Trading Service.
+package backtest
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/history"
+	"github.com/tiongMax/gostocks/internal/trading"
+)
+
+// Strategy reacts to market data by placing orders through a Broker. A run
+// calls OnTick or OnCandle for every event, depending on the data replayed,
+// and OnFill for each of the strategy's orders that executes. A strategy is
+// used by one run at a time and needs no locking.
+type Strategy interface {
+	OnTick(b *Broker, t history.Tick)
+	OnCandle(b *Broker, c history.Candle)
+	OnFill(b *Broker, t Trade)
+}
+
+// Trade is the fill of an order. RealizedPnL is set for sells.
+type Trade struct {
+	Time        time.Time `json:"time"`
+	OrderID     int64     `json:"order_id"`
+	Symbol      string    `json:"symbol"`
+	Side        string    `json:"side"`
+	Type        string    `json:"type"`
+	Quantity    float64   `json:"quantity"`
+	Price       float64   `json:"price"`
+	Fee         float64   `json:"fee"`
+	RealizedPnL float64   `json:"realized_pnl"`
+}
+
+// order is an open order with the index of the event it was placed during.
+type order struct {
+	trading.Order
+	event int
+}
+
+// Broker is a strategy's simulated account. Orders submitted while handling
+// an event are matched from the next event of their symbol on, as the
+// Trading Service matches them from the next tick.
+type Broker struct {
+	model     trading.ExecutionModel
+	account   trading.Account
+	positions map[string]*trading.Position
+	symbols   []string // Symbols with a position, sorted so sums are deterministic
+	prices    map[string]float64
+	open      []*order // Oldest first
+	nextID    int64
+	now       time.Time
+	event     int // Index of the event being replayed
+
+	trades    []Trade
+	rejected  int
+	cancelled int
+}
+
+func newBroker(cash float64, model trading.ExecutionModel) *Broker {
+	return &Broker{
+		model:     model,
+		account:   trading.Account{Cash: cash, StartingCash: cash},
+		positions: make(map[string]*trading.Position),
+		prices:    make(map[string]float64),
+	}
+}
+
+// Time returns the time of the event being replayed.
+func (b *Broker) Time() time.Time {
+	return b.now
+}
+
+// Cash returns the cash left.
+func (b *Broker) Cash() float64 {
+	return b.account.Cash
+}
+
+// Position returns the holding of a symbol, which is zero if there is none.
+func (b *Broker) Position(symbol string) trading.Position {
+	if pos, ok := b.positions[symbol]; ok {
+		return *pos
+	}
+	return trading.Position{Symbol: symbol}
+}
+
+// Price returns the last traded price of a symbol, or 0 if it has not
+// traded yet. For candles it is the close.
+func (b *Broker) Price(symbol string) float64 {
+	return b.prices[symbol]
+}
+
+// Equity returns the cash plus the positions valued at their last price.
+func (b *Broker) Equity() float64 {
+	equity := b.account.Cash
+	for _, s := range b.symbols {
+		equity += b.positions[s].Quantity * b.prices[s]
+	}
+	return equity
+}
+
+// Submit places an order and returns its ID. Only the symbol, side, type,
+// quantity and limit and stop prices are used; they are validated with the
+// Trading Service's rules.
+func (b *Broker) Submit(o trading.Order) (int64, error) {
+	if err := validate(&o); err != nil {
+		return 0, err
+	}
+	b.nextID++
+	b.open = append(b.open, &order{
+		Order: trading.Order{
+			ID:         b.nextID,
+			Symbol:     o.Symbol,
+			Side:       o.Side,
+			Type:       o.Type,
+			Quantity:   o.Quantity,
+			LimitPrice: o.LimitPrice,
+			StopPrice:  o.StopPrice,
+			Status:     trading.StatusOpen,
+			CreatedAt:  b.now,
+		},
+		event: b.event,
+	})
+	return b.nextID, nil
+}
+
+// Buy places a market order to buy quantity of a symbol.
+func (b *Broker) Buy(symbol string, quantity float64) (int64, error) {
+	return b.Submit(trading.Order{Symbol: symbol, Side: trading.SideBuy, Type: trading.TypeMarket, Quantity: quantity})
+}
+
+// Sell places a market order to sell quantity of a symbol.
+func (b *Broker) Sell(symbol string, quantity float64) (int64, error) {
+	return b.Submit(trading.Order{Symbol: symbol, Side: trading.SideSell, Type: trading.TypeMarket, Quantity: quantity})
+}
+
+// Cancel cancels an open order. It reports false if the order is not open.
+func (b *Broker) Cancel(id int64) bool {
+	for _, o := range b.open {
+		if o.ID == id && o.Status == trading.StatusOpen {
+			o.Status = trading.StatusCancelled
+			b.cancelled++
+			return true
+		}
+	}
+	return false
+}
+
+// OpenOrders returns copies of the open orders, oldest first.
+func (b *Broker) OpenOrders() []trading.Order {
+	var orders []trading.Order
+	for _, o := range b.open {
+		if o.Status == trading.StatusOpen {
+			orders = append(orders, o.Order)
+		}
+	}
+	return orders
+}
+
+// position returns the holding of a symbol, creating it if needed.
+func (b *Broker) position(symbol string) *trading.Position {
+	pos, ok := b.positions[symbol]
+	if !ok {
+		pos = &trading.Position{Symbol: symbol}
+		b.positions[symbol] = pos
+		i := sort.SearchStrings(b.symbols, symbol)
+		b.symbols = append(b.symbols, "")
+		copy(b.symbols[i+1:], b.symbols[i:])
+		b.symbols[i] = symbol
+	}
+	return pos
+}
+
+// match executes the open orders on a symbol placed before the current
+// event against its prices, in the order they traded. A stop order whose
+// stop is reached is triggered and keeps matching at the later prices.
+func (b *Broker) match(s Strategy, symbol string, prices []float64) {
+	orders := b.open
+	for _, o := range orders {
+		if o.Symbol != symbol || o.Status != trading.StatusOpen || o.event >= b.event {
+			continue
+		}
+		for _, p := range prices {
+			triggered, fill := b.model.Match(&o.Order, p)
+			if triggered {
+				o.StopTriggered = true
+			}
+			if fill != nil {
+				b.fill(s, &o.Order, *fill)
+				break
+			}
+		}
+	}
+
+	open := b.open[:0]
+	for _, o := range b.open {
+		if o.Status == trading.StatusOpen {
+			open = append(open, o)
+		}
+	}
+	b.open = open
+}
+
+// fill books the fill of an order, rejecting it as the Trading Service does
+// if the account does not cover it.
+func (b *Broker) fill(s Strategy, o *trading.Order, fill trading.Fill) {
+	pos := b.position(o.Symbol)
+	realized := pos.RealizedPnL
+	if err := trading.Apply(&b.account, pos, o.Side, o.Quantity, fill); err != nil {
+		o.Status, o.RejectReason = trading.StatusRejected, err.Error()
+		b.rejected++
+		return
+	}
+
+	now := b.now
+	o.Status, o.FillPrice, o.Fee, o.FilledAt = trading.StatusFilled, fill.Price, fill.Fee, &now
+	t := Trade{
+		Time:        now,
+		OrderID:     o.ID,
+		Symbol:      o.Symbol,
+		Side:        o.Side,
+		Type:        o.Type,
+		Quantity:    o.Quantity,
+		Price:       fill.Price,
+		Fee:         fill.Fee,
+		RealizedPnL: pos.RealizedPnL - realized,
+	}
+	b.trades = append(b.trades, t)
+	s.OnFill(b, t)
+}
+
+// validate checks an order with the rules of the Trading Service.
+func validate(o *trading.Order) error {
+	if o.Symbol == "" {
+		return errors.New("symbol is required")
+	}
+	return trading.ValidateOrder(o.Side, o.Type, o.Quantity, o.LimitPrice, o.StopPrice)
+}
@@ -0,0 +1,115 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Params are the numeric parameters of a strategy, by name.
+type Params map[string]float64
+
+// Get returns a parameter, or def if it is not set.
+func (p Params) Get(name string, def float64) float64 {
+	if v, ok := p[name]; ok {
+		return v
+	}
+	return def
+}
+
+// String renders the parameters as name=value pairs sorted by name.
+func (p Params) String() string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=%g", name, p[name])
+	}
+	return strings.Join(pairs, " ")
+}
+
+// Grid expands lists of values into every combination of parameters. The
+// combinations are ordered by name, with the last name varying fastest.
+func Grid(values map[string][]float64) []Params {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	grid := []Params{{}}
+	for _, name := range names {
+		var next []Params
+		for _, p := range grid {
+			for _, v := range values[name] {
+				q := make(Params, len(p)+1)
+				for k, pv := range p {
+					q[k] = pv
+				}
+				q[name] = v
+				next = append(next, q)
+			}
+		}
+		grid = next
+	}
+	return grid
+}
+
+// Factory creates a strategy from its parameters.
+type Factory func(Params) (Strategy, error)
+
+// SweepResult is the outcome of one run of a sweep. Err is set if the
+// strategy could not be created or the run was cancelled.
+type SweepResult struct {
+	Params Params
+	Result *Result
+	Err    error
+}
+
+// Sweep runs a strategy over the same events once per parameter set, on up
+// to workers goroutines (GOMAXPROCS if workers is not positive). The events
+// are shared and only read. Results are in the order of grid and, as every
+// run is deterministic, do not depend on scheduling.
+func Sweep(ctx context.Context, cfg Config, events []Event, newStrategy Factory, grid []Params, workers int) []SweepResult {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	results := make([]SweepResult, len(grid))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(grid)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = sweepOne(ctx, cfg, events, newStrategy, grid[i])
+			}
+		}()
+	}
+	for i := range grid {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+func sweepOne(ctx context.Context, cfg Config, events []Event, newStrategy Factory, params Params) SweepResult {
+	s, err := newStrategy(params)
+	if err != nil {
+		return SweepResult{Params: params, Err: err}
+	}
+	r, err := Run(ctx, cfg, s, events)
+	if err != nil {
+		return SweepResult{Params: params, Err: err}
+	}
+	r.Params = params
+	return SweepResult{Params: params, Result: r}
+}
@@ -0,0 +1,115 @@
+// Package bus connects services to the message bus that carries ticks and
+// events between them: Kafka in a deployment, or an in-process Memory bus
+// when all services run in one binary or in tests.
+//
+// Topics are split into partitions, and messages with the same key always
+// go to the same partition, so they are delivered in order. Subscribers in
+// the same consumer group split the partitions of their topics among
+// themselves and commit how far they have got, so that the group resumes
+// there after a restart or a rebalance.
+package bus
+
+import (
+	"context"
+	"time"
+)
+
+// Message is a message on the bus. Partition, Offset and Timestamp are set
+// when it is published.
+type Message struct {
+	Topic   string
+	Key     string // Messages with the same key are kept in order
+	Value   []byte
+	Headers map[string]string
+
+	Partition int32
+	Offset    int64
+	Timestamp time.Time // When the message was published
+}
+
+// Publisher publishes messages.
+type Publisher interface {
+	// Publish stores messages on the bus, setting their partitions and
+	// offsets. It returns once they are stored.
+	Publish(ctx context.Context, msgs ...*Message) error
+	Close() error
+}
+
+// Start is where a subscriber starts reading partitions for which its group
+// has no committed offset.
+type Start int
+
+const (
+	// Oldest starts at the oldest message kept.
+	Oldest Start = iota
+	// Newest starts with the next message published.
+	Newest
+)
+
+// Subscription describes what a Subscriber consumes.
+type Subscription struct {
+	Topics []string
+
+	// Group is the consumer group. Subscribers in a group split the
+	// partitions of its topics and commit their offsets. Without a group,
+	// a subscriber reads every partition from Start and commits nothing.
+	Group string
+	Start Start
+
+	// OnAssign is called, if set, with the partitions of each topic claimed
+	// after every rebalance, before their messages are delivered. OnRevoke
+	// is called when they are given up again, once their handlers returned.
+	OnAssign func(claims map[string][]int32)
+	OnRevoke func(claims map[string][]int32)
+}
+
+// Subscriber delivers the messages of topics to a handler.
+type Subscriber interface {
+	// Subscribe consumes until ctx is done, calling the handler once per
+	// claimed partition after every rebalance. It returns nil once ctx is
+	// done, or an error if the bus fails.
+	Subscribe(ctx context.Context, sub Subscription, h Handler) error
+}
+
+// Claim is a partition held by a subscriber until the next rebalance.
+type Claim interface {
+	Topic() string
+	Partition() int32
+	InitialOffset() int64 // Offset of the first message delivered
+
+	// HighWaterMark returns the offset the next message published to the
+	// partition will get.
+	HighWaterMark() int64
+
+	// Messages delivers the messages of the partition in order. It is
+	// closed when the claim is revoked.
+	Messages() <-chan *Message
+
+	// Commit records that msg and every message before it in the partition
+	// have been processed. Commits are ignored without a group.
+	Commit(msg *Message)
+
+	// Context is done when the claim is revoked or the subscription ends.
+	Context() context.Context
+}
+
+// Handler processes the messages of a claim.
+type Handler interface {
+	// Consume processes messages until the claim's Messages channel is
+	// closed or its context is done. Handlers for the claims of a
+	// subscriber run concurrently.
+	Consume(claim Claim) error
+}
+
+// HandlerFunc adapts a function to a Handler.
+type HandlerFunc func(claim Claim) error
+
+func (f HandlerFunc) Consume(claim Claim) error {
+	return f(claim)
+}
+
+// Bus creates publishers and subscribes to topics.
+type Bus interface {
+	Subscriber
+	NewPublisher() (Publisher, error)
+}
@@ -0,0 +1,212 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeadLetterSuffix is appended to a topic to name its dead-letter topic.
+const DeadLetterSuffix = ".dlq"
+
+// Headers describing why a message was dead-lettered. They are added to the
+// message's own headers. A redriven message keeps those saying where it came
+// from and which group it is for.
+const (
+	HeaderError     = "dlq-error"     // The last error
+	HeaderTopic     = "dlq-topic"     // Where the message was consumed from
+	HeaderPartition = "dlq-partition" // Its partition there
+	HeaderOffset    = "dlq-offset"    // Its offset there
+	HeaderGroup     = "dlq-group"     // The consumer group that gave up
+	HeaderAttempts  = "dlq-attempts"  // How often it was tried
+	HeaderFailedAt  = "dlq-failed-at" // RFC 3339
+)
+
+// DeadLetterTopic returns the dead-letter topic of topic.
+func DeadLetterTopic(topic string) string {
+	return topic + DeadLetterSuffix
+}
+
+// RetryPolicy bounds how often a failing message is retried before it is
+// dead-lettered. The wait between attempts doubles from Backoff up to
+// MaxBackoff.
+type RetryPolicy struct {
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetry tries a message 5 times over about 1.5 seconds.
+var DefaultRetry = RetryPolicy{Attempts: 5, Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+func (p RetryPolicy) wait(attempt int) time.Duration {
+	d := p.Backoff << (attempt - 1)
+	if d <= 0 || d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// permanentError is an error that retrying cannot fix.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks err as not worth retrying, such as a malformed message:
+// DeadLetter.Process dead-letters the message at once.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// DeadLetter applies the failure policy of a consumer group: messages that
+// fail are retried with backoff, and published to the dead-letter topic of
+// the topic they came from once they fail permanently or run out of
+// attempts, so that they no longer hold up their partition.
+type DeadLetter struct {
+	pub   Publisher
+	group string
+	retry RetryPolicy
+}
+
+// NewDeadLetter creates the failure policy of a consumer group, which
+// dead-letters messages through pub.
+func NewDeadLetter(pub Publisher, group string, retry RetryPolicy) *DeadLetter {
+	return &DeadLetter{pub: pub, group: group, retry: retry}
+}
+
+// Owns reports whether the group should process msg. Every message is, but
+// for those redriven to another group, which already processed the original
+// or gave up on it itself: the group only commits them.
+func (d *DeadLetter) Owns(msg *Message) bool {
+	group, redriven := msg.Headers[HeaderGroup]
+	return !redriven || group == d.group
+}
+
+// Process calls fn for msg until it succeeds, fails permanently or has been
+// tried as often as the policy allows, and then dead-letters msg. fn must be
+// safe to repeat. Process returns nil once msg is handled either way and may
+// be committed, or ctx's error if it is done first, when msg must not be
+// committed.
+func (d *DeadLetter) Process(ctx context.Context, msg *Message, fn func(ctx context.Context) error) error {
+	attempts := max(d.retry.Attempts, 1)
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.As(err, new(permanentError)) || attempt >= attempts {
+			return d.send(ctx, msg, err, attempt)
+		}
+
+		slog.Warn("Retrying message", "topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset,
+			"attempt", attempt, "error", err)
+		if !sleep(ctx, d.retry.wait(attempt)) {
+			return ctx.Err()
+		}
+	}
+}
+
+// Reject dead-letters msg at once because of a permanent failure, such as
+// a value that cannot be decoded. Like Process, it returns nil once msg may
+// be committed.
+func (d *DeadLetter) Reject(ctx context.Context, msg *Message, cause error) error {
+	return d.send(ctx, msg, cause, 1)
+}
+
+// send publishes msg to its dead-letter topic. Publishing is retried until
+// it succeeds or ctx is done: the message is never committed without being
+// kept somewhere.
+func (d *DeadLetter) send(ctx context.Context, msg *Message, cause error, attempts int) error {
+	dead := &Message{
+		Topic:   DeadLetterTopic(msg.Topic),
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: maps.Clone(msg.Headers),
+	}
+	if dead.Headers == nil {
+		dead.Headers = make(map[string]string)
+	}
+	partition, offset := Origin(msg)
+	dead.Headers[HeaderError] = cause.Error()
+	dead.Headers[HeaderTopic] = msg.Topic
+	dead.Headers[HeaderPartition] = strconv.Itoa(int(partition))
+	dead.Headers[HeaderOffset] = strconv.FormatInt(offset, 10)
+	dead.Headers[HeaderGroup] = d.group
+	dead.Headers[HeaderAttempts] = strconv.Itoa(attempts)
+	dead.Headers[HeaderFailedAt] = time.Now().UTC().Format(time.RFC3339)
+
+	for attempt := 1; ; attempt++ {
+		err := d.pub.Publish(ctx, dead)
+		if err == nil {
+			slog.Error("Dead-lettered message", "topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset,
+				"attempts", attempts, "error", cause)
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		slog.Error("Failed to dead-letter message", "topic", msg.Topic, "partition", msg.Partition,
+			"offset", msg.Offset, "error", err)
+		if !sleep(ctx, d.retry.wait(attempt)) {
+			return ctx.Err()
+		}
+	}
+}
+
+// Redrive returns a dead-lettered message as it was first published, to be
+// published again to the topic it was consumed from. It keeps the topic,
+// partition, offset and group headers: only the group that dead-lettered the
+// message processes it again (see DeadLetter.Owns), and Origin tells the
+// original position, so that the message is deduplicated like a redelivery.
+func Redrive(dead *Message) *Message {
+	topic := dead.Headers[HeaderTopic]
+	if topic == "" {
+		topic = strings.TrimSuffix(dead.Topic, DeadLetterSuffix)
+	}
+	msg := &Message{Topic: topic, Key: dead.Key, Value: dead.Value, Headers: maps.Clone(dead.Headers)}
+	delete(msg.Headers, HeaderError)
+	delete(msg.Headers, HeaderAttempts)
+	delete(msg.Headers, HeaderFailedAt)
+	return msg
+}
+
+// Origin returns the partition and offset at which msg was first consumed:
+// its own, or for a redriven message those it was dead-lettered from.
+func Origin(msg *Message) (int32, int64) {
+	partition, err1 := strconv.ParseInt(msg.Headers[HeaderPartition], 10, 32)
+	offset, err2 := strconv.ParseInt(msg.Headers[HeaderOffset], 10, 64)
+	if err1 != nil || err2 != nil {
+		return msg.Partition, msg.Offset
+	}
+	return int32(partition), offset
+}
+
+// sleep waits for d, reporting false if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,114 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// deadLetters returns the messages on the dead-letter topic of topic.
+func deadLetters(m *Memory, topic string) []*Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var msgs []*Message
+	for _, p := range m.topic(DeadLetterTopic(topic)).parts {
+		msgs = append(msgs, p.log...)
+	}
+	return msgs
+}
+
+func TestDeadLetterProcess(t *testing.T) {
+	errDown := errors.New("store down")
+	retry := RetryPolicy{Attempts: 3, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	tests := []struct {
+		name      string
+		failures  int   // Calls that fail before one succeeds
+		err       error // What they fail with
+		wantCalls int
+		wantDead  string // Attempts header of the dead letter, if any
+	}{
+		{name: "succeeds", failures: 0, err: errDown, wantCalls: 1},
+		{name: "transient", failures: 2, err: errDown, wantCalls: 3},
+		{name: "exhausted", failures: 3, err: errDown, wantCalls: 3, wantDead: "3"},
+		{name: "permanent", failures: 1, err: Permanent(errDown), wantCalls: 1, wantDead: "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMemory(1, 100)
+			pub, _ := m.NewPublisher()
+			d := NewDeadLetter(pub, "alerts", retry)
+			msg := &Message{Topic: "ticks", Key: "AAPL", Value: []byte("tick"), Headers: map[string]string{"trace": "abc"},
+				Partition: 0, Offset: 41}
+
+			calls := 0
+			err := d.Process(context.Background(), msg, func(context.Context) error {
+				if calls++; calls <= tt.failures {
+					return tt.err
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Process() = %v, want nil", err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("called %d times, want %d", calls, tt.wantCalls)
+			}
+
+			dead := deadLetters(m, "ticks")
+			if tt.wantDead == "" {
+				if len(dead) != 0 {
+					t.Errorf("dead-lettered %d messages, want none", len(dead))
+				}
+				return
+			}
+			if len(dead) != 1 {
+				t.Fatalf("dead-lettered %d messages, want 1", len(dead))
+			}
+			h := dead[0].Headers
+			if h[HeaderAttempts] != tt.wantDead || h[HeaderError] != "store down" || h[HeaderTopic] != "ticks" ||
+				h[HeaderOffset] != "41" || h[HeaderGroup] != "alerts" || h["trace"] != "abc" {
+				t.Errorf("dead letter headers = %v", h)
+			}
+
+			// Redriving restores the message as it was published, for the
+			// group that gave up on it only
+			redriven := Redrive(dead[0])
+			if redriven.Topic != "ticks" || redriven.Key != "AAPL" || string(redriven.Value) != "tick" ||
+				redriven.Headers["trace"] != "abc" || redriven.Headers[HeaderError] != "" || redriven.Headers[HeaderAttempts] != "" {
+				t.Errorf("Redrive() = %+v", redriven)
+			}
+			if !d.Owns(redriven) || NewDeadLetter(pub, "processor", retry).Owns(redriven) {
+				t.Errorf("redriven message owned by the wrong groups")
+			}
+
+			// Consumed again elsewhere, it keeps the position it was first
+			// consumed at
+			redriven.Partition, redriven.Offset = 2, 7
+			if partition, offset := Origin(redriven); partition != 0 || offset != 41 {
+				t.Errorf("Origin() = %d@%d, want 0@41", partition, offset)
+			}
+		})
+	}
+}
+
+func TestDeadLetterCancel(t *testing.T) {
+	m := NewMemory(1, 100)
+	pub, _ := m.NewPublisher()
+	d := NewDeadLetter(pub, "alerts", RetryPolicy{Attempts: 3, Backoff: time.Hour, MaxBackoff: time.Hour})
+
+	// A message whose claim is revoked while it fails is neither retried
+	// nor dead-lettered: the partition's next owner gets it
+	ctx, cancel := context.WithCancel(context.Background())
+	err := d.Process(ctx, &Message{Topic: "ticks"}, func(context.Context) error {
+		cancel()
+		return errors.New("store down")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Process() = %v, want context.Canceled", err)
+	}
+	if dead := deadLetters(m, "ticks"); len(dead) != 0 {
+		t.Errorf("dead-lettered %d messages, want none", len(dead))
+	}
+}
@@ -0,0 +1,316 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Kafka is a Bus backed by a Kafka cluster.
+type Kafka struct {
+	brokers []string
+}
+
+// NewKafka creates a Bus connecting to the given Kafka brokers.
+func NewKafka(brokers []string) *Kafka {
+	return &Kafka{brokers: brokers}
+}
+
+// NewPublisher connects a producer that waits for every in-sync replica to
+// store a message.
+func (k *Kafka) NewPublisher() (Publisher, error) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Retry.Max = 5
+
+	producer, err := sarama.NewSyncProducer(k.brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Kafka: %w", err)
+	}
+	return &kafkaPublisher{producer: producer}, nil
+}
+
+// Subscribe joins a consumer group, or reads every partition directly if
+// the subscription has no group.
+func (k *Kafka) Subscribe(ctx context.Context, sub Subscription, h Handler) error {
+	config := sarama.NewConfig()
+	config.Consumer.Return.Errors = true
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	if sub.Start == Newest {
+		config.Consumer.Offsets.Initial = sarama.OffsetNewest
+	}
+	if sub.Group == "" {
+		return k.subscribeAll(ctx, config, sub, h)
+	}
+
+	client, err := sarama.NewClient(k.brokers, config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kafka: %w", err)
+	}
+	defer client.Close()
+	group, err := sarama.NewConsumerGroupFromClient(sub.Group, client)
+	if err != nil {
+		return fmt.Errorf("failed to join consumer group %s: %w", sub.Group, err)
+	}
+	defer group.Close()
+
+	go func() {
+		for err := range group.Errors() {
+			slog.Error("Error from consumer group", "group", sub.Group, "error", err)
+		}
+	}()
+
+	slog.Info("Connected to Kafka Consumer Group", "group", sub.Group, "topics", sub.Topics)
+	handler := &groupHandler{client: client, sub: sub, h: h}
+	for {
+		// Consume runs the handler for the claimed partitions until the
+		// next rebalance
+		if err := group.Consume(ctx, sub.Topics, handler); err != nil {
+			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// subscribeAll reads every partition of the subscribed topics.
+func (k *Kafka) subscribeAll(ctx context.Context, config *sarama.Config, sub Subscription, h Handler) error {
+	client, err := sarama.NewClient(k.brokers, config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kafka: %w", err)
+	}
+	defer client.Close()
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Kafka: %w", err)
+	}
+	defer consumer.Close()
+
+	claims := make(map[string][]int32)
+	var kcs []*kafkaClaim
+	for _, topic := range sub.Topics {
+		partitions, err := consumer.Partitions(topic)
+		if err != nil {
+			return fmt.Errorf("failed to list partitions of %s: %w", topic, err)
+		}
+		for _, p := range partitions {
+			initial, err := client.GetOffset(topic, p, config.Consumer.Offsets.Initial)
+			if err != nil {
+				return fmt.Errorf("failed to get offset of partition %d of %s: %w", p, topic, err)
+			}
+			pc, err := consumer.ConsumePartition(topic, p, initial)
+			if err != nil {
+				return fmt.Errorf("failed to consume partition %d of %s: %w", p, topic, err)
+			}
+			defer pc.Close()
+			kcs = append(kcs, &kafkaClaim{
+				ctx:       ctx,
+				topic:     topic,
+				partition: p,
+				initial:   initial,
+				hwm:       highWaterMark(client, topic, p, pc.HighWaterMarkOffset),
+				messages:  convert(ctx, pc.Messages()),
+			})
+		}
+		claims[topic] = partitions
+	}
+
+	slog.Info("Reading Kafka partitions", "topics", sub.Topics, "partitions", len(kcs))
+	if sub.OnAssign != nil {
+		sub.OnAssign(claims)
+	}
+	var wg sync.WaitGroup
+	for _, c := range kcs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := h.Consume(c); err != nil {
+				slog.Error("Error consuming partition", "topic", c.topic, "partition", c.partition, "error", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if sub.OnRevoke != nil {
+		sub.OnRevoke(claims)
+	}
+	return nil
+}
+
+// groupHandler runs a Handler for the claims of a consumer group session.
+type groupHandler struct {
+	client sarama.Client
+	sub    Subscription
+	h      Handler
+}
+
+func (g *groupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	if g.sub.OnAssign != nil {
+		g.sub.OnAssign(session.Claims())
+	}
+	return nil
+}
+
+func (g *groupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	if g.sub.OnRevoke != nil {
+		g.sub.OnRevoke(session.Claims())
+	}
+	return nil
+}
+
+func (g *groupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	c := &kafkaClaim{
+		ctx:       session.Context(),
+		topic:     claim.Topic(),
+		partition: claim.Partition(),
+		initial:   claim.InitialOffset(),
+		hwm:       highWaterMark(g.client, claim.Topic(), claim.Partition(), claim.HighWaterMarkOffset),
+		messages:  convert(session.Context(), claim.Messages()),
+		commit:    func(msg *Message) { session.MarkOffset(msg.Topic, msg.Partition, msg.Offset+1, "") },
+	}
+	return g.h.Consume(c)
+}
+
+// highWaterMark returns a function reporting the high water mark of a
+// partition. Sarama learns it from fetch responses, so until the first one
+// the newest offset when the partition was claimed is reported instead.
+func highWaterMark(client sarama.Client, topic string, partition int32, fetched func() int64) func() int64 {
+	newest, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		slog.Warn("Failed to get high water mark", "topic", topic, "partition", partition, "error", err)
+	}
+	return func() int64 {
+		return max(newest, fetched())
+	}
+}
+
+// convert relays Sarama messages as bus messages until in is closed or ctx
+// is done.
+func convert(ctx context.Context, in <-chan *sarama.ConsumerMessage) <-chan *Message {
+	// Buffered like Sarama's channels, so that handlers can take every
+	// message already fetched at once
+	out := make(chan *Message, 256)
+	go func() {
+		defer close(out)
+		for msg := range in {
+			select {
+			case out <- fromSarama(msg):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func fromSarama(msg *sarama.ConsumerMessage) *Message {
+	var headers map[string]string
+	if len(msg.Headers) > 0 {
+		headers = make(map[string]string, len(msg.Headers))
+		for _, h := range msg.Headers {
+			headers[string(h.Key)] = string(h.Value)
+		}
+	}
+	return &Message{
+		Topic:     msg.Topic,
+		Key:       string(msg.Key),
+		Value:     msg.Value,
+		Headers:   headers,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Timestamp: msg.Timestamp,
+	}
+}
+
+// kafkaClaim is a partition claimed from Kafka.
+type kafkaClaim struct {
+	ctx       context.Context
+	topic     string
+	partition int32
+	initial   int64
+	hwm       func() int64
+	messages  <-chan *Message
+	commit    func(*Message)
+}
+
+func (c *kafkaClaim) Topic() string {
+	return c.topic
+}
+
+func (c *kafkaClaim) Partition() int32 {
+	return c.partition
+}
+
+func (c *kafkaClaim) InitialOffset() int64 {
+	return c.initial
+}
+
+func (c *kafkaClaim) HighWaterMark() int64 {
+	return c.hwm()
+}
+
+func (c *kafkaClaim) Messages() <-chan *Message {
+	return c.messages
+}
+
+func (c *kafkaClaim) Context() context.Context {
+	return c.ctx
+}
+
+func (c *kafkaClaim) Commit(msg *Message) {
+	if c.commit != nil {
+		c.commit(msg)
+	}
+}
+
+// kafkaPublisher publishes through a synchronous producer.
+type kafkaPublisher struct {
+	producer sarama.SyncProducer
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, msgs ...*Message) error {
+	pms := make([]*sarama.ProducerMessage, len(msgs))
+	for i, msg := range msgs {
+		timestamp := msg.Timestamp
+		if timestamp.IsZero() {
+			timestamp = time.Now()
+		}
+		pm := &sarama.ProducerMessage{
+			Topic:     msg.Topic,
+			Value:     sarama.ByteEncoder(msg.Value),
+			Timestamp: timestamp,
+		}
+		if msg.Key != "" {
+			pm.Key = sarama.StringEncoder(msg.Key)
+		}
+		for k, v := range msg.Headers {
+			pm.Headers = append(pm.Headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+		}
+		pms[i] = pm
+	}
+
+	if len(pms) == 1 {
+		if _, _, err := p.producer.SendMessage(pms[0]); err != nil {
+			return err
+		}
+	} else if err := p.producer.SendMessages(pms); err != nil {
+		return err
+	}
+	for i, pm := range pms {
+		msgs[i].Partition, msgs[i].Offset, msgs[i].Timestamp = pm.Partition, pm.Offset, pm.Timestamp
+	}
+	return nil
+}
+
+func (p *kafkaPublisher) Close() error {
+	return p.producer.Close()
+}
@@ -0,0 +1,341 @@
+package bus
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+)
+
+// Memory is a Bus that keeps topics in process, for running every service
+// in one binary and for tests.
+//
+// Topics are created on first use with a fixed number of partitions.
+// Messages are assigned to partitions by a hash of their key, as by Sarama's
+// default partitioner, so the ticks of a symbol stay in order. Consumer
+// groups split the partitions of their topics among their members and
+// rebalance when members join or leave; committed offsets survive for as
+// long as the process. Each partition keeps its latest messages up to the
+// retention, and consumers that fall further behind skip to the oldest
+// message kept.
+type Memory struct {
+	partitions int32
+	retention  int
+
+	mu     sync.Mutex
+	cond   *sync.Cond // Signalled when a consumer group session ends
+	topics map[string]*memTopic
+	groups map[string]*memGroup
+}
+
+type memTopic struct {
+	parts []*memPartition
+	next  int32 // Partition of the next message without a key
+}
+
+type memPartition struct {
+	start int64 // Offset of log[0]
+	log   []*Message
+	wake  chan struct{} // Closed when a message is appended
+}
+
+func (p *memPartition) end() int64 {
+	return p.start + int64(len(p.log))
+}
+
+// NewMemory creates an in-memory bus whose topics have the given number of
+// partitions, each keeping at least its latest retention messages.
+func NewMemory(partitions, retention int) *Memory {
+	m := &Memory{
+		partitions: int32(max(partitions, 1)),
+		retention:  max(retention, 1),
+		topics:     make(map[string]*memTopic),
+		groups:     make(map[string]*memGroup),
+	}
+	m.cond = sync.NewCond(&m.mu)
+	return m
+}
+
+// NewPublisher returns a publisher to the bus. Publishing never fails.
+func (m *Memory) NewPublisher() (Publisher, error) {
+	return memPublisher{m: m}, nil
+}
+
+// Subscribe joins a consumer group, or reads every partition if the
+// subscription has no group.
+func (m *Memory) Subscribe(ctx context.Context, sub Subscription, h Handler) error {
+	if sub.Group == "" {
+		m.subscribeAll(ctx, sub, h)
+		return nil
+	}
+
+	member := m.join(sub)
+	defer m.leave(member)
+	for ctx.Err() == nil {
+		m.session(ctx, member, h)
+	}
+	return nil
+}
+
+// subscribeAll reads every partition of the subscribed topics until ctx is
+// done.
+func (m *Memory) subscribeAll(ctx context.Context, sub Subscription, h Handler) {
+	ctx, cancel := context.WithCancel(ctx)
+	m.mu.Lock()
+	claims := make(map[string][]int32)
+	var mcs []*memClaim
+	for _, topic := range sub.Topics {
+		for p := range m.topic(topic).parts {
+			partition := int32(p)
+			mcs = append(mcs, m.claim(ctx, topic, partition, m.resolve(topic, partition, sub.Start, -1), nil))
+			claims[topic] = append(claims[topic], partition)
+		}
+	}
+	m.mu.Unlock()
+
+	if sub.OnAssign != nil {
+		sub.OnAssign(claims)
+	}
+	m.run(ctx, cancel, h, mcs, nil)
+	if sub.OnRevoke != nil {
+		sub.OnRevoke(claims)
+	}
+}
+
+// run feeds claims to their handlers until ctx is done, revoked is closed or
+// every handler has returned, then cancels ctx, the context of the claims,
+// and waits for the handlers to return. Without claims it waits for ctx or
+// revoked.
+func (m *Memory) run(ctx context.Context, cancel context.CancelFunc, h Handler, claims []*memClaim, revoked <-chan struct{}) {
+	var feeders, handlers sync.WaitGroup
+	for _, c := range claims {
+		feeders.Add(1)
+		go func() {
+			defer feeders.Done()
+			m.feed(c, ctx.Done())
+		}()
+		handlers.Add(1)
+		go func() {
+			defer handlers.Done()
+			if err := h.Consume(c); err != nil {
+				slog.Error("Error consuming partition", "topic", c.topic, "partition", c.partition, "error", err)
+			}
+		}()
+	}
+	finished := make(chan struct{})
+	if len(claims) > 0 {
+		go func() {
+			handlers.Wait()
+			close(finished)
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-revoked:
+	case <-finished:
+	}
+	cancel()
+	feeders.Wait()
+	handlers.Wait()
+}
+
+// claim creates a claim on a partition starting at offset, which commits
+// through commit if that is set.
+func (m *Memory) claim(ctx context.Context, topic string, partition int32, offset int64, commit func(*Message)) *memClaim {
+	return &memClaim{
+		ctx:       ctx,
+		m:         m,
+		topic:     topic,
+		partition: partition,
+		initial:   offset,
+		messages:  make(chan *Message, 256),
+		commit:    commit,
+	}
+}
+
+// topic returns a topic, creating it if needed. m.mu must be held.
+func (m *Memory) topic(name string) *memTopic {
+	t, ok := m.topics[name]
+	if !ok {
+		t = &memTopic{parts: make([]*memPartition, m.partitions)}
+		for i := range t.parts {
+			t.parts[i] = &memPartition{wake: make(chan struct{})}
+		}
+		m.topics[name] = t
+	}
+	return t
+}
+
+// publish appends messages to their topics and sets their partitions,
+// offsets and timestamps.
+func (m *Memory) publish(msgs []*Message) {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, msg := range msgs {
+		t := m.topic(msg.Topic)
+		partition := t.next
+		if msg.Key != "" {
+			partition = hashPartition([]byte(msg.Key), int32(len(t.parts)))
+		} else {
+			t.next = (t.next + 1) % int32(len(t.parts))
+		}
+		p := t.parts[partition]
+
+		msg.Partition, msg.Offset = partition, p.end()
+		if msg.Timestamp.IsZero() {
+			msg.Timestamp = now
+		}
+		// The bus keeps its own copy, which the publisher cannot change
+		stored := *msg
+		stored.Headers = maps.Clone(msg.Headers)
+		p.log = append(p.log, &stored)
+
+		if len(p.log) >= 2*m.retention {
+			// Trim in bulk so appends stay cheap
+			drop := len(p.log) - m.retention
+			p.log = append([]*Message(nil), p.log[drop:]...)
+			p.start += int64(drop)
+		}
+		close(p.wake)
+		p.wake = make(chan struct{})
+	}
+}
+
+// hashPartition assigns a key to a partition like Sarama's hash partitioner,
+// so that keys stay on the same partitions when moving to Kafka.
+func hashPartition(key []byte, partitions int32) int32 {
+	h := fnv.New32a()
+	h.Write(key)
+	p := int32(h.Sum32()) % partitions
+	if p < 0 {
+		p = -p
+	}
+	return p
+}
+
+// resolve returns where to start reading a partition: at committed if it is
+// not negative, else as start says. Offsets no longer kept move to the
+// oldest message kept. m.mu must be held.
+func (m *Memory) resolve(topic string, partition int32, start Start, committed int64) int64 {
+	p := m.topic(topic).parts[partition]
+	switch {
+	case committed >= 0:
+		return min(max(committed, p.start), p.end())
+	case start == Newest:
+		return p.end()
+	}
+	return p.start
+}
+
+// highWaterMark returns the offset the next message of a partition gets.
+func (m *Memory) highWaterMark(topic string, partition int32) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.topic(topic).parts[partition].end()
+}
+
+// read returns the message of a partition at offset, or the channel closed
+// when one is appended if there is none yet. An offset that is no longer
+// kept moves to the oldest message kept.
+func (m *Memory) read(topic string, partition int32, offset int64) (*Message, <-chan struct{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := m.topic(topic).parts[partition]
+	if offset < p.start {
+		offset = p.start
+	}
+	if offset < p.end() {
+		return p.log[offset-p.start], nil
+	}
+	return nil, p.wake
+}
+
+// feed sends the messages of a claim's partition to its Messages channel
+// until done is closed, then closes the channel.
+func (m *Memory) feed(c *memClaim, done <-chan struct{}) {
+	defer close(c.messages)
+	offset := c.initial
+	for {
+		msg, wake := m.read(c.topic, c.partition, offset)
+		if msg == nil {
+			select {
+			case <-wake:
+				continue
+			case <-done:
+				return
+			}
+		}
+		// Every consumer gets its own copy
+		delivered := *msg
+		delivered.Headers = maps.Clone(msg.Headers)
+		select {
+		case c.messages <- &delivered:
+			offset = msg.Offset + 1
+		case <-done:
+			return
+		}
+	}
+}
+
+// memPublisher publishes to the in-memory bus.
+type memPublisher struct {
+	m *Memory
+}
+
+func (p memPublisher) Publish(ctx context.Context, msgs ...*Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	p.m.publish(msgs)
+	return nil
+}
+
+func (p memPublisher) Close() error {
+	return nil
+}
+
+// memClaim is a partition claimed from the in-memory bus.
+type memClaim struct {
+	ctx       context.Context
+	m         *Memory
+	topic     string
+	partition int32
+	initial   int64
+	messages  chan *Message
+	commit    func(*Message) // Nil without a group
+}
+
+func (c *memClaim) Topic() string {
+	return c.topic
+}
+
+func (c *memClaim) Partition() int32 {
+	return c.partition
+}
+
+func (c *memClaim) InitialOffset() int64 {
+	return c.initial
+}
+
+func (c *memClaim) HighWaterMark() int64 {
+	return c.m.highWaterMark(c.topic, c.partition)
+}
+
+func (c *memClaim) Messages() <-chan *Message {
+	return c.messages
+}
+
+func (c *memClaim) Commit(msg *Message) {
+	if c.commit != nil {
+		c.commit(msg)
+	}
+}
+
+func (c *memClaim) Context() context.Context {
+	return c.ctx
+}
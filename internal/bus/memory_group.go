@@ -0,0 +1,154 @@
+package bus
+
+import (
+	"context"
+	"slices"
+)
+
+// memGroup is a consumer group of the in-memory bus. Its fields are guarded
+// by the bus's mutex.
+type memGroup struct {
+	offsets    map[string]map[int32]int64 // Committed offsets
+	members    []*memMember               // In join order
+	generation int32
+	rebalance  chan struct{} // Closed when the members change
+	running    map[int32]int // Sessions running, by generation
+}
+
+// memMember is a subscriber in a consumer group.
+type memMember struct {
+	group *memGroup
+	sub   Subscription
+}
+
+// join adds a subscriber to its group and starts a new generation.
+func (m *Memory) join(sub Subscription) *memMember {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, ok := m.groups[sub.Group]
+	if !ok {
+		g = &memGroup{
+			offsets:   make(map[string]map[int32]int64),
+			rebalance: make(chan struct{}),
+			running:   make(map[int32]int),
+		}
+		m.groups[sub.Group] = g
+	}
+	member := &memMember{group: g, sub: sub}
+	g.members = append(g.members, member)
+	g.bump()
+	return member
+}
+
+// leave removes a subscriber from its group and starts a new generation.
+func (m *Memory) leave(member *memMember) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g := member.group
+	if i := slices.Index(g.members, member); i >= 0 {
+		g.members = slices.Delete(g.members, i, i+1)
+		g.bump()
+	}
+	m.cond.Broadcast()
+}
+
+// session runs a member's handler on the partitions it is assigned until
+// ctx is done, the next rebalance, or every handler returned.
+func (m *Memory) session(ctx context.Context, member *memMember, h Handler) {
+	g := member.group
+	m.mu.Lock()
+	// Wait for the sessions of earlier generations to release their
+	// partitions, so that no partition has two owners
+	for g.olderRunning(g.generation) {
+		m.cond.Wait()
+	}
+	gen := g.generation
+	revoked := g.rebalance
+	assigned := g.assign(m, member)
+
+	ctx, cancel := context.WithCancel(ctx)
+	var claims []*memClaim
+	for topic, partitions := range assigned {
+		for _, p := range partitions {
+			committed, ok := g.offsets[topic][p]
+			if !ok {
+				committed = -1
+			}
+			offset := m.resolve(topic, p, member.sub.Start, committed)
+			claims = append(claims, m.claim(ctx, topic, p, offset, m.committer(g)))
+		}
+	}
+	g.running[gen]++
+	m.mu.Unlock()
+
+	if member.sub.OnAssign != nil {
+		member.sub.OnAssign(assigned)
+	}
+	m.run(ctx, cancel, h, claims, revoked)
+	if member.sub.OnRevoke != nil {
+		member.sub.OnRevoke(assigned)
+	}
+
+	m.mu.Lock()
+	if g.running[gen]--; g.running[gen] == 0 {
+		delete(g.running, gen)
+	}
+	m.cond.Broadcast()
+	m.mu.Unlock()
+}
+
+// committer returns a function committing messages to a group. Committed
+// offsets only move forward.
+func (m *Memory) committer(g *memGroup) func(*Message) {
+	return func(msg *Message) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if g.offsets[msg.Topic] == nil {
+			g.offsets[msg.Topic] = make(map[int32]int64)
+		}
+		if offset, ok := g.offsets[msg.Topic][msg.Partition]; !ok || msg.Offset+1 > offset {
+			g.offsets[msg.Topic][msg.Partition] = msg.Offset + 1
+		}
+	}
+}
+
+func (g *memGroup) bump() {
+	g.generation++
+	close(g.rebalance)
+	g.rebalance = make(chan struct{})
+}
+
+// olderRunning reports whether sessions of a generation before gen are still
+// running.
+func (g *memGroup) olderRunning(gen int32) bool {
+	for other, n := range g.running {
+		if other < gen && n > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// assign returns the partitions of a member's topics that it owns: those
+// whose number, modulo the number of members subscribed to the topic, is the
+// member's position among them. m.mu must be held.
+func (g *memGroup) assign(m *Memory, member *memMember) map[string][]int32 {
+	claims := make(map[string][]int32)
+	for _, topic := range member.sub.Topics {
+		var subscribed []*memMember
+		for _, other := range g.members {
+			if slices.Contains(other.sub.Topics, topic) {
+				subscribed = append(subscribed, other)
+			}
+		}
+		pos := slices.Index(subscribed, member)
+		for p := range len(m.topic(topic).parts) {
+			if p%len(subscribed) == pos {
+				claims[topic] = append(claims[topic], int32(p))
+			}
+		}
+	}
+	return claims
+}
@@ -0,0 +1,215 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// collector is a handler that sends every message it is given to a channel
+// and commits it, and reports every assignment.
+type collector struct {
+	messages chan *Message
+	assigned chan map[string][]int32
+}
+
+func newCollector() *collector {
+	return &collector{messages: make(chan *Message, 1000), assigned: make(chan map[string][]int32, 100)}
+}
+
+func (c *collector) Consume(claim Claim) error {
+	for msg := range claim.Messages() {
+		c.messages <- msg
+		claim.Commit(msg)
+	}
+	return nil
+}
+
+func (c *collector) subscription(group string, start Start) Subscription {
+	return Subscription{
+		Topics:   []string{"ticks"},
+		Group:    group,
+		Start:    start,
+		OnAssign: func(claims map[string][]int32) { c.assigned <- claims },
+	}
+}
+
+func publish(t *testing.T, b Bus, key string, n int) {
+	t.Helper()
+	p, err := b.NewPublisher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range n {
+		if err := p.Publish(context.Background(), &Message{Topic: "ticks", Key: key, Value: []byte(fmt.Sprint(i))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// receive waits for n messages, returning the values of each key in order.
+func receive(t *testing.T, messages <-chan *Message, n int) map[string][]string {
+	t.Helper()
+	got := make(map[string][]string)
+	for i := range n {
+		select {
+		case msg := <-messages:
+			got[msg.Key] = append(got[msg.Key], string(msg.Value))
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after %d of %d messages", i, n)
+		}
+	}
+	return got
+}
+
+func TestMemoryPartitioner(t *testing.T) {
+	m := NewMemory(8, 100)
+	p, _ := m.NewPublisher()
+	partitioner := sarama.NewHashPartitioner("ticks")
+	for _, key := range []string{"AAPL", "MSFT", "BINANCE:BTCUSDT", "IC MARKETS:1"} {
+		want, err := partitioner.Partition(&sarama.ProducerMessage{Key: sarama.StringEncoder(key)}, 8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg := &Message{Topic: "ticks", Key: key}
+		if err := p.Publish(context.Background(), msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.Partition != want {
+			t.Errorf("partition of %q = %d, want %d as with Kafka", key, msg.Partition, want)
+		}
+	}
+}
+
+func TestMemoryConsumerGroup(t *testing.T) {
+	m := NewMemory(4, 1000)
+	keys := []string{"AAPL", "MSFT", "GOOG", "AMZN", "TSLA", "NVDA"}
+	for _, key := range keys {
+		publish(t, m, key, 10)
+	}
+
+	// Two members split the partitions and see every message once, in order
+	// per key
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	handler := newCollector()
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Subscribe(ctx, handler.subscription("processor", Oldest), handler)
+		}()
+	}
+
+	got := receive(t, handler.messages, len(keys)*10)
+	for _, key := range keys {
+		if len(got[key]) != 10 {
+			t.Errorf("got %d messages for %s, want 10", len(got[key]), key)
+			continue
+		}
+		for i, v := range got[key] {
+			if v != fmt.Sprint(i) {
+				t.Errorf("%s message %d = %s, out of order", key, i, v)
+				break
+			}
+		}
+	}
+	cancel()
+	wg.Wait()
+
+	// The group resumes from its committed offsets
+	publish(t, m, "AAPL", 3)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	handler = newCollector()
+	go m.Subscribe(ctx, handler.subscription("processor", Oldest), handler)
+
+	got = receive(t, handler.messages, 3)
+	if want := "[0 1 2]"; fmt.Sprint(got["AAPL"]) != want {
+		t.Errorf("after rejoining got %v, want AAPL %s", got, want)
+	}
+	select {
+	case msg := <-handler.messages:
+		t.Errorf("unexpected redelivery of %s %s", msg.Key, msg.Value)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestMemoryRebalance(t *testing.T) {
+	m := NewMemory(4, 1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := newCollector()
+	go m.Subscribe(ctx, first.subscription("alerts", Newest), first)
+	if claims := <-first.assigned; len(claims["ticks"]) != 4 {
+		t.Fatalf("sole member claims %v, want all 4 partitions", claims)
+	}
+
+	second := newCollector()
+	secondCtx, secondCancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.Subscribe(secondCtx, second.subscription("alerts", Newest), second)
+	}()
+	a, b := <-first.assigned, <-second.assigned
+	if len(a["ticks"]) != 2 || len(b["ticks"]) != 2 {
+		t.Fatalf("after a join claims are %v and %v, want 2 partitions each", a, b)
+	}
+
+	// When the second member leaves, the first takes its partitions back
+	secondCancel()
+	<-done
+	if claims := <-first.assigned; len(claims["ticks"]) != 4 {
+		t.Fatalf("after a leave claims are %v, want all 4 partitions", claims)
+	}
+}
+
+func TestMemoryRetention(t *testing.T) {
+	m := NewMemory(1, 5)
+	publish(t, m, "AAPL", 12)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hwm := make(chan int64, 1)
+	handler := newCollector()
+	go m.Subscribe(ctx, Subscription{Topics: []string{"ticks"}}, HandlerFunc(func(claim Claim) error {
+		hwm <- claim.HighWaterMark()
+		return handler.Consume(claim)
+	}))
+
+	got := receive(t, handler.messages, 7)
+	if want := "[5 6 7 8 9 10 11]"; fmt.Sprint(got["AAPL"]) != want {
+		t.Errorf("got %v, want the retained %s", got["AAPL"], want)
+	}
+	if n := <-hwm; n != 12 {
+		t.Errorf("high water mark = %d, want 12", n)
+	}
+}
+
+func TestMemoryNewest(t *testing.T) {
+	m := NewMemory(2, 100)
+	publish(t, m, "AAPL", 3)
+
+	// Without a group, every subscriber gets every new message
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handlers := []*collector{newCollector(), newCollector()}
+	for _, h := range handlers {
+		go m.Subscribe(ctx, h.subscription("", Newest), h)
+		<-h.assigned
+	}
+
+	publish(t, m, "MSFT", 2)
+	for _, h := range handlers {
+		got := receive(t, h.messages, 2)
+		if len(got["AAPL"]) != 0 || len(got["MSFT"]) != 2 {
+			t.Errorf("got %v, want only the 2 new MSFT messages", got)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package bus
+
+import (
+	"context"
+	"sync"
+)
+
+// poolQueue is the number of jobs waiting per worker of a Pool before
+// Submit blocks.
+const poolQueue = 64
+
+// Pool runs the jobs of one claim on a fixed number of workers. Jobs with
+// the same key, such as the ticks of a symbol, always run on the same worker
+// and so in order, while jobs with other keys need not wait for them.
+//
+// The pool tracks which messages of the claim are done, so that the claim
+// is only committed up to the first message that is not.
+type Pool[T any] struct {
+	ctx    context.Context
+	fn     func(ctx context.Context, job T) error
+	queues []chan poolJob[T]
+	wg     sync.WaitGroup
+	closed sync.Once
+
+	mu          sync.Mutex
+	inflight    []*poolEntry // Messages tracked and not yet committable, in order
+	entries     map[int64]*poolEntry
+	committable *Message
+	progress    chan struct{}
+}
+
+type poolJob[T any] struct {
+	job  T
+	msgs []*Message
+}
+
+type poolEntry struct {
+	msg  *Message
+	done bool
+}
+
+// NewPool starts workers calling fn for the jobs submitted. Once ctx is done
+// the jobs not started yet are dropped, leaving their messages for the
+// partition's next owner.
+func NewPool[T any](ctx context.Context, workers int, fn func(ctx context.Context, job T) error) *Pool[T] {
+	p := &Pool[T]{
+		ctx:      ctx,
+		fn:       fn,
+		queues:   make([]chan poolJob[T], max(workers, 1)),
+		entries:  make(map[int64]*poolEntry),
+		progress: make(chan struct{}, 1),
+	}
+	for i := range p.queues {
+		p.queues[i] = make(chan poolJob[T], poolQueue)
+		p.wg.Add(1)
+		go p.work(p.queues[i])
+	}
+	return p
+}
+
+// Track records that msg was read from the claim. Every message must be
+// tracked, in order, before it is submitted or done.
+func (p *Pool[T]) Track(msg *Message) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e := &poolEntry{msg: msg}
+	p.inflight = append(p.inflight, e)
+	p.entries[msg.Offset] = e
+}
+
+// Submit queues job on the worker of key. msgs are done once fn returns nil
+// for it; a job that fails leaves them undone, so that the claim is never
+// committed past them. Submit blocks while the worker's queue is full, and
+// reports false if the pool's context is done first.
+func (p *Pool[T]) Submit(key string, job T, msgs ...*Message) bool {
+	if p.ctx.Err() != nil {
+		return false
+	}
+	var worker int
+	if key != "" {
+		worker = int(hashPartition([]byte(key), int32(len(p.queues))))
+	} else if len(msgs) > 0 {
+		worker = int(msgs[0].Offset % int64(len(p.queues)))
+	}
+
+	select {
+	case p.queues[worker] <- poolJob[T]{job: job, msgs: msgs}:
+		return true
+	case <-p.ctx.Done():
+		return false
+	}
+}
+
+// Done records that msgs are done without running a job, such as messages
+// that were dead-lettered.
+func (p *Pool[T]) Done(msgs ...*Message) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, msg := range msgs {
+		if e, ok := p.entries[msg.Offset]; ok {
+			e.done = true
+		}
+	}
+
+	advanced := false
+	for len(p.inflight) > 0 && p.inflight[0].done {
+		p.committable = p.inflight[0].msg
+		delete(p.entries, p.committable.Offset)
+		p.inflight = p.inflight[1:]
+		advanced = true
+	}
+	if advanced {
+		select {
+		case p.progress <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Committable returns the latest message that is done along with every
+// message tracked before it, or nil if there is none yet.
+func (p *Pool[T]) Committable() *Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.committable
+}
+
+// Progress receives when the committable message has moved on.
+func (p *Pool[T]) Progress() <-chan struct{} {
+	return p.progress
+}
+
+// Close stops the workers once they have run the jobs submitted, or dropped
+// them if ctx is done, and waits for them. Nothing may be submitted after.
+func (p *Pool[T]) Close() {
+	p.closed.Do(func() {
+		for _, q := range p.queues {
+			close(q)
+		}
+	})
+	p.wg.Wait()
+}
+
+func (p *Pool[T]) work(queue <-chan poolJob[T]) {
+	defer p.wg.Done()
+	for j := range queue {
+		if p.ctx.Err() != nil {
+			continue
+		}
+		if err := p.fn(p.ctx, j.job); err == nil {
+			p.Done(j.msgs...)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	order := make(map[string][]int64)
+	release := make(chan struct{})
+	pool := NewPool(ctx, 4, func(ctx context.Context, msg *Message) error {
+		if msg.Key == "SLOW" {
+			<-release
+		}
+		if msg.Key == "FAIL" {
+			return errors.New("store down")
+		}
+		mu.Lock()
+		order[msg.Key] = append(order[msg.Key], msg.Offset)
+		mu.Unlock()
+		return nil
+	})
+
+	keys := []string{"AAPL", "MSFT", "AAPL", "SLOW", "MSFT", "AAPL", "GOOG", "MSFT"}
+	var msgs []*Message
+	for i, key := range keys {
+		msg := &Message{Key: key, Offset: int64(i)}
+		msgs = append(msgs, msg)
+		pool.Track(msg)
+		pool.Submit(key, msg, msg)
+	}
+
+	// Nothing after the slow message is committable until it is done, though
+	// the other keys are not held up by it
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(order["AAPL"])+len(order["MSFT"])+len(order["GOOG"]) == 7
+	})
+	if got := pool.Committable(); got != msgs[2] {
+		t.Errorf("committable = %v, want offset 2", got)
+	}
+
+	close(release)
+	<-pool.Progress()
+	waitFor(t, func() bool { return pool.Committable() == msgs[7] })
+
+	// Messages of a key are done in order
+	mu.Lock()
+	if got := fmt.Sprint(order); got != "map[AAPL:[0 2 5] GOOG:[6] MSFT:[1 4 7] SLOW:[3]]" {
+		t.Errorf("order = %s", got)
+	}
+	mu.Unlock()
+
+	// A job that fails holds the committable message back
+	failed := &Message{Key: "FAIL", Offset: 8}
+	after := &Message{Offset: 9}
+	pool.Track(failed)
+	pool.Track(after)
+	pool.Submit("FAIL", failed, failed)
+	pool.Done(after)
+	pool.Close()
+	if got := pool.Committable(); got != msgs[7] {
+		t.Errorf("committable after a failure = %v, want offset 7", got)
+	}
+}
+
+func TestPoolDrain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	pool := NewPool(ctx, 1, func(ctx context.Context, msg *Message) error {
+		if msg.Offset == 0 {
+			close(started)
+			<-ctx.Done()
+		}
+		return nil
+	})
+	var msgs []*Message
+	for i := range 3 {
+		msg := &Message{Key: "AAPL", Offset: int64(i)}
+		msgs = append(msgs, msg)
+		pool.Track(msg)
+		pool.Submit(msg.Key, msg, msg)
+	}
+
+	// When the claim is revoked, the running job finishes and the queued
+	// ones are dropped
+	<-started
+	cancel()
+	if pool.Submit("AAPL", msgs[0]) {
+		t.Error("Submit() after revoke = true, want false")
+	}
+	pool.Close()
+	if got := pool.Committable(); got != msgs[0] {
+		t.Errorf("committable = %v, want offset 0", got)
+	}
+}
+
+// waitFor waits up to a second for cond to hold.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,68 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Encode marshals m into a message for topic with the given partition key.
+func Encode(topic, key string, m proto.Message) (*Message, error) {
+	value, err := proto.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", m.ProtoReflect().Descriptor().FullName(), err)
+	}
+	return &Message{Topic: topic, Key: key, Value: value}, nil
+}
+
+// Decode unmarshals the value of msg as a protobuf message of type M:
+//
+//	tick, err := bus.Decode[stock.StockTick](msg)
+func Decode[M any, PM interface {
+	*M
+	proto.Message
+}](msg *Message) (PM, error) {
+	m := PM(new(M))
+	if err := proto.Unmarshal(msg.Value, m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s at %s/%d@%d: %w",
+			m.ProtoReflect().Descriptor().FullName(), msg.Topic, msg.Partition, msg.Offset, err)
+	}
+	return m, nil
+}
+
+// EachProto returns a Handler that decodes every message of a claim as an M
+// and calls fn with it, in order, committing the message once fn succeeds.
+// Messages that cannot be decoded are logged and skipped; messages for which
+// fn fails are logged and left uncommitted.
+func EachProto[M any, PM interface {
+	*M
+	proto.Message
+}](fn func(ctx context.Context, msg *Message, m PM) error) Handler {
+	return HandlerFunc(func(claim Claim) error {
+		ctx := claim.Context()
+		for {
+			select {
+			case msg, ok := <-claim.Messages():
+				if !ok {
+					return nil
+				}
+				m, err := Decode[M, PM](msg)
+				if err != nil {
+					slog.Error("Error unmarshaling message", "error", err)
+					continue
+				}
+				if err := fn(ctx, msg, m); err != nil {
+					slog.Error("Error handling message", "topic", msg.Topic, "partition", msg.Partition,
+						"offset", msg.Offset, "error", err)
+					continue
+				}
+				claim.Commit(msg)
+
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package bus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tiongMax/gostocks/proto/stock"
+)
+
+func TestEachProto(t *testing.T) {
+	m := NewMemory(1, 100)
+	p, _ := m.NewPublisher()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	good, err := Encode("ticks", "AAPL", &stock.StockTick{Symbol: "AAPL", Price: 190.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad := &Message{Topic: "ticks", Key: "AAPL", Value: []byte{0xff}}
+	last, _ := Encode("ticks", "AAPL", &stock.StockTick{Symbol: "AAPL", Price: 191})
+	if err := p.Publish(ctx, good, bad, last); err != nil {
+		t.Fatal(err)
+	}
+
+	ticks := make(chan *stock.StockTick, 10)
+	h := EachProto(func(ctx context.Context, msg *Message, tick *stock.StockTick) error {
+		ticks <- tick
+		if tick.Price == 191 {
+			cancel()
+		}
+		return nil
+	})
+	m.Subscribe(ctx, Subscription{Topics: []string{"ticks"}, Group: "test"}, h)
+
+	// The undecodable message is skipped
+	if got := len(ticks); got != 2 {
+		t.Fatalf("handled %d ticks, want 2", got)
+	}
+	if tick := <-ticks; tick.Symbol != "AAPL" || tick.Price != 190.5 {
+		t.Errorf("first tick = %v", tick)
+	}
+
+	// Handled messages are committed
+	if got := m.groups["test"].offsets["ticks"][0]; got != 3 {
+		t.Errorf("committed offset = %d, want 3", got)
+	}
+}
@@ -0,0 +1,68 @@
+// Package freshness decides when a symbol's latest price is too old to trust.
+package freshness
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SLA is the maximum age of a symbol's latest price before it is stale.
+// Symbols without an override use the default.
+type SLA struct {
+	def     time.Duration
+	symbols map[string]time.Duration
+}
+
+// NewSLA creates an SLA with a default and per-symbol overrides.
+func NewSLA(def time.Duration, symbols map[string]time.Duration) SLA {
+	return SLA{def: def, symbols: symbols}
+}
+
+// ParseSLA parses a default duration such as "60s" and overrides of the form
+// "AAPL=30s,BINANCE:BTCUSDT=10s". Either string may be empty; the default
+// then falls back to def.
+func ParseSLA(defStr, overrides string, def time.Duration) (SLA, error) {
+	if defStr != "" {
+		d, err := time.ParseDuration(defStr)
+		if err != nil || d <= 0 {
+			return SLA{}, fmt.Errorf("invalid freshness SLA %q", defStr)
+		}
+		def = d
+	}
+
+	symbols := make(map[string]time.Duration)
+	for _, entry := range strings.Split(overrides, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		symbol, durStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return SLA{}, fmt.Errorf("invalid freshness SLA override %q", entry)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(durStr))
+		if err != nil || d <= 0 {
+			return SLA{}, fmt.Errorf("invalid freshness SLA override %q", entry)
+		}
+		symbols[strings.ToUpper(strings.TrimSpace(symbol))] = d
+	}
+	return NewSLA(def, symbols), nil
+}
+
+// For returns the SLA of a symbol.
+func (s SLA) For(symbol string) time.Duration {
+	if d, ok := s.symbols[symbol]; ok {
+		return d
+	}
+	return s.def
+}
+
+// Stale reports whether a price observed at t is stale at now.
+// A zero t (unknown time) is always stale.
+func (s SLA) Stale(symbol string, t, now time.Time) bool {
+	if t.IsZero() {
+		return true
+	}
+	return now.Sub(t) > s.For(symbol)
+}
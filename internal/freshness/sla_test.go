@@ -0,0 +1,55 @@
+package freshness
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSLA(t *testing.T) {
+	sla, err := ParseSLA("", "AAPL=30s, binance:btcusdt=10s", time.Minute)
+	if err != nil {
+		t.Fatalf("ParseSLA() error = %v", err)
+	}
+
+	for symbol, want := range map[string]time.Duration{
+		"AAPL":            30 * time.Second,
+		"BINANCE:BTCUSDT": 10 * time.Second,
+		"MSFT":            time.Minute,
+	} {
+		if got := sla.For(symbol); got != want {
+			t.Errorf("For(%q) = %v, want %v", symbol, got, want)
+		}
+	}
+
+	for _, bad := range [][2]string{{"0s", ""}, {"soon", ""}, {"", "AAPL"}, {"", "AAPL=-1s"}} {
+		if _, err := ParseSLA(bad[0], bad[1], time.Minute); err == nil {
+			t.Errorf("ParseSLA(%q, %q) succeeded, want error", bad[0], bad[1])
+		}
+	}
+}
+
+func TestStale(t *testing.T) {
+	sla := NewSLA(time.Minute, map[string]time.Duration{"BTC": 10 * time.Second})
+	now := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		symbol string
+		t      time.Time
+		want   bool
+	}{
+		{"within default", "AAPL", now.Add(-59 * time.Second), false},
+		{"at the limit", "AAPL", now.Add(-time.Minute), false},
+		{"past default", "AAPL", now.Add(-61 * time.Second), true},
+		{"past override", "BTC", now.Add(-11 * time.Second), true},
+		{"unknown time", "AAPL", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sla.Stale(tt.symbol, tt.t, now); got != tt.want {
+				t.Errorf("Stale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
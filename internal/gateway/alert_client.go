@@ -3,9 +3,11 @@ package gateway
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	pb "github.com/tiongMax/gostocks/proto/alert"
+	pbt "github.com/tiongMax/gostocks/proto/trading"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -38,12 +40,57 @@ func NewAlertClient(addr string) (*AlertClient, error) {
 	}, nil
 }
 
+// WindowData restricts alert evaluation to a recurring local-time window.
+type WindowData struct {
+	Timezone     string  `json:"timezone,omitempty"`
+	Start        string  `json:"start,omitempty"`
+	End          string  `json:"end,omitempty"`
+	Weekdays     []int32 `json:"weekdays,omitempty"` // 0 = Sunday ... 6 = Saturday
+	SkipHolidays bool    `json:"skip_holidays,omitempty"`
+}
+
+// PortfolioRuleData is the subject of a portfolio alert: a metric of the
+// portfolio's live valuation and the threshold it is compared with.
+type PortfolioRuleData struct {
+	PortfolioID int32   `json:"portfolio_id" binding:"required,gt=0"`
+	Metric      string  `json:"metric" binding:"required,oneof=UNREALIZED_PNL DAY_CHANGE DAY_CHANGE_PERCENT MARKET_VALUE POSITION_WEIGHT"`
+	Threshold   float64 `json:"threshold"`        // Currency, or percent for DAY_CHANGE_PERCENT and POSITION_WEIGHT
+	Symbol      string  `json:"symbol,omitempty"` // Position for POSITION_WEIGHT
+}
+
+// OrderActionData is a paper order placed through the Trading Service when
+// an alert triggers.
+type OrderActionData struct {
+	Symbol     string  `json:"symbol,omitempty"` // Defaults to the alert's symbol, or the portfolio rule's
+	Side       string  `json:"side" binding:"required,oneof=BUY SELL"`
+	Type       string  `json:"type" binding:"required,oneof=MARKET LIMIT STOP STOP_LIMIT"`
+	Quantity   float64 `json:"quantity" binding:"required,gt=0"`
+	LimitPrice float64 `json:"limit_price,omitempty" binding:"gte=0"` // LIMIT and STOP_LIMIT
+	StopPrice  float64 `json:"stop_price,omitempty" binding:"gte=0"`  // STOP and STOP_LIMIT
+}
+
+// ActionResultData links a trigger to the order its action placed.
+type ActionResultData struct {
+	Status   string `json:"status"` // PENDING, PLACED or FAILED
+	OrderID  int64  `json:"order_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Attempts int32  `json:"attempts"`
+}
+
 // CreateAlertRequest represents the request body for creating an alert.
+// Price alerts need a symbol and target price, portfolio alerts a portfolio rule.
+// Either may place a paper order when it triggers.
 type CreateAlertRequest struct {
-	UserID      int32   `json:"user_id" binding:"required,gt=0"`
-	Symbol      string  `json:"symbol" binding:"required"`
-	TargetPrice float64 `json:"target_price" binding:"required,gt=0"`
-	Condition   string  `json:"condition" binding:"required,oneof=ABOVE BELOW"`
+	UserID          int32              `json:"user_id,omitempty" binding:"gte=0"`                        // Defaults to the caller; other users need the admin scope
+	Kind            string             `json:"kind,omitempty" binding:"omitempty,oneof=PRICE PORTFOLIO"` // Default PRICE
+	Symbol          string             `json:"symbol,omitempty" binding:"required_unless=Kind PORTFOLIO"`
+	TargetPrice     float64            `json:"target_price,omitempty" binding:"required_unless=Kind PORTFOLIO,omitempty,gt=0"`
+	Portfolio       *PortfolioRuleData `json:"portfolio,omitempty" binding:"required_if=Kind PORTFOLIO"`
+	Condition       string             `json:"condition" binding:"required,oneof=ABOVE BELOW"`
+	ExpiresAt       int64              `json:"expires_at,omitempty" binding:"gte=0"` // Unix timestamp
+	Window          *WindowData        `json:"window,omitempty"`
+	MarketHoursOnly bool               `json:"market_hours_only,omitempty"`
+	Action          *OrderActionData   `json:"action,omitempty"`
 }
 
 // CreateAlertResponse represents the response after creating an alert.
@@ -63,12 +110,23 @@ func (a *AlertClient) CreateAlert(ctx context.Context, req *CreateAlertRequest)
 		condition = pb.AlertCondition_BELOW
 	}
 
+	kind := pb.AlertKind_PRICE
+	if req.Kind == "PORTFOLIO" {
+		kind = pb.AlertKind_PORTFOLIO
+	}
+
 	// Call gRPC
 	resp, err := a.client.CreateAlert(ctx, &pb.CreateAlertRequest{
-		UserId:      req.UserID,
-		Symbol:      req.Symbol,
-		TargetPrice: req.TargetPrice,
-		Condition:   condition,
+		UserId:          req.UserID,
+		Kind:            kind,
+		Symbol:          req.Symbol,
+		TargetPrice:     req.TargetPrice,
+		Portfolio:       ruleToProto(req.Portfolio),
+		Condition:       condition,
+		ExpiresAt:       req.ExpiresAt,
+		Window:          windowToProto(req.Window),
+		MarketHoursOnly: req.MarketHoursOnly,
+		Action:          actionToProto(req.Action),
 	})
 	if err != nil {
 		return nil, err
@@ -82,13 +140,19 @@ func (a *AlertClient) CreateAlert(ctx context.Context, req *CreateAlertRequest)
 
 // AlertData represents a single alert in the response.
 type AlertData struct {
-	ID          int32   `json:"id"`
-	UserID      int32   `json:"user_id"`
-	Symbol      string  `json:"symbol"`
-	TargetPrice float64 `json:"target_price"`
-	Condition   string  `json:"condition"`
-	Triggered   bool    `json:"triggered"`
-	CreatedAt   int64   `json:"created_at"`
+	ID          int32              `json:"id"`
+	UserID      int32              `json:"user_id"`
+	Kind        string             `json:"kind"`
+	Symbol      string             `json:"symbol"`
+	TargetPrice float64            `json:"target_price"`
+	Portfolio   *PortfolioRuleData `json:"portfolio,omitempty"`
+	Condition   string             `json:"condition"`
+	Triggered   bool               `json:"triggered"`
+	Status      string             `json:"status"`
+	ExpiresAt   int64              `json:"expires_at,omitempty"`
+	Window      *WindowData        `json:"window,omitempty"`
+	Action      *OrderActionData   `json:"action,omitempty"`
+	CreatedAt   int64              `json:"created_at"`
 }
 
 // GetAlerts retrieves alerts from the Alert Service.
@@ -103,21 +167,19 @@ func (a *AlertClient) GetAlerts(ctx context.Context, userID int32, activeOnly bo
 
 	alerts := make([]AlertData, len(resp.Alerts))
 	for i, alert := range resp.Alerts {
-		condition := "UNKNOWN"
-		switch alert.Condition {
-		case pb.AlertCondition_ABOVE:
-			condition = "ABOVE"
-		case pb.AlertCondition_BELOW:
-			condition = "BELOW"
-		}
-
 		alerts[i] = AlertData{
 			ID:          alert.Id,
 			UserID:      alert.UserId,
+			Kind:        kindString(alert.Kind),
 			Symbol:      alert.Symbol,
 			TargetPrice: alert.TargetPrice,
-			Condition:   condition,
+			Portfolio:   ruleFromProto(alert.Portfolio),
+			Condition:   conditionString(alert.Condition),
 			Triggered:   alert.Triggered,
+			Status:      statusString(alert.Status),
+			ExpiresAt:   alert.ExpiresAt,
+			Window:      windowFromProto(alert.Window),
+			Action:      actionFromProto(alert.Action),
 			CreatedAt:   alert.CreatedAt,
 		}
 	}
@@ -125,6 +187,276 @@ func (a *AlertClient) GetAlerts(ctx context.Context, userID int32, activeOnly bo
 	return alerts, nil
 }
 
+// TriggerQuery filters the trigger history. Times are Unix milliseconds.
+type TriggerQuery struct {
+	UserID    int32
+	AlertID   int32
+	Symbol    string
+	From      int64
+	To        int64
+	Limit     int32
+	PageToken string
+}
+
+// NotificationData is the delivery outcome of a trigger on one channel.
+type NotificationData struct {
+	Channel   string `json:"channel"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// TriggerData represents a single alert trigger in the response.
+// For portfolio alerts, target_price is the rule's threshold, trigger_price
+// the metric's value and symbol that of the tick that fired the alert.
+type TriggerData struct {
+	ID            int64              `json:"id"`
+	AlertID       int32              `json:"alert_id"`
+	UserID        int32              `json:"user_id"`
+	Kind          string             `json:"kind"`
+	Symbol        string             `json:"symbol"`
+	Condition     string             `json:"condition"`
+	TargetPrice   float64            `json:"target_price"`
+	TriggerPrice  float64            `json:"trigger_price"`
+	Portfolio     *PortfolioRuleData `json:"portfolio,omitempty"`
+	Action        *ActionResultData  `json:"action,omitempty"`
+	TickTimestamp int64              `json:"tick_timestamp"`
+	Partition     int32              `json:"partition"`
+	Offset        int64              `json:"offset"`
+	TriggeredAt   int64              `json:"triggered_at"`
+	Notifications []NotificationData `json:"notifications"`
+}
+
+// ListAlertTriggers retrieves a page of trigger history from the Alert Service.
+// It returns the triggers and the token for the next page (empty if none).
+func (a *AlertClient) ListAlertTriggers(ctx context.Context, q TriggerQuery) ([]TriggerData, string, error) {
+	resp, err := a.client.ListAlertTriggers(ctx, &pb.ListAlertTriggersRequest{
+		UserId:    q.UserID,
+		AlertId:   q.AlertID,
+		Symbol:    q.Symbol,
+		From:      q.From,
+		To:        q.To,
+		PageSize:  q.Limit,
+		PageToken: q.PageToken,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	triggers := make([]TriggerData, len(resp.Triggers))
+	for i, t := range resp.Triggers {
+		triggers[i] = triggerFromProto(t)
+	}
+	return triggers, resp.NextPageToken, nil
+}
+
+// triggerFromProto converts a proto trigger to its JSON form.
+func triggerFromProto(t *pb.AlertTrigger) TriggerData {
+	notifications := make([]NotificationData, len(t.Notifications))
+	for i, n := range t.Notifications {
+		notifications[i] = NotificationData{
+			Channel:   n.Channel,
+			Status:    n.Status,
+			Error:     n.Error,
+			UpdatedAt: n.UpdatedAt,
+		}
+	}
+
+	return TriggerData{
+		ID:            t.Id,
+		AlertID:       t.AlertId,
+		UserID:        t.UserId,
+		Kind:          kindString(t.Kind),
+		Symbol:        t.Symbol,
+		Condition:     conditionString(t.Condition),
+		TargetPrice:   t.TargetPrice,
+		TriggerPrice:  t.TriggerPrice,
+		Portfolio:     ruleFromProto(t.Portfolio),
+		Action:        actionResultFromProto(t.Action),
+		TickTimestamp: t.TickTimestamp,
+		Partition:     t.Partition,
+		Offset:        t.Offset,
+		TriggeredAt:   t.TriggeredAt,
+		Notifications: notifications,
+	}
+}
+
+// AlertEventData is a single event relayed from a WatchAlerts stream.
+// Type is "trigger" or "heartbeat"; Cursor resumes the stream after this event.
+type AlertEventData struct {
+	Type      string       `json:"type"`
+	Cursor    int64        `json:"cursor"`
+	Trigger   *TriggerData `json:"trigger,omitempty"`
+	Timestamp int64        `json:"timestamp,omitempty"`
+}
+
+// WatchAlerts opens a WatchAlerts stream and calls fn for every event until
+// the context is cancelled, the stream fails, or fn returns an error.
+func (a *AlertClient) WatchAlerts(ctx context.Context, userID int32, symbols []string, cursor int64, fn func(AlertEventData) error) error {
+	stream, err := a.client.WatchAlerts(ctx, &pb.WatchAlertsRequest{
+		UserId:  userID,
+		Symbols: symbols,
+		Cursor:  cursor,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		data := AlertEventData{Cursor: event.Cursor}
+		switch e := event.Event.(type) {
+		case *pb.AlertEvent_Trigger:
+			trigger := triggerFromProto(e.Trigger)
+			data.Type = "trigger"
+			data.Trigger = &trigger
+		case *pb.AlertEvent_Heartbeat:
+			data.Type = "heartbeat"
+			data.Timestamp = e.Heartbeat.Timestamp
+		default:
+			continue
+		}
+
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+}
+
+// conditionString converts a proto AlertCondition to its JSON form.
+func conditionString(c pb.AlertCondition) string {
+	switch c {
+	case pb.AlertCondition_ABOVE:
+		return "ABOVE"
+	case pb.AlertCondition_BELOW:
+		return "BELOW"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// statusString converts a proto AlertStatus to its JSON form.
+func statusString(s pb.AlertStatus) string {
+	if s == pb.AlertStatus_STATUS_UNSPECIFIED {
+		return "UNKNOWN"
+	}
+	return s.String()
+}
+
+// kindString converts a proto AlertKind to its JSON form. Alerts created
+// before portfolio alerts existed are price alerts.
+func kindString(k pb.AlertKind) string {
+	if k == pb.AlertKind_PORTFOLIO {
+		return "PORTFOLIO"
+	}
+	return "PRICE"
+}
+
+// ruleToProto converts a JSON portfolio rule to its proto form.
+func ruleToProto(r *PortfolioRuleData) *pb.PortfolioRule {
+	if r == nil {
+		return nil
+	}
+	return &pb.PortfolioRule{
+		PortfolioId: r.PortfolioID,
+		Metric:      pb.PortfolioMetric(pb.PortfolioMetric_value[r.Metric]),
+		Threshold:   r.Threshold,
+		Symbol:      r.Symbol,
+	}
+}
+
+// ruleFromProto converts a proto portfolio rule to its JSON form.
+func ruleFromProto(r *pb.PortfolioRule) *PortfolioRuleData {
+	if r == nil {
+		return nil
+	}
+	return &PortfolioRuleData{
+		PortfolioID: r.PortfolioId,
+		Metric:      r.Metric.String(),
+		Threshold:   r.Threshold,
+		Symbol:      r.Symbol,
+	}
+}
+
+// actionToProto converts a JSON order action to its proto form.
+func actionToProto(a *OrderActionData) *pb.OrderAction {
+	if a == nil {
+		return nil
+	}
+	return &pb.OrderAction{
+		Symbol:     a.Symbol,
+		Side:       pbt.Side(pbt.Side_value[a.Side]),
+		Type:       pbt.OrderType(pbt.OrderType_value[a.Type]),
+		Quantity:   a.Quantity,
+		LimitPrice: a.LimitPrice,
+		StopPrice:  a.StopPrice,
+	}
+}
+
+// actionFromProto converts a proto order action to its JSON form.
+func actionFromProto(a *pb.OrderAction) *OrderActionData {
+	if a == nil {
+		return nil
+	}
+	return &OrderActionData{
+		Symbol:     a.Symbol,
+		Side:       a.Side.String(),
+		Type:       a.Type.String(),
+		Quantity:   a.Quantity,
+		LimitPrice: a.LimitPrice,
+		StopPrice:  a.StopPrice,
+	}
+}
+
+// actionResultFromProto converts the outcome of a trigger's order action to
+// its JSON form.
+func actionResultFromProto(r *pb.ActionResult) *ActionResultData {
+	if r == nil {
+		return nil
+	}
+	return &ActionResultData{
+		Status:   strings.TrimPrefix(r.Status.String(), "ACTION_"),
+		OrderID:  r.OrderId,
+		Error:    r.Error,
+		Attempts: r.Attempts,
+	}
+}
+
+// windowToProto converts a JSON window to its proto form.
+func windowToProto(w *WindowData) *pb.ActiveWindow {
+	if w == nil {
+		return nil
+	}
+	return &pb.ActiveWindow{
+		Timezone:     w.Timezone,
+		Start:        w.Start,
+		End:          w.End,
+		Weekdays:     w.Weekdays,
+		SkipHolidays: w.SkipHolidays,
+	}
+}
+
+// windowFromProto converts a proto window to its JSON form.
+func windowFromProto(w *pb.ActiveWindow) *WindowData {
+	if w == nil {
+		return nil
+	}
+	return &WindowData{
+		Timezone:     w.Timezone,
+		Start:        w.Start,
+		End:          w.End,
+		Weekdays:     w.Weekdays,
+		SkipHolidays: w.SkipHolidays,
+	}
+}
+
 // Close closes the gRPC connection.
 func (a *AlertClient) Close() error {
 	return a.conn.Close()
@@ -0,0 +1,536 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/tiongMax/gostocks/internal/apierror"
+	"github.com/tiongMax/gostocks/internal/auth"
+	"github.com/tiongMax/gostocks/internal/freshness"
+	pb "github.com/tiongMax/gostocks/proto/alert"
+	pbp "github.com/tiongMax/gostocks/proto/portfolio"
+	pbt "github.com/tiongMax/gostocks/proto/trading"
+	pbw "github.com/tiongMax/gostocks/proto/watchlist"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAlertService answers the gateway's alert RPCs with canned data.
+type fakeAlertService struct {
+	pb.UnimplementedAlertServiceServer
+}
+
+func (fakeAlertService) CreateAlert(ctx context.Context, req *pb.CreateAlertRequest) (*pb.CreateAlertResponse, error) {
+	if req.Symbol == "QUOTA" {
+		return nil, status.Error(codes.ResourceExhausted, "user already has 100 active alerts")
+	}
+	if req.Portfolio != nil && req.Portfolio.PortfolioId != 3 {
+		st, _ := status.New(codes.NotFound, "portfolio.portfolio_id is not one of the user's portfolios").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "portfolio.portfolio_id", Description: "is not one of the user's portfolios"}},
+		})
+		return nil, st.Err()
+	}
+	if req.ExpiresAt > 0 && req.ExpiresAt < time.Now().Unix() {
+		st, _ := status.New(codes.InvalidArgument, "expires_at must be in the future").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "expires_at", Description: "must be in the future"}},
+		})
+		return nil, st.Err()
+	}
+	return &pb.CreateAlertResponse{AlertId: 1, Message: "Alert created"}, nil
+}
+
+func (fakeAlertService) GetAlerts(ctx context.Context, req *pb.GetAlertsRequest) (*pb.GetAlertsResponse, error) {
+	return &pb.GetAlertsResponse{Alerts: []*pb.Alert{{
+		Id: 1, UserId: req.UserId, Symbol: "AAPL", TargetPrice: 150, Condition: pb.AlertCondition_ABOVE,
+		Status: pb.AlertStatus_ACTIVE, CreatedAt: 1700000000,
+		Window: &pb.ActiveWindow{Timezone: "America/New_York", Start: "09:30", End: "16:00", Weekdays: []int32{1, 2, 3, 4, 5}},
+	}, {
+		Id: 2, UserId: req.UserId, Kind: pb.AlertKind_PORTFOLIO, Condition: pb.AlertCondition_BELOW,
+		Status: pb.AlertStatus_ACTIVE, CreatedAt: 1700000000,
+		Portfolio: &pb.PortfolioRule{PortfolioId: 3, Metric: pb.PortfolioMetric_UNREALIZED_PNL, Threshold: -5000},
+		Action:    &pb.OrderAction{Symbol: "AAPL", Side: pbt.Side_SELL, Type: pbt.OrderType_MARKET, Quantity: 10},
+	}}}, nil
+}
+
+func (fakeAlertService) ListAlertTriggers(ctx context.Context, req *pb.ListAlertTriggersRequest) (*pb.ListAlertTriggersResponse, error) {
+	if req.PageToken == "broken" {
+		return nil, status.Error(codes.Internal, "failed to list alert triggers: connection reset")
+	}
+	return &pb.ListAlertTriggersResponse{Triggers: []*pb.AlertTrigger{{
+		Id: 9, AlertId: 1, UserId: req.UserId, Symbol: "AAPL", Condition: pb.AlertCondition_ABOVE,
+		TargetPrice: 150, TriggerPrice: 151, TickTimestamp: 1700000000000, TriggeredAt: 1700000000500,
+		Notifications: []*pb.NotificationStatus{{Channel: "log", Status: "SENT", UpdatedAt: 1700000000600}},
+		Action:        &pb.ActionResult{Status: pb.ActionStatus_ACTION_PLACED, OrderId: 12, Attempts: 1},
+	}, {
+		Id: 8, AlertId: 2, UserId: req.UserId, Kind: pb.AlertKind_PORTFOLIO, Symbol: "NVDA", Condition: pb.AlertCondition_ABOVE,
+		TargetPrice: 25, TriggerPrice: 26.4, TickTimestamp: 1699999990000, TriggeredAt: 1699999990200,
+		Portfolio:     &pb.PortfolioRule{PortfolioId: 3, Metric: pb.PortfolioMetric_POSITION_WEIGHT, Threshold: 25, Symbol: "NVDA"},
+		Notifications: []*pb.NotificationStatus{},
+		Action:        &pb.ActionResult{Status: pb.ActionStatus_ACTION_FAILED, Error: "quantity exceeds the position held", Attempts: 1},
+	}}}, nil
+}
+
+// fakeWatchlistService serves watchlist 1 (AAPL and NOPE) and the empty
+// watchlist 2, both owned by user 7.
+type fakeWatchlistService struct {
+	pbw.UnimplementedWatchlistServiceServer
+}
+
+func (fakeWatchlistService) watchlist(id, userID int32) (*pbw.Watchlist, error) {
+	if (id != 1 && id != 2) || (userID != 0 && userID != 7) {
+		return nil, status.Error(codes.NotFound, "watchlist not found")
+	}
+	w := &pbw.Watchlist{Id: id, UserId: 7, Name: "Tech", CreatedAt: 1700000000, UpdatedAt: 1700000000}
+	if id == 1 {
+		w.Symbols = []string{"AAPL", "NOPE"}
+	}
+	return w, nil
+}
+
+func (f fakeWatchlistService) CreateWatchlist(ctx context.Context, req *pbw.CreateWatchlistRequest) (*pbw.Watchlist, error) {
+	if req.Name == "Taken" {
+		st, _ := status.New(codes.AlreadyExists, "name is already used by another watchlist").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "name", Description: "is already used by another watchlist"}},
+		})
+		return nil, st.Err()
+	}
+	return &pbw.Watchlist{Id: 3, UserId: req.UserId, Name: req.Name, Symbols: req.Symbols, CreatedAt: 1700000000, UpdatedAt: 1700000000}, nil
+}
+
+func (f fakeWatchlistService) GetWatchlist(ctx context.Context, req *pbw.GetWatchlistRequest) (*pbw.Watchlist, error) {
+	return f.watchlist(req.Id, req.UserId)
+}
+
+func (f fakeWatchlistService) ListWatchlists(ctx context.Context, req *pbw.ListWatchlistsRequest) (*pbw.ListWatchlistsResponse, error) {
+	w, _ := f.watchlist(1, req.UserId)
+	return &pbw.ListWatchlistsResponse{Watchlists: []*pbw.Watchlist{w}}, nil
+}
+
+func (f fakeWatchlistService) RenameWatchlist(ctx context.Context, req *pbw.RenameWatchlistRequest) (*pbw.Watchlist, error) {
+	w, err := f.watchlist(req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	w.Name = req.Name
+	return w, nil
+}
+
+func (f fakeWatchlistService) DeleteWatchlist(ctx context.Context, req *pbw.DeleteWatchlistRequest) (*pbw.DeleteWatchlistResponse, error) {
+	if _, err := f.watchlist(req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+	return &pbw.DeleteWatchlistResponse{}, nil
+}
+
+func (f fakeWatchlistService) AddSymbols(ctx context.Context, req *pbw.UpdateSymbolsRequest) (*pbw.Watchlist, error) {
+	w, err := f.watchlist(req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	w.Symbols = append(w.Symbols, req.Symbols...)
+	return w, nil
+}
+
+func (f fakeWatchlistService) RemoveSymbols(ctx context.Context, req *pbw.UpdateSymbolsRequest) (*pbw.Watchlist, error) {
+	w, err := f.watchlist(req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	w.Symbols = []string{"NOPE"}
+	return w, nil
+}
+
+// fakePortfolioService serves portfolio 1, owned by user 7, holding 10 AAPL.
+type fakePortfolioService struct {
+	pbp.UnimplementedPortfolioServiceServer
+}
+
+func (fakePortfolioService) portfolio(id, userID int32) (*pbp.Portfolio, error) {
+	if id != 1 || (userID != 0 && userID != 7) {
+		return nil, status.Error(codes.NotFound, "portfolio not found")
+	}
+	return &pbp.Portfolio{Id: 1, UserId: 7, Name: "Core", CostMethod: pbp.CostMethod_FIFO, CreatedAt: 1700000000}, nil
+}
+
+func (fakePortfolioService) valuation() *pbp.Valuation {
+	return &pbp.Valuation{
+		PortfolioId: 1, CostMethod: pbp.CostMethod_FIFO,
+		Positions: []*pbp.Position{{
+			Symbol: "AAPL", Quantity: 10, AverageCost: 100, CostBasis: 1000, LastPrice: 150.25, PriceTime: 1700000000000,
+			MarketValue: 1502.5, UnrealizedPnl: 502.5, DayChange: 12.5, Weight: 1, Priced: true,
+			Lots: []*pbp.Lot{{Quantity: 10, Price: 100, OpenedAt: 1690000000000}},
+		}},
+		MarketValue: 1502.5, CostBasis: 1000, UnrealizedPnl: 502.5, DayChange: 12.5, DayChangePercent: 0.84,
+		Timestamp: 1700000000500,
+	}
+}
+
+func (fakePortfolioService) CreatePortfolio(ctx context.Context, req *pbp.CreatePortfolioRequest) (*pbp.Portfolio, error) {
+	return &pbp.Portfolio{Id: 2, UserId: req.UserId, Name: req.Name, CostMethod: pbp.CostMethod_LIFO, CreatedAt: 1700000000}, nil
+}
+
+func (f fakePortfolioService) GetPortfolio(ctx context.Context, req *pbp.GetPortfolioRequest) (*pbp.Portfolio, error) {
+	return f.portfolio(req.Id, req.UserId)
+}
+
+func (f fakePortfolioService) ListPortfolios(ctx context.Context, req *pbp.ListPortfoliosRequest) (*pbp.ListPortfoliosResponse, error) {
+	p, _ := f.portfolio(1, 0)
+	return &pbp.ListPortfoliosResponse{Portfolios: []*pbp.Portfolio{p}}, nil
+}
+
+func (f fakePortfolioService) DeletePortfolio(ctx context.Context, req *pbp.DeletePortfolioRequest) (*pbp.DeletePortfolioResponse, error) {
+	if _, err := f.portfolio(req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+	return &pbp.DeletePortfolioResponse{}, nil
+}
+
+func (f fakePortfolioService) RecordTransaction(ctx context.Context, req *pbp.RecordTransactionRequest) (*pbp.Transaction, error) {
+	if _, err := f.portfolio(req.PortfolioId, req.UserId); err != nil {
+		return nil, err
+	}
+	if req.Side == pbp.Side_SELL && req.Quantity > 10 {
+		st, _ := status.New(codes.FailedPrecondition, "quantity sell exceeds the quantity held").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "quantity", Description: "sell exceeds the quantity held"}},
+		})
+		return nil, st.Err()
+	}
+	return &pbp.Transaction{
+		Id: 5, PortfolioId: req.PortfolioId, Symbol: req.Symbol, Side: req.Side, Quantity: req.Quantity,
+		Price: req.Price, Fee: req.Fee, ExecutedAt: 1700000000000, CreatedAt: 1700000000,
+	}, nil
+}
+
+func (f fakePortfolioService) ListTransactions(ctx context.Context, req *pbp.ListTransactionsRequest) (*pbp.ListTransactionsResponse, error) {
+	if _, err := f.portfolio(req.PortfolioId, req.UserId); err != nil {
+		return nil, err
+	}
+	return &pbp.ListTransactionsResponse{Transactions: []*pbp.Transaction{{
+		Id: 1, PortfolioId: 1, Symbol: "AAPL", Side: pbp.Side_BUY, Quantity: 10, Price: 100,
+		ExecutedAt: 1690000000000, CreatedAt: 1690000000,
+	}}}, nil
+}
+
+func (f fakePortfolioService) GetValuation(ctx context.Context, req *pbp.GetValuationRequest) (*pbp.Valuation, error) {
+	if _, err := f.portfolio(req.PortfolioId, req.UserId); err != nil {
+		return nil, err
+	}
+	return f.valuation(), nil
+}
+
+// fakeTradingService serves order 1, an open AAPL limit buy of user 7, and
+// an account holding 10 AAPL.
+type fakeTradingService struct {
+	pbt.UnimplementedTradingServiceServer
+}
+
+func (fakeTradingService) order(id int64, userID int32) (*pbt.Order, error) {
+	if id != 1 || (userID != 0 && userID != 7) {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return &pbt.Order{
+		Id: 1, UserId: 7, Symbol: "AAPL", Side: pbt.Side_BUY, Type: pbt.OrderType_LIMIT, Quantity: 5, LimitPrice: 140,
+		Status: pbt.OrderStatus_OPEN, CreatedAt: 1700000000000, UpdatedAt: 1700000000000,
+	}, nil
+}
+
+func (fakeTradingService) account(userID int32, cash float64) *pbt.Account {
+	return &pbt.Account{
+		UserId: userID, Cash: cash, StartingCash: 100000, RealizedPnl: 25,
+		Positions: []*pbt.Position{{Symbol: "AAPL", Quantity: 10, AverageCost: 100, RealizedPnl: 25}},
+		UpdatedAt: 1700000000000,
+	}
+}
+
+func (fakeTradingService) PlaceOrder(ctx context.Context, req *pbt.PlaceOrderRequest) (*pbt.Order, error) {
+	if req.Side == pbt.Side_SELL && req.Quantity > 10 {
+		st, _ := status.New(codes.FailedPrecondition, "quantity exceeds the position held").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "quantity", Description: "exceeds the position held"}},
+		})
+		return nil, st.Err()
+	}
+	return &pbt.Order{
+		Id: 2, UserId: req.UserId, ClientOrderId: req.ClientOrderId, Symbol: req.Symbol, Side: req.Side, Type: req.Type,
+		Quantity: req.Quantity, LimitPrice: req.LimitPrice, StopPrice: req.StopPrice, Status: pbt.OrderStatus_OPEN,
+		CreatedAt: 1700000000000, UpdatedAt: 1700000000000,
+	}, nil
+}
+
+func (f fakeTradingService) GetOrder(ctx context.Context, req *pbt.GetOrderRequest) (*pbt.Order, error) {
+	return f.order(req.Id, req.UserId)
+}
+
+func (f fakeTradingService) ListOrders(ctx context.Context, req *pbt.ListOrdersRequest) (*pbt.ListOrdersResponse, error) {
+	o, _ := f.order(1, 0)
+	filled := &pbt.Order{
+		Id: 3, UserId: 7, Symbol: "AAPL", Side: pbt.Side_SELL, Type: pbt.OrderType_MARKET, Quantity: 2,
+		Status: pbt.OrderStatus_FILLED, FillPrice: 150.2, Fee: 1, CreatedAt: 1700000000000, UpdatedAt: 1700000001000, FilledAt: 1700000001000,
+	}
+	return &pbt.ListOrdersResponse{Orders: []*pbt.Order{filled, o}}, nil
+}
+
+func (f fakeTradingService) CancelOrder(ctx context.Context, req *pbt.CancelOrderRequest) (*pbt.Order, error) {
+	if req.Id == 3 {
+		return nil, status.Error(codes.FailedPrecondition, "order is not open")
+	}
+	o, err := f.order(req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	o.Status = pbt.OrderStatus_CANCELLED
+	return o, nil
+}
+
+func (f fakeTradingService) GetAccount(ctx context.Context, req *pbt.GetAccountRequest) (*pbt.Account, error) {
+	return f.account(req.UserId, 98500), nil
+}
+
+func (f fakeTradingService) ResetAccount(ctx context.Context, req *pbt.ResetAccountRequest) (*pbt.Account, error) {
+	a := f.account(req.UserId, 100000)
+	a.Positions, a.RealizedPnl = nil, 0
+	return a, nil
+}
+
+// newContractServer runs the gateway router with strict OpenAPI validation
+// against an in-memory Redis and fake Alert, Watchlist, Portfolio and
+// Trading Services. History is not backed, so only its request validation can be exercised.
+func newContractServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	mr := miniredis.RunT(t)
+	now := time.Now()
+	mr.HSet("price:AAPL",
+		"price", "150.25", "timestamp", strconv.FormatInt(now.Unix(), 10), "volume", "10",
+		"open", "149", "high", "151", "low", "148.5", "day_volume", "12000",
+		"event_time", strconv.FormatInt(now.UnixMilli(), 10), "ingest_time", strconv.FormatInt(now.UnixMilli(), 10))
+	redisClient, err := NewRedisClient(mr.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { redisClient.Close() })
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterAlertServiceServer(grpcServer, fakeAlertService{})
+	pbw.RegisterWatchlistServiceServer(grpcServer, fakeWatchlistService{})
+	pbp.RegisterPortfolioServiceServer(grpcServer, fakePortfolioService{})
+	pbt.RegisterTradingServiceServer(grpcServer, fakeTradingService{})
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	alertClient, err := NewAlertClient(lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { alertClient.Close() })
+
+	watchlistClient, err := NewWatchlistClient(lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { watchlistClient.Close() })
+
+	portfolioClient, err := NewPortfolioClient(lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { portfolioClient.Close() })
+
+	tradingClient, err := NewTradingClient(lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { tradingClient.Close() })
+
+	secret := []byte("contract-secret")
+	verifier, err := auth.NewJWTVerifier(secret, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "7", "exp": now.Add(time.Hour).Unix(),
+	}).SignedString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := LoadSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	router.Use(apierror.RequestID(), auth.TokenFromQuery(), ValidateOpenAPI(spec, ValidationStrict), gin.Recovery())
+	handler := NewHandler(redisClient, alertClient, watchlistClient, portfolioClient, tradingClient, nil, NewHub(redisClient), freshness.NewSLA(time.Minute, nil), StaleModeFlag, time.Second)
+	pass := func(c *gin.Context) { c.Next() }
+	RegisterRoutes(router, handler, spec, RouteMiddleware{
+		IPLimit: pass,
+		Auth:    auth.NewAuthenticator(nil, verifier).Middleware(),
+		Read:    pass,
+		Write:   pass,
+		Stream:  pass,
+	})
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv, token
+}
+
+func TestContract(t *testing.T) {
+	srv, token := newContractServer(t)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		noAuth     bool
+		wantStatus int
+	}{
+		{"health", "GET", "/health", "", true, http.StatusOK},
+		{"spec", "GET", "/openapi.json", "", true, http.StatusOK},
+		{"docs", "GET", "/docs", "", true, http.StatusOK},
+		{"missing credentials", "GET", "/price/AAPL", "", true, http.StatusUnauthorized},
+		{"price", "GET", "/price/aapl", "", false, http.StatusOK},
+		{"price not found", "GET", "/price/NOPE", "", false, http.StatusNotFound},
+		{"prices", "GET", "/prices?symbols=AAPL,NOPE", "", false, http.StatusOK},
+		{"prices without symbols", "GET", "/prices", "", false, http.StatusBadRequest},
+		{"post prices", "POST", "/prices", `{"symbols": ["AAPL"]}`, false, http.StatusOK},
+		{"post prices wrong type", "POST", "/prices", `{"symbols": "AAPL"}`, false, http.StatusBadRequest},
+		{"history bad format", "GET", "/history/AAPL?format=xml", "", false, http.StatusBadRequest},
+		{"history bad range", "GET", "/history/AAPL?from=2000&to=1000", "", false, http.StatusBadRequest},
+		{"history bad interval", "GET", "/history/AAPL?interval=7x", "", false, http.StatusBadRequest},
+		{"create alert", "POST", "/alerts", `{"symbol": "AAPL", "target_price": 150, "condition": "ABOVE"}`, false, http.StatusCreated},
+		{"create alert invalid body", "POST", "/alerts", `{"symbol": "AAPL", "target_price": -1, "condition": "ABOVE"}`, false, http.StatusBadRequest},
+		{"create alert rejected upstream", "POST", "/alerts", `{"symbol": "AAPL", "target_price": 150, "condition": "ABOVE", "expires_at": 1000}`, false, http.StatusBadRequest},
+		{"create alert over quota", "POST", "/alerts", `{"symbol": "QUOTA", "target_price": 150, "condition": "ABOVE"}`, false, http.StatusTooManyRequests},
+		{"create alert for other user", "POST", "/alerts", `{"user_id": 8, "symbol": "AAPL", "target_price": 150, "condition": "ABOVE"}`, false, http.StatusForbidden},
+		{"create portfolio alert", "POST", "/alerts", `{"kind": "PORTFOLIO", "portfolio": {"portfolio_id": 3, "metric": "POSITION_WEIGHT", "threshold": 25, "symbol": "nvda"}, "condition": "ABOVE"}`, false, http.StatusCreated},
+		{"create portfolio alert without rule", "POST", "/alerts", `{"kind": "PORTFOLIO", "condition": "BELOW"}`, false, http.StatusBadRequest},
+		{"create portfolio alert unknown metric", "POST", "/alerts", `{"kind": "PORTFOLIO", "portfolio": {"portfolio_id": 3, "metric": "BETA", "threshold": 1}, "condition": "ABOVE"}`, false, http.StatusBadRequest},
+		{"create portfolio alert on other portfolio", "POST", "/alerts", `{"kind": "PORTFOLIO", "portfolio": {"portfolio_id": 4, "metric": "DAY_CHANGE_PERCENT", "threshold": -2}, "condition": "BELOW"}`, false, http.StatusNotFound},
+		{"create alert with order action", "POST", "/alerts", `{"symbol": "AAPL", "target_price": 180, "condition": "BELOW", "action": {"side": "BUY", "type": "MARKET", "quantity": 10}}`, false, http.StatusCreated},
+		{"create alert with bad order action", "POST", "/alerts", `{"symbol": "AAPL", "target_price": 180, "condition": "BELOW", "action": {"side": "BUY", "type": "MARKET", "quantity": 0}}`, false, http.StatusBadRequest},
+		{"alerts", "GET", "/alerts?active_only=true", "", false, http.StatusOK},
+		{"alerts bad user_id", "GET", "/alerts?user_id=abc", "", false, http.StatusBadRequest},
+		{"triggers", "GET", "/alerts/triggers?limit=10", "", false, http.StatusOK},
+		{"triggers upstream failure", "GET", "/alerts/triggers?page_token=broken", "", false, http.StatusInternalServerError},
+		{"create watchlist", "POST", "/watchlists", `{"name": "Tech", "symbols": ["AAPL"]}`, false, http.StatusCreated},
+		{"create watchlist without name", "POST", "/watchlists", `{"symbols": ["AAPL"]}`, false, http.StatusBadRequest},
+		{"create watchlist name taken", "POST", "/watchlists", `{"name": "Taken"}`, false, http.StatusConflict},
+		{"watchlists", "GET", "/watchlists", "", false, http.StatusOK},
+		{"watchlist", "GET", "/watchlists/1", "", false, http.StatusOK},
+		{"watchlist bad id", "GET", "/watchlists/abc", "", false, http.StatusBadRequest},
+		{"watchlist not found", "GET", "/watchlists/9", "", false, http.StatusNotFound},
+		{"rename watchlist", "PATCH", "/watchlists/1", `{"name": "Growth"}`, false, http.StatusOK},
+		{"delete watchlist", "DELETE", "/watchlists/1", "", false, http.StatusNoContent},
+		{"add watchlist symbols", "POST", "/watchlists/1/symbols", `{"symbols": ["MSFT"]}`, false, http.StatusOK},
+		{"remove watchlist symbol", "DELETE", "/watchlists/1/symbols/aapl", "", false, http.StatusOK},
+		{"watchlist prices", "GET", "/watchlists/1/prices", "", false, http.StatusOK},
+		{"empty watchlist prices", "GET", "/watchlists/2/prices", "", false, http.StatusOK},
+		{"create portfolio", "POST", "/portfolios", `{"name": "Core", "cost_method": "LIFO"}`, false, http.StatusCreated},
+		{"create portfolio bad cost method", "POST", "/portfolios", `{"name": "Core", "cost_method": "HIFO"}`, false, http.StatusBadRequest},
+		{"portfolios", "GET", "/portfolios", "", false, http.StatusOK},
+		{"portfolio", "GET", "/portfolios/1", "", false, http.StatusOK},
+		{"portfolio not found", "GET", "/portfolios/9", "", false, http.StatusNotFound},
+		{"delete portfolio", "DELETE", "/portfolios/1", "", false, http.StatusNoContent},
+		{"record transaction", "POST", "/portfolios/1/transactions", `{"symbol": "AAPL", "side": "BUY", "quantity": 5, "price": 150.5, "fee": 1}`, false, http.StatusCreated},
+		{"record transaction zero quantity", "POST", "/portfolios/1/transactions", `{"symbol": "AAPL", "side": "BUY", "quantity": 0, "price": 150}`, false, http.StatusBadRequest},
+		{"record oversell", "POST", "/portfolios/1/transactions", `{"symbol": "AAPL", "side": "SELL", "quantity": 50, "price": 150}`, false, http.StatusBadRequest},
+		{"transactions", "GET", "/portfolios/1/transactions?symbol=aapl", "", false, http.StatusOK},
+		{"valuation", "GET", "/portfolios/1/valuation", "", false, http.StatusOK},
+		{"place order", "POST", "/orders", `{"symbol": "aapl", "side": "BUY", "type": "LIMIT", "quantity": 5, "limit_price": 140, "client_order_id": "abc-1"}`, false, http.StatusCreated},
+		{"place order bad type", "POST", "/orders", `{"symbol": "AAPL", "side": "BUY", "type": "TRAILING", "quantity": 5}`, false, http.StatusBadRequest},
+		{"place order zero quantity", "POST", "/orders", `{"symbol": "AAPL", "side": "BUY", "type": "MARKET", "quantity": 0}`, false, http.StatusBadRequest},
+		{"place oversell", "POST", "/orders", `{"symbol": "AAPL", "side": "SELL", "type": "MARKET", "quantity": 50}`, false, http.StatusBadRequest},
+		{"orders", "GET", "/orders?status=OPEN&symbol=aapl", "", false, http.StatusOK},
+		{"orders bad status", "GET", "/orders?status=PENDING", "", false, http.StatusBadRequest},
+		{"order", "GET", "/orders/1", "", false, http.StatusOK},
+		{"order bad id", "GET", "/orders/abc", "", false, http.StatusBadRequest},
+		{"order not found", "GET", "/orders/9", "", false, http.StatusNotFound},
+		{"cancel order", "DELETE", "/orders/1", "", false, http.StatusOK},
+		{"cancel filled order", "DELETE", "/orders/3", "", false, http.StatusBadRequest},
+		{"account", "GET", "/account", "", false, http.StatusOK},
+		{"reset account", "POST", "/account/reset", `{"cash": 50000}`, false, http.StatusOK},
+		{"reset account negative cash", "POST", "/account/reset", `{"cash": -1}`, false, http.StatusBadRequest},
+		{"stream portfolio not found", "GET", "/stream/portfolios/9", "", false, http.StatusNotFound},
+		{"stream prices without symbols", "GET", "/stream/prices", "", false, http.StatusBadRequest},
+		{"stream alerts bad cursor", "GET", "/stream/alerts?last_event_id=x", "", false, http.StatusBadRequest},
+		{"watch alerts bad cursor", "GET", "/alerts/watch?cursor=-1", "", false, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			if !tt.noAuth {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+
+			// Strict validation turns responses that break the spec into 500s
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d; body: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if resp.Header.Get(apierror.RequestIDHeader) == "" {
+				t.Errorf("missing %s header", apierror.RequestIDHeader)
+			}
+		})
+	}
+}
+
+func TestSpecCoversRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	spec, err := LoadSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	pass := func(c *gin.Context) { c.Next() }
+	RegisterRoutes(router, &Handler{}, spec, RouteMiddleware{IPLimit: pass, Auth: pass, Read: pass, Write: pass, Stream: pass})
+
+	registered := make(map[string]bool)
+	for _, r := range router.Routes() {
+		key := r.Method + " " + specPath(r.Path)
+		registered[key] = true
+		item := spec.Paths.Find(specPath(r.Path))
+		if item == nil || item.GetOperation(r.Method) == nil {
+			t.Errorf("route %s is not in the OpenAPI spec", key)
+		}
+	}
+	for path, item := range spec.Paths.Map() {
+		for method := range item.Operations() {
+			if key := method + " " + path; !registered[key] {
+				t.Errorf("spec operation %s has no route", key)
+			}
+		}
+	}
+}
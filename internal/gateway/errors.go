@@ -0,0 +1,150 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/tiongMax/gostocks/internal/apierror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcErrors maps gRPC status codes to HTTP statuses and envelope codes.
+var grpcErrors = map[codes.Code]struct {
+	status int
+	code   string
+}{
+	codes.Canceled:           {499, apierror.CodeCancelled}, // Client closed request
+	codes.Unknown:            {http.StatusInternalServerError, apierror.CodeUnknown},
+	codes.InvalidArgument:    {http.StatusBadRequest, apierror.CodeInvalidArgument},
+	codes.DeadlineExceeded:   {http.StatusGatewayTimeout, apierror.CodeDeadlineExceeded},
+	codes.NotFound:           {http.StatusNotFound, apierror.CodeNotFound},
+	codes.AlreadyExists:      {http.StatusConflict, apierror.CodeAlreadyExists},
+	codes.PermissionDenied:   {http.StatusForbidden, apierror.CodePermissionDenied},
+	codes.ResourceExhausted:  {http.StatusTooManyRequests, apierror.CodeResourceExhausted},
+	codes.FailedPrecondition: {http.StatusBadRequest, apierror.CodeFailedPrecondition},
+	codes.Aborted:            {http.StatusConflict, apierror.CodeAborted},
+	codes.OutOfRange:         {http.StatusBadRequest, apierror.CodeOutOfRange},
+	codes.Unimplemented:      {http.StatusNotImplemented, apierror.CodeUnimplemented},
+	codes.Internal:           {http.StatusInternalServerError, apierror.CodeInternal},
+	codes.Unavailable:        {http.StatusServiceUnavailable, apierror.CodeUnavailable},
+	codes.DataLoss:           {http.StatusInternalServerError, apierror.CodeInternal},
+	codes.Unauthenticated:    {http.StatusUnauthorized, apierror.CodeUnauthenticated},
+}
+
+// fromGRPC translates an error returned by a gRPC call. Client errors keep
+// the service's message and field violations; server errors get a generic
+// message so that internal details are not leaked to callers.
+func fromGRPC(err error) *apierror.Error {
+	st := status.Convert(err)
+	m, ok := grpcErrors[st.Code()]
+	if !ok {
+		m = grpcErrors[codes.Unknown]
+	}
+
+	e := &apierror.Error{Status: m.status, Code: m.code, Message: st.Message()}
+	switch {
+	case st.Code() == codes.Unavailable:
+		e.Message = "upstream service unavailable"
+	case st.Code() == codes.DeadlineExceeded:
+		e.Message = "upstream service timed out"
+	case m.status >= 500:
+		e.Message = "internal error"
+	}
+
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				e.Details = append(e.Details, apierror.FieldViolation{Field: v.GetField(), Description: v.GetDescription()})
+			}
+		}
+	}
+	return e
+}
+
+// respondGRPC writes the translated form of a failed gRPC call. Server
+// errors are logged with the request ID so that they can be correlated.
+func respondGRPC(c *gin.Context, msg string, err error, args ...any) {
+	e := fromGRPC(err)
+	if e.Status >= 500 {
+		slog.Error(msg, append(args, "request_id", apierror.RequestIDFrom(c), "error", err)...)
+	} else {
+		slog.Warn(msg, append(args, "request_id", apierror.RequestIDFrom(c), "error", err)...)
+	}
+	apierror.Respond(c, e)
+}
+
+func init() {
+	// Report binding failures under the JSON field names clients send
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
+	}
+}
+
+// fromBinding translates a request body binding error, listing each
+// invalid field.
+func fromBinding(err error) *apierror.Error {
+	e := apierror.New(http.StatusBadRequest, "invalid request body")
+
+	var verrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &verrs):
+		for _, fe := range verrs {
+			e.Details = append(e.Details, apierror.FieldViolation{Field: fieldPath(fe), Description: describe(fe)})
+		}
+	case errors.As(err, &typeErr):
+		e.Details = []apierror.FieldViolation{{Field: typeErr.Field, Description: "must be of type " + typeErr.Type.String()}}
+	default:
+		e.Message = "invalid request body: " + err.Error()
+	}
+	return e
+}
+
+// fieldPath returns the dotted JSON path of the field, without the
+// top-level struct name.
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+// describe renders a validation failure for the common binding tags.
+func describe(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_if", "required_unless":
+		return "is required"
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte":
+		return "must be at least " + fe.Param()
+	case "lt":
+		return "must be less than " + fe.Param()
+	case "lte":
+		return "must be at most " + fe.Param()
+	case "max":
+		if fe.Kind() == reflect.String {
+			return "must be at most " + fe.Param() + " characters"
+		}
+		return "must be at most " + fe.Param()
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	}
+	return "failed the " + fe.Tag() + " check"
+}
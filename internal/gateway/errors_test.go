@@ -0,0 +1,82 @@
+package gateway
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/tiongMax/gostocks/internal/apierror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromGRPC(t *testing.T) {
+	withViolation, _ := status.New(codes.InvalidArgument, "target_price must be positive").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "target_price", Description: "must be positive"}},
+	})
+
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantMessage string
+		wantDetails []apierror.FieldViolation
+	}{
+		{
+			"invalid argument with field violation", withViolation.Err(),
+			http.StatusBadRequest, apierror.CodeInvalidArgument, "target_price must be positive",
+			[]apierror.FieldViolation{{Field: "target_price", Description: "must be positive"}},
+		},
+		{"not found", status.Error(codes.NotFound, "alert not found"), http.StatusNotFound, apierror.CodeNotFound, "alert not found", nil},
+		{"quota", status.Error(codes.ResourceExhausted, "user already has 100 active alerts"), http.StatusTooManyRequests, apierror.CodeResourceExhausted, "user already has 100 active alerts", nil},
+		{"internal hides message", status.Error(codes.Internal, "pq: relation does not exist"), http.StatusInternalServerError, apierror.CodeInternal, "internal error", nil},
+		{"unavailable", status.Error(codes.Unavailable, "connection refused"), http.StatusServiceUnavailable, apierror.CodeUnavailable, "upstream service unavailable", nil},
+		{"non-status error", errors.New("boom"), http.StatusInternalServerError, apierror.CodeUnknown, "internal error", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := fromGRPC(tt.err)
+			if e.Status != tt.wantStatus || e.Code != tt.wantCode || e.Message != tt.wantMessage {
+				t.Errorf("fromGRPC() = %d %s %q, want %d %s %q", e.Status, e.Code, e.Message, tt.wantStatus, tt.wantCode, tt.wantMessage)
+			}
+			if len(e.Details) != len(tt.wantDetails) {
+				t.Fatalf("details = %v, want %v", e.Details, tt.wantDetails)
+			}
+			for i := range e.Details {
+				if e.Details[i] != tt.wantDetails[i] {
+					t.Errorf("details[%d] = %v, want %v", i, e.Details[i], tt.wantDetails[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFromBinding(t *testing.T) {
+	req := CreateAlertRequest{Symbol: "AAPL", TargetPrice: -1, Condition: "SIDEWAYS"}
+	err := binding.Validator.ValidateStruct(&req)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	e := fromBinding(err)
+	if e.Status != http.StatusBadRequest || e.Code != apierror.CodeInvalidArgument {
+		t.Errorf("fromBinding() = %d %s, want 400 %s", e.Status, e.Code, apierror.CodeInvalidArgument)
+	}
+
+	want := map[string]string{
+		"target_price": "must be greater than 0",
+		"condition":    "must be one of: ABOVE, BELOW",
+	}
+	if len(e.Details) != len(want) {
+		t.Fatalf("details = %v, want %v", e.Details, want)
+	}
+	for _, v := range e.Details {
+		if want[v.Field] != v.Description {
+			t.Errorf("violation %s = %q, want %q", v.Field, v.Description, want[v.Field])
+		}
+	}
+}
@@ -1,71 +1,330 @@
 package gateway
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tiongMax/gostocks/internal/apierror"
+	"github.com/tiongMax/gostocks/internal/auth"
+	"github.com/tiongMax/gostocks/internal/freshness"
+	"github.com/tiongMax/gostocks/internal/history"
+)
+
+// Stale modes decide how a stale price is served.
+const (
+	StaleModeFlag    = "flag"    // 200 with "stale": true
+	StaleModeWarning = "warning" // Also a Warning: 110 header
+	StaleModeReject  = "reject"  // 503 for single-symbol lookups
+)
+
+// staleWarning is the HTTP Warning header for stale responses (RFC 7234).
+const staleWarning = `110 - "Response is Stale"`
+
+const (
+	// historyDefaultRange is the range returned when the history request gives no from.
+	historyDefaultRange = 24 * time.Hour
+
+	// maxBatchSymbols caps the number of symbols in one batch price lookup.
+	maxBatchSymbols = 500
 )
 
 // Handler holds the dependencies for HTTP handlers.
 type Handler struct {
-	redis       *RedisClient
-	alertClient *AlertClient
+	redis        *RedisClient
+	alertClient  *AlertClient
+	watchlists   *WatchlistClient
+	portfolios   *PortfolioClient
+	trading      *TradingClient
+	history      *HistoryClient
+	hub          *Hub
+	sla          freshness.SLA
+	staleMode    string
+	sseHeartbeat time.Duration
 }
 
 // NewHandler creates a new Handler with the given dependencies.
-func NewHandler(redis *RedisClient, alertClient *AlertClient) *Handler {
+// Prices older than their SLA are served according to staleMode.
+// sseHeartbeat is the interval between heartbeat comments on idle SSE streams.
+func NewHandler(redis *RedisClient, alertClient *AlertClient, watchlists *WatchlistClient, portfolios *PortfolioClient, trading *TradingClient, history *HistoryClient, hub *Hub, sla freshness.SLA, staleMode string, sseHeartbeat time.Duration) *Handler {
 	return &Handler{
-		redis:       redis,
-		alertClient: alertClient,
+		redis:        redis,
+		alertClient:  alertClient,
+		watchlists:   watchlists,
+		portfolios:   portfolios,
+		trading:      trading,
+		history:      history,
+		hub:          hub,
+		sla:          sla,
+		staleMode:    staleMode,
+		sseHeartbeat: sseHeartbeat,
 	}
 }
 
+// markFreshness sets the age and stale flag of a price served at now.
+func (h *Handler) markFreshness(price *PriceData, now time.Time) {
+	var eventTime time.Time
+	if price.EventTime > 0 {
+		eventTime = time.UnixMilli(price.EventTime)
+		price.AgeMs = now.Sub(eventTime).Milliseconds()
+	}
+	price.Stale = h.sla.Stale(price.Symbol, eventTime, now)
+}
+
 // GetPrice handles GET /price/:symbol
 // Returns the latest price for a stock symbol from Redis.
 func (h *Handler) GetPrice(c *gin.Context) {
 	symbol := strings.ToUpper(c.Param("symbol"))
 	if symbol == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "symbol is required"})
+		apierror.Abort(c, http.StatusBadRequest, "symbol is required")
 		return
 	}
 
 	price, err := h.redis.GetPrice(c.Request.Context(), symbol)
 	if err != nil {
-		slog.Error("Redis lookup failed", "symbol", symbol, "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		slog.Error("Redis lookup failed", "symbol", symbol, "request_id", apierror.RequestIDFrom(c), "error", err)
+		apierror.Abort(c, http.StatusInternalServerError, "price lookup failed")
 		return
 	}
 
 	if price == nil {
 		slog.Debug("Symbol not found in Redis", "symbol", symbol)
-		c.JSON(http.StatusNotFound, gin.H{"error": "symbol not found", "symbol": symbol})
+		apierror.Abort(c, http.StatusNotFound, "symbol not found: "+symbol)
 		return
 	}
 
+	h.markFreshness(price, time.Now())
+	if price.Stale {
+		switch h.staleMode {
+		case StaleModeReject:
+			body := apierror.Envelope(c, apierror.New(http.StatusServiceUnavailable, "price is stale"))
+			body["price"] = price
+			c.JSON(http.StatusServiceUnavailable, body)
+			return
+		case StaleModeWarning:
+			c.Header("Warning", staleWarning)
+		}
+	}
+
 	c.JSON(http.StatusOK, price)
 }
 
+// PricesRequest is the body of POST /prices.
+type PricesRequest struct {
+	Symbols []string `json:"symbols" binding:"required"`
+}
+
+// GetPrices handles GET /prices?symbols=AAPL,MSFT and POST /prices
+// Returns the latest prices of many symbols from Redis in one round-trip,
+// listing the symbols that were not found under "missing" and the ones past
+// their freshness SLA under "stale". Batches are never rejected for staleness;
+// in warning and reject modes a stale batch carries a Warning header.
+func (h *Handler) GetPrices(c *gin.Context) {
+	var symbols []string
+	if c.Request.Method == http.MethodPost {
+		var req PricesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierror.Respond(c, fromBinding(err))
+			return
+		}
+		symbols = parseSymbols(strings.Join(req.Symbols, ","))
+	} else {
+		symbols = parseSymbols(c.Query("symbols"))
+	}
+
+	if len(symbols) == 0 {
+		apierror.Abort(c, http.StatusBadRequest, "symbols is required")
+		return
+	}
+	if len(symbols) > maxBatchSymbols {
+		apierror.Abort(c, http.StatusBadRequest, "too many symbols")
+		return
+	}
+
+	h.writePrices(c, symbols)
+}
+
+// writePrices responds with the latest prices of symbols in the batch format
+// of GetPrices. An empty list yields an empty batch without a Redis lookup.
+func (h *Handler) writePrices(c *gin.Context, symbols []string) {
+	var prices []PriceData
+	var missing []string
+	if len(symbols) > 0 {
+		var err error
+		prices, missing, err = h.redis.GetPrices(c.Request.Context(), symbols)
+		if err != nil {
+			slog.Error("Redis batch lookup failed", "symbols", len(symbols), "request_id", apierror.RequestIDFrom(c), "error", err)
+			apierror.Abort(c, http.StatusInternalServerError, "price lookup failed")
+			return
+		}
+	}
+
+	now := time.Now()
+	stale := []string{}
+	for i := range prices {
+		h.markFreshness(&prices[i], now)
+		if prices[i].Stale {
+			stale = append(stale, prices[i].Symbol)
+		}
+	}
+	if len(stale) > 0 && h.staleMode != StaleModeFlag {
+		c.Header("Warning", staleWarning)
+	}
+
+	if prices == nil {
+		prices = []PriceData{}
+	}
+	if missing == nil {
+		missing = []string{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"prices":  prices,
+		"missing": missing,
+		"stale":   stale,
+		"count":   len(prices),
+	})
+}
+
+// GetHistory handles GET /history/:symbol
+// Query params: from, to (Unix ms or RFC 3339; default the last 24 hours),
+// interval ("tick" for raw ticks, a candle interval such as "5m", or empty to
+// pick the finest interval that fits the response cap), format ("json" or
+// "csv"; an Accept: text/csv header also selects CSV).
+func (h *Handler) GetHistory(c *gin.Context) {
+	symbol := strings.ToUpper(c.Param("symbol"))
+	if symbol == "" {
+		apierror.Abort(c, http.StatusBadRequest, "symbol is required")
+		return
+	}
+
+	fromMs, err := parseTimeParam(c.Query("from"))
+	if err != nil {
+		apierror.Abort(c, http.StatusBadRequest, "invalid from")
+		return
+	}
+	toMs, err := parseTimeParam(c.Query("to"))
+	if err != nil {
+		apierror.Abort(c, http.StatusBadRequest, "invalid to")
+		return
+	}
+
+	to := time.Now()
+	if toMs != 0 {
+		to = time.UnixMilli(toMs)
+	}
+	from := to.Add(-historyDefaultRange)
+	if fromMs != 0 {
+		from = time.UnixMilli(fromMs)
+	}
+	if !from.Before(to) {
+		apierror.Abort(c, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	format := c.Query("format")
+	if format == "" {
+		format = "json"
+		if strings.Contains(c.GetHeader("Accept"), "text/csv") {
+			format = "csv"
+		}
+	}
+	if format != "json" && format != "csv" {
+		apierror.Abort(c, http.StatusBadRequest, "format must be json or csv")
+		return
+	}
+
+	ctx := c.Request.Context()
+	var data *HistoryData
+	switch iv := c.Query("interval"); iv {
+	case "tick":
+		data, err = h.history.GetTicks(ctx, symbol, from, to)
+	case "":
+		// Downsample long ranges so they fit in one response
+		data, err = h.history.GetCandles(ctx, symbol, history.ChooseInterval(from, to, h.history.MaxPoints()), from, to)
+	default:
+		interval, perr := history.ParseInterval(iv)
+		if perr != nil {
+			apierror.Abort(c, http.StatusBadRequest, perr.Error())
+			return
+		}
+		data, err = h.history.GetCandles(ctx, symbol, interval, from, to)
+	}
+	if err != nil {
+		slog.Error("History lookup failed", "symbol", symbol, "request_id", apierror.RequestIDFrom(c), "error", err)
+		apierror.Abort(c, http.StatusInternalServerError, "history lookup failed")
+		return
+	}
+
+	if format == "csv" {
+		writeHistoryCSV(c, data)
+		return
+	}
+	c.JSON(http.StatusOK, data)
+}
+
+// writeHistoryCSV writes ticks or candles as CSV with a header row.
+// Truncation is reported in the X-Next-From header.
+func writeHistoryCSV(c *gin.Context, data *HistoryData) {
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", "attachment; filename=\""+data.Symbol+"-"+data.Interval+".csv\"")
+	if data.Truncated {
+		c.Header("X-Next-From", strconv.FormatInt(data.NextFrom, 10))
+	}
+	c.Status(http.StatusOK)
+
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	w := csv.NewWriter(c.Writer)
+	if data.Interval == "tick" {
+		w.Write([]string{"timestamp", "seq", "price", "volume"})
+		for _, t := range data.Ticks {
+			w.Write([]string{strconv.FormatInt(t.Timestamp, 10), strconv.FormatInt(t.Seq, 10), f(t.Price), f(t.Volume)})
+		}
+	} else {
+		w.Write([]string{"timestamp", "open", "high", "low", "close", "volume", "trades"})
+		for _, cd := range data.Candles {
+			w.Write([]string{strconv.FormatInt(cd.Timestamp, 10), f(cd.Open), f(cd.High), f(cd.Low), f(cd.Close), f(cd.Volume), strconv.FormatInt(cd.Trades, 10)})
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		slog.Warn("Failed to write history CSV", "symbol", data.Symbol, "error", err)
+	}
+}
+
 // CreateAlert handles POST /alerts
-// Creates a new price alert via the Alert Service.
+// Creates a new price or portfolio alert via the Alert Service.
 func (h *Handler) CreateAlert(c *gin.Context) {
 	var req CreateAlertRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		slog.Warn("Invalid CreateAlert payload", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, fromBinding(err))
 		return
 	}
 
-	// Normalize symbol
+	userID, ok := resolveUser(c, req.UserID, false)
+	if !ok {
+		return
+	}
+	req.UserID = userID
+
+	// Normalize symbols
 	req.Symbol = strings.ToUpper(req.Symbol)
 	req.Condition = strings.ToUpper(req.Condition)
+	if req.Portfolio != nil {
+		req.Portfolio.Symbol = strings.ToUpper(req.Portfolio.Symbol)
+	}
+	if req.Action != nil {
+		req.Action.Symbol = strings.ToUpper(req.Action.Symbol)
+	}
 
 	resp, err := h.alertClient.CreateAlert(c.Request.Context(), &req)
 	if err != nil {
-		slog.Error("Failed to create alert", "symbol", req.Symbol, "user_id", req.UserID, "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondGRPC(c, "Failed to create alert", err, "symbol", req.Symbol, "user_id", req.UserID)
 		return
 	}
 
@@ -74,7 +333,7 @@ func (h *Handler) CreateAlert(c *gin.Context) {
 }
 
 // GetAlerts handles GET /alerts
-// Query params: user_id (optional), active_only (optional, default: false)
+// Query params: user_id (optional, admin only; 0 = all users), active_only (optional, default: false)
 func (h *Handler) GetAlerts(c *gin.Context) {
 	// Parse query parameters
 	userIDStr := c.Query("user_id")
@@ -84,18 +343,22 @@ func (h *Handler) GetAlerts(c *gin.Context) {
 	if userIDStr != "" {
 		id, err := strconv.ParseInt(userIDStr, 10, 32)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+			apierror.Abort(c, http.StatusBadRequest, "invalid user_id")
 			return
 		}
 		userID = int32(id)
 	}
 
+	userID, ok := resolveUser(c, userID, true)
+	if !ok {
+		return
+	}
+
 	activeOnly := activeOnlyStr == "true" || activeOnlyStr == "1"
 
 	alerts, err := h.alertClient.GetAlerts(c.Request.Context(), userID, activeOnly)
 	if err != nil {
-		slog.Error("Failed to fetch alerts", "user_id", userID, "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondGRPC(c, "Failed to fetch alerts", err, "user_id", userID)
 		return
 	}
 
@@ -105,6 +368,181 @@ func (h *Handler) GetAlerts(c *gin.Context) {
 	})
 }
 
+// ListAlertTriggers handles GET /alerts/triggers
+// Query params: user_id, alert_id, symbol, from, to (Unix ms or RFC 3339),
+// limit (default 50, max 500), page_token (from a previous response).
+func (h *Handler) ListAlertTriggers(c *gin.Context) {
+	var q TriggerQuery
+
+	for _, p := range []struct {
+		name string
+		dst  *int32
+	}{
+		{"user_id", &q.UserID},
+		{"alert_id", &q.AlertID},
+		{"limit", &q.Limit},
+	} {
+		if v := c.Query(p.name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 32)
+			if err != nil || n < 0 {
+				apierror.Abort(c, http.StatusBadRequest, "invalid "+p.name)
+				return
+			}
+			*p.dst = int32(n)
+		}
+	}
+
+	var err error
+	if q.From, err = parseTimeParam(c.Query("from")); err != nil {
+		apierror.Abort(c, http.StatusBadRequest, "invalid from")
+		return
+	}
+	if q.To, err = parseTimeParam(c.Query("to")); err != nil {
+		apierror.Abort(c, http.StatusBadRequest, "invalid to")
+		return
+	}
+	q.Symbol = strings.ToUpper(c.Query("symbol"))
+	q.PageToken = c.Query("page_token")
+
+	var ok bool
+	if q.UserID, ok = resolveUser(c, q.UserID, true); !ok {
+		return
+	}
+
+	triggers, next, err := h.alertClient.ListAlertTriggers(c.Request.Context(), q)
+	if err != nil {
+		respondGRPC(c, "Failed to fetch alert triggers", err, "user_id", q.UserID)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"triggers":        triggers,
+		"count":           len(triggers),
+		"next_page_token": next,
+	})
+}
+
+// WatchAlerts handles GET /alerts/watch
+// Relays the Alert Service's trigger stream as newline-delimited JSON.
+// Query params: user_id, symbols (comma-separated), cursor (resume point).
+func (h *Handler) WatchAlerts(c *gin.Context) {
+	var userID int32
+	if v := c.Query("user_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			apierror.Abort(c, http.StatusBadRequest, "invalid user_id")
+			return
+		}
+		userID = int32(id)
+	}
+
+	var cursor int64
+	if v := c.Query("cursor"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			apierror.Abort(c, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		cursor = n
+	}
+
+	userID, ok := resolveUser(c, userID, true)
+	if !ok {
+		return
+	}
+
+	symbols := parseSymbols(c.Query("symbols"))
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	enc := json.NewEncoder(c.Writer)
+	err := h.alertClient.WatchAlerts(c.Request.Context(), userID, symbols, cursor, func(event AlertEventData) error {
+		if err := enc.Encode(event); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		slog.Warn("Alert stream ended", "user_id", userID, "error", err)
+	}
+}
+
+// resolveUser returns the user a request acts on. Callers act on their own
+// data; requested may name another user only with the admin scope, in which
+// case 0 means all users if allowAll is set. It responds 403 and returns false
+// when the caller may not act on the requested user.
+func resolveUser(c *gin.Context, requested int32, allowAll bool) (int32, bool) {
+	identity := auth.FromContext(c)
+	if identity == nil {
+		apierror.Abort(c, http.StatusUnauthorized, "credentials required")
+		return 0, false
+	}
+
+	if requested == 0 {
+		if allowAll && identity.IsAdmin() {
+			return 0, true
+		}
+		return identity.UserID, true
+	}
+	if requested != identity.UserID && !identity.IsAdmin() {
+		apierror.Abort(c, http.StatusForbidden, "admin scope required to access other users")
+		return 0, false
+	}
+	return requested, true
+}
+
+// resourceTarget parses the ID of a per-user resource, such as a watchlist,
+// from the route and resolves the owner to match: the caller, or any owner
+// (0) for admins. It responds and returns false on errors.
+func resourceTarget(c *gin.Context, resource string) (int32, int32, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil || id <= 0 {
+		apierror.Abort(c, http.StatusBadRequest, "invalid "+resource+" id")
+		return 0, 0, false
+	}
+
+	userID, ok := resolveUser(c, 0, true)
+	if !ok {
+		return 0, 0, false
+	}
+	return int32(id), userID, true
+}
+
+// parseSymbols splits a comma-separated symbol list, normalizing case and
+// dropping empty and repeated entries.
+func parseSymbols(v string) []string {
+	var symbols []string
+	seen := make(map[string]bool)
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.ToUpper(strings.TrimSpace(s)); s != "" && !seen[s] {
+			seen[s] = true
+			symbols = append(symbols, s)
+		}
+	}
+	return symbols
+}
+
+// parseTimeParam parses a query time given as Unix milliseconds or RFC 3339.
+// An empty value yields 0 (unbounded).
+func parseTimeParam(v string) (int64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return ms, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return 0, err
+	}
+	return t.UnixMilli(), nil
+}
+
 // HealthCheck handles GET /health
 func (h *Handler) HealthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
@@ -0,0 +1,53 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tiongMax/gostocks/internal/auth"
+)
+
+func TestResolveUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	user := &auth.Identity{UserID: 7}
+	admin := &auth.Identity{UserID: 1, Scopes: []string{auth.ScopeAdmin}}
+
+	tests := []struct {
+		name       string
+		identity   *auth.Identity
+		requested  int32
+		allowAll   bool
+		want       int32
+		wantOK     bool
+		wantStatus int
+	}{
+		{"defaults to caller", user, 0, true, 7, true, http.StatusOK},
+		{"own user", user, 7, true, 7, true, http.StatusOK},
+		{"other user forbidden", user, 8, true, 0, false, http.StatusForbidden},
+		{"admin reads other user", admin, 8, true, 8, true, http.StatusOK},
+		{"admin reads all users", admin, 0, true, 0, true, http.StatusOK},
+		{"admin writes as self by default", admin, 0, false, 1, true, http.StatusOK},
+		{"unauthenticated", nil, 7, true, 0, false, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			if tt.identity != nil {
+				auth.SetIdentity(c, tt.identity)
+			}
+
+			got, ok := resolveUser(c, tt.requested, tt.allowAll)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("resolveUser() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+			if !ok && w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
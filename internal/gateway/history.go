@@ -0,0 +1,135 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/history"
+)
+
+// HistoryClient reads stored ticks and candles for the history endpoint.
+type HistoryClient struct {
+	reader    *history.Reader
+	maxPoints int
+}
+
+// NewHistoryClient connects to the time-series store. Responses are capped
+// at maxPoints ticks or candles.
+func NewHistoryClient(connStr string, maxPoints int) (*HistoryClient, error) {
+	reader, err := history.NewReader(connStr)
+	if err != nil {
+		return nil, err
+	}
+	return &HistoryClient{reader: reader, maxPoints: maxPoints}, nil
+}
+
+// TickData represents a single stored tick in the response.
+type TickData struct {
+	Timestamp int64   `json:"timestamp"` // Unix milliseconds
+	Seq       int64   `json:"seq"`
+	Price     float64 `json:"price"`
+	Volume    float64 `json:"volume"`
+}
+
+// CandleData represents a single OHLCV candle in the response.
+type CandleData struct {
+	Timestamp int64   `json:"timestamp"` // Candle start, Unix milliseconds
+	Open      float64 `json:"open"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Close     float64 `json:"close"`
+	Volume    float64 `json:"volume"`
+	Trades    int64   `json:"trades"`
+}
+
+// HistoryData is the response of the history endpoint. When Truncated is set,
+// request again with from=NextFrom for the rest of the range.
+type HistoryData struct {
+	Symbol    string       `json:"symbol"`
+	Interval  string       `json:"interval"` // "tick" or a candle interval such as "5m"
+	From      int64        `json:"from"`
+	To        int64        `json:"to"`
+	Count     int          `json:"count"`
+	Truncated bool         `json:"truncated"`
+	NextFrom  int64        `json:"next_from,omitempty"`
+	Ticks     []TickData   `json:"ticks,omitempty"`
+	Candles   []CandleData `json:"candles,omitempty"`
+}
+
+// MaxPoints returns the response size cap.
+func (c *HistoryClient) MaxPoints() int {
+	return c.maxPoints
+}
+
+// GetTicks returns raw ticks for a symbol in [from, to).
+func (c *HistoryClient) GetTicks(ctx context.Context, symbol string, from, to time.Time) (*HistoryData, error) {
+	// Fetch one extra row to learn whether the range was truncated
+	ticks, err := c.reader.Ticks(ctx, symbol, from, to, c.maxPoints+1)
+	if err != nil {
+		return nil, err
+	}
+
+	data := newHistoryData(symbol, "tick", from, to)
+	if len(ticks) > c.maxPoints {
+		// Ticks sharing the boundary timestamp may be repeated on the next page
+		data.Truncated = true
+		data.NextFrom = ticks[c.maxPoints].Time.UnixMilli()
+		ticks = ticks[:c.maxPoints]
+	}
+
+	data.Ticks = make([]TickData, len(ticks))
+	for i, t := range ticks {
+		data.Ticks[i] = TickData{
+			Timestamp: t.Time.UnixMilli(),
+			Seq:       t.Seq,
+			Price:     t.Price,
+			Volume:    t.Volume,
+		}
+	}
+	data.Count = len(ticks)
+	return data, nil
+}
+
+// GetCandles returns candles of the given interval for a symbol in [from, to).
+func (c *HistoryClient) GetCandles(ctx context.Context, symbol string, interval time.Duration, from, to time.Time) (*HistoryData, error) {
+	candles, err := c.reader.Candles(ctx, symbol, interval, from, to, c.maxPoints+1)
+	if err != nil {
+		return nil, err
+	}
+
+	data := newHistoryData(symbol, history.IntervalName(interval), from, to)
+	if len(candles) > c.maxPoints {
+		data.Truncated = true
+		data.NextFrom = candles[c.maxPoints].Start.UnixMilli()
+		candles = candles[:c.maxPoints]
+	}
+
+	data.Candles = make([]CandleData, len(candles))
+	for i, cd := range candles {
+		data.Candles[i] = CandleData{
+			Timestamp: cd.Start.UnixMilli(),
+			Open:      cd.Open,
+			High:      cd.High,
+			Low:       cd.Low,
+			Close:     cd.Close,
+			Volume:    cd.Volume,
+			Trades:    cd.Trades,
+		}
+	}
+	data.Count = len(candles)
+	return data, nil
+}
+
+func newHistoryData(symbol, interval string, from, to time.Time) *HistoryData {
+	return &HistoryData{
+		Symbol:   symbol,
+		Interval: interval,
+		From:     from.UnixMilli(),
+		To:       to.UnixMilli(),
+	}
+}
+
+// Close closes the database connection.
+func (c *HistoryClient) Close() error {
+	return c.reader.Close()
+}
@@ -0,0 +1,227 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// priceChannelPrefix matches the processor's per-symbol pub/sub channels.
+const priceChannelPrefix = "prices:"
+
+// Hub fans out live price updates from Redis pub/sub to streaming clients.
+// A single pub/sub connection serves every client of the gateway instance,
+// whatever transport (WebSocket, SSE) the client uses.
+type Hub struct {
+	client *redis.Client
+	epoch  string // Distinguishes sequence numbers across gateway restarts
+
+	mu     sync.RWMutex
+	seq    uint64
+	latest map[string]PriceEvent               // symbol -> last update seen
+	subs   map[string]map[*Subscriber]struct{} // symbol -> subscribers
+}
+
+// PriceEvent is a price update stamped with the hub's sequence number.
+type PriceEvent struct {
+	Seq   uint64
+	Price PriceData
+}
+
+// NewHub creates a Hub reading from the given Redis connection.
+func NewHub(r *RedisClient) *Hub {
+	return &Hub{
+		client: r.client,
+		epoch:  strconv.FormatInt(time.Now().UnixNano(), 36),
+		latest: make(map[string]PriceEvent),
+		subs:   make(map[string]map[*Subscriber]struct{}),
+	}
+}
+
+// Run relays price updates until the context is cancelled.
+// The Redis client reconnects and resubscribes on its own after failures.
+func (h *Hub) Run(ctx context.Context) error {
+	pubsub := h.client.PSubscribe(ctx, priceChannelPrefix+"*")
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+	slog.Info("Price hub subscribed", "pattern", priceChannelPrefix+"*")
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			var price PriceData
+			if err := json.Unmarshal([]byte(msg.Payload), &price); err != nil {
+				slog.Warn("Invalid price update", "channel", msg.Channel, "error", err)
+				continue
+			}
+			if price.Symbol == "" {
+				price.Symbol = strings.TrimPrefix(msg.Channel, priceChannelPrefix)
+			}
+			h.publish(price)
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (h *Hub) publish(price PriceData) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.seq++
+	event := PriceEvent{Seq: h.seq, Price: price}
+	h.latest[price.Symbol] = event
+
+	for sub := range h.subs[price.Symbol] {
+		sub.Offer(event)
+	}
+}
+
+// EventID formats a sequence number as a resumable event ID.
+func (h *Hub) EventID(seq uint64) string {
+	return fmt.Sprintf("%s-%d", h.epoch, seq)
+}
+
+// ParseEventID returns the sequence number of an event ID issued by this hub.
+// It reports false for IDs from another gateway instance or an earlier run,
+// whose sequence numbers mean nothing here.
+func (h *Hub) ParseEventID(id string) (uint64, bool) {
+	epoch, seqStr, ok := strings.Cut(id, "-")
+	if !ok || epoch != h.epoch {
+		return 0, false
+	}
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return seq, true
+}
+
+// Replay offers the subscriber the latest update of each symbol newer than
+// afterSeq, and returns the symbols the hub has not seen since it started.
+func (h *Hub) Replay(sub *Subscriber, symbols []string, afterSeq uint64) []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var unseen []string
+	for _, s := range symbols {
+		event, ok := h.latest[s]
+		if !ok {
+			unseen = append(unseen, s)
+			continue
+		}
+		if event.Seq > afterSeq {
+			sub.Offer(event)
+		}
+	}
+	return unseen
+}
+
+// Subscribe adds symbols to a subscriber's interest set.
+func (h *Hub) Subscribe(sub *Subscriber, symbols ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, s := range symbols {
+		if h.subs[s] == nil {
+			h.subs[s] = make(map[*Subscriber]struct{})
+		}
+		h.subs[s][sub] = struct{}{}
+	}
+}
+
+// Unsubscribe removes symbols from a subscriber's interest set.
+func (h *Hub) Unsubscribe(sub *Subscriber, symbols ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, s := range symbols {
+		h.removeLocked(sub, s)
+	}
+}
+
+// Remove drops a subscriber from every symbol. Call it when the client goes away.
+func (h *Hub) Remove(sub *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for s := range h.subs {
+		h.removeLocked(sub, s)
+	}
+}
+
+func (h *Hub) removeLocked(sub *Subscriber, symbol string) {
+	delete(h.subs[symbol], sub)
+	if len(h.subs[symbol]) == 0 {
+		delete(h.subs, symbol)
+	}
+}
+
+// Subscriber is one streaming client's view of the hub. Updates are conflated
+// per symbol: if the client falls behind, only the latest price of each symbol
+// is kept, so its buffer never grows beyond its number of symbols.
+type Subscriber struct {
+	mu      sync.Mutex
+	pending map[string]PriceEvent
+	order   []string // Symbols with pending updates, in arrival order
+	ready   chan struct{}
+}
+
+// NewSubscriber creates an empty Subscriber; register it with Hub.Subscribe.
+func NewSubscriber() *Subscriber {
+	return &Subscriber{
+		pending: make(map[string]PriceEvent),
+		ready:   make(chan struct{}, 1),
+	}
+}
+
+// Offer queues a price update, replacing any unsent update for the same symbol.
+func (s *Subscriber) Offer(event PriceEvent) {
+	symbol := event.Price.Symbol
+
+	s.mu.Lock()
+	if _, ok := s.pending[symbol]; !ok {
+		s.order = append(s.order, symbol)
+	}
+	s.pending[symbol] = event
+	s.mu.Unlock()
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Ready is signalled when updates are waiting to be taken.
+func (s *Subscriber) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Take returns and clears all pending updates, oldest symbol first.
+func (s *Subscriber) Take() []PriceEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := make([]PriceEvent, len(s.order))
+	for i, sym := range s.order {
+		events[i] = s.pending[sym]
+		delete(s.pending, sym)
+	}
+	s.order = s.order[:0]
+	return events
+}
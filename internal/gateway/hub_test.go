@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/tiongMax/gostocks/internal/freshness"
+)
+
+func TestSubscriberConflatesPerSymbol(t *testing.T) {
+	sub := NewSubscriber()
+
+	sub.Offer(PriceEvent{Seq: 1, Price: PriceData{Symbol: "AAPL", Price: 150.00}})
+	sub.Offer(PriceEvent{Seq: 2, Price: PriceData{Symbol: "MSFT", Price: 400.00}})
+	sub.Offer(PriceEvent{Seq: 3, Price: PriceData{Symbol: "AAPL", Price: 151.00}})
+
+	select {
+	case <-sub.Ready():
+	default:
+		t.Fatal("expected subscriber to be ready")
+	}
+
+	events := sub.Take()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 conflated updates, got %d", len(events))
+	}
+	if events[0].Price.Symbol != "AAPL" || events[0].Price.Price != 151.00 || events[0].Seq != 3 {
+		t.Errorf("expected latest AAPL price first, got %+v", events[0])
+	}
+	if events[1].Price.Symbol != "MSFT" {
+		t.Errorf("expected MSFT second, got %+v", events[1])
+	}
+
+	if len(sub.Take()) != 0 {
+		t.Error("expected no pending updates after Take")
+	}
+}
+
+func TestHubDeliversOnlySubscribedSymbols(t *testing.T) {
+	hub := newTestHub()
+	sub := NewSubscriber()
+
+	hub.Subscribe(sub, "AAPL", "MSFT")
+	hub.Unsubscribe(sub, "MSFT")
+
+	hub.publish(PriceData{Symbol: "AAPL", Price: 150.00})
+	hub.publish(PriceData{Symbol: "MSFT", Price: 400.00})
+
+	events := sub.Take()
+	if len(events) != 1 || events[0].Price.Symbol != "AAPL" {
+		t.Fatalf("expected only AAPL, got %+v", events)
+	}
+
+	hub.Remove(sub)
+	if len(hub.subs) != 0 {
+		t.Errorf("expected hub to forget removed subscriber, got %d symbols", len(hub.subs))
+	}
+}
+
+func TestHubReplayAfterEventID(t *testing.T) {
+	hub := newTestHub()
+
+	hub.publish(PriceData{Symbol: "AAPL", Price: 150.00})
+	hub.publish(PriceData{Symbol: "MSFT", Price: 400.00})
+	lastSeen := hub.EventID(2)
+	hub.publish(PriceData{Symbol: "AAPL", Price: 151.00})
+
+	seq, ok := hub.ParseEventID(lastSeen)
+	if !ok || seq != 2 {
+		t.Fatalf("expected to parse own event ID, got %d %v", seq, ok)
+	}
+	if _, ok := hub.ParseEventID("otherepoch-2"); ok {
+		t.Error("expected event ID from another epoch to be rejected")
+	}
+
+	sub := NewSubscriber()
+	unseen := hub.Replay(sub, []string{"AAPL", "MSFT", "TSLA"}, seq)
+	if len(unseen) != 1 || unseen[0] != "TSLA" {
+		t.Errorf("expected TSLA to be unseen, got %v", unseen)
+	}
+
+	events := sub.Take()
+	if len(events) != 1 || events[0].Price.Price != 151.00 {
+		t.Fatalf("expected only the AAPL update after the cursor, got %+v", events)
+	}
+}
+
+func newTestHub() *Hub {
+	return &Hub{
+		epoch:  "test",
+		latest: make(map[string]PriceEvent),
+		subs:   make(map[string]map[*Subscriber]struct{}),
+	}
+}
+
+func TestSnapshotMarksFreshness(t *testing.T) {
+	mr := miniredis.RunT(t)
+	old := time.Now().Add(-time.Hour)
+	mr.HSet("price:AAPL", "price", "150.25", "timestamp", strconv.FormatInt(old.Unix(), 10),
+		"event_time", strconv.FormatInt(old.UnixMilli(), 10))
+	redisClient, err := NewRedisClient(mr.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer redisClient.Close()
+
+	h := NewHandler(redisClient, nil, nil, nil, nil, nil, newTestHub(), freshness.NewSLA(time.Minute, nil), StaleModeFlag, time.Second)
+	sub := NewSubscriber()
+	h.offerSnapshot(context.Background(), sub, []string{"AAPL"})
+
+	events := sub.Take()
+	if len(events) != 1 {
+		t.Fatalf("expected a snapshot of AAPL, got %+v", events)
+	}
+	if price := events[0].Price; !price.Stale || price.AgeMs < time.Hour.Milliseconds() {
+		t.Errorf("expected an hour-old stale snapshot, got age %dms, stale %v", price.AgeMs, price.Stale)
+	}
+}
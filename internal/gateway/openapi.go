@@ -0,0 +1,238 @@
+package gateway
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+	"github.com/tiongMax/gostocks/internal/apierror"
+)
+
+// OpenAPI validation modes. Validation is meant for test and staging
+// environments; it buffers every non-streaming response.
+const (
+	ValidationOff    = "off"    // No validation
+	ValidationWarn   = "warn"   // Log requests and responses that do not match the spec
+	ValidationStrict = "strict" // Reject invalid requests with 400, replace invalid responses with 500
+)
+
+//go:embed api
+var apiFiles embed.FS
+
+func init() {
+	// Lets response validation read the docs page
+	openapi3filter.RegisterBodyDecoder("text/html", openapi3filter.FileBodyDecoder)
+}
+
+// LoadSpec parses and checks the embedded OpenAPI document.
+func LoadSpec() (*openapi3.T, error) {
+	data, err := apiFiles.ReadFile("api/openapi.yaml")
+	if err != nil {
+		return nil, err
+	}
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+	return doc, nil
+}
+
+// ServeSpec returns a handler for GET /openapi.json.
+func ServeSpec(doc *openapi3.T) gin.HandlerFunc {
+	body, err := json.Marshal(doc)
+	return func(c *gin.Context) {
+		if err != nil {
+			apierror.Abort(c, http.StatusInternalServerError, "failed to encode OpenAPI spec")
+			return
+		}
+		c.Data(http.StatusOK, "application/json", body)
+	}
+}
+
+// ServeDocs handles GET /docs, an interactive viewer for /openapi.json.
+func ServeDocs(c *gin.Context) {
+	page, err := apiFiles.ReadFile("api/docs.html")
+	if err != nil {
+		apierror.Abort(c, http.StatusInternalServerError, "docs unavailable")
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+}
+
+// specRoute returns the operation documenting the route gin matched, or
+// nil if the request matched no route or the spec lacks the operation.
+func specRoute(doc *openapi3.T, c *gin.Context) *routers.Route {
+	path := specPath(c.FullPath())
+	if path == "" {
+		return nil
+	}
+	item := doc.Paths.Find(path)
+	if item == nil {
+		return nil
+	}
+	op := item.GetOperation(c.Request.Method)
+	if op == nil {
+		return nil
+	}
+	return &routers.Route{Spec: doc, Path: path, PathItem: item, Method: c.Request.Method, Operation: op}
+}
+
+// specPath converts a gin route path ("/price/:symbol") to OpenAPI form
+// ("/price/{symbol}").
+func specPath(ginPath string) string {
+	parts := strings.Split(ginPath, "/")
+	for i, p := range parts {
+		if strings.HasPrefix(p, ":") || strings.HasPrefix(p, "*") {
+			parts[i] = "{" + p[1:] + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// ValidateOpenAPI checks requests and responses against doc. It must run
+// before any middleware that can respond, so that every response is checked.
+// Streaming operations (marked x-streaming) only have their requests checked.
+func ValidateOpenAPI(doc *openapi3.T, mode string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.FullPath() == "" {
+			// Unknown route; gin answers 404
+			c.Next()
+			return
+		}
+		route := specRoute(doc, c)
+		if route == nil {
+			slog.Error("Route missing from OpenAPI spec", "method", c.Request.Method, "path", c.FullPath())
+			if mode == ValidationStrict {
+				apierror.Abort(c, http.StatusInternalServerError, "route missing from API specification")
+				return
+			}
+			c.Next()
+			return
+		}
+
+		params := make(map[string]string, len(c.Params))
+		for _, p := range c.Params {
+			params[p.Key] = p.Value
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: params,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				MultiError:         true,
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc, // Credentials are checked by auth
+			},
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			slog.Warn("Request does not match OpenAPI spec", "operation", route.Operation.OperationID, "request_id", apierror.RequestIDFrom(c), "error", err)
+			if mode == ValidationStrict {
+				e := apierror.New(http.StatusBadRequest, "request does not match the API specification")
+				e.Details = specViolations(err)
+				apierror.Respond(c, e)
+				return
+			}
+		}
+
+		if _, streaming := route.Operation.Extensions["x-streaming"]; streaming {
+			c.Next()
+			return
+		}
+
+		w := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		err := openapi3filter.ValidateResponse(c.Request.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 w.Status(),
+			Header:                 w.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(w.body.Bytes())),
+			Options:                &openapi3filter.Options{MultiError: true, IncludeResponseStatus: true},
+		})
+		if err != nil {
+			slog.Error("Response does not match OpenAPI spec", "operation", route.Operation.OperationID, "status", w.Status(), "request_id", apierror.RequestIDFrom(c), "error", err)
+			if mode == ValidationStrict {
+				e := apierror.New(http.StatusInternalServerError, "response does not match the API specification")
+				e.Details = specViolations(err)
+				e.RequestID = apierror.RequestIDFrom(c)
+				c.Writer.WriteHeader(e.Status)
+				body, _ := json.Marshal(gin.H{"error": e})
+				c.Writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+				c.Writer.Write(body)
+				return
+			}
+		}
+		c.Writer.Write(w.body.Bytes())
+	}
+}
+
+// bufferedWriter holds the response body until it has been validated. The
+// status and headers go to the underlying writer, which sends them with the
+// first body write.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// Flush is a no-op; the body is sent once validated.
+func (w *bufferedWriter) Flush() {}
+
+// specViolations lists the fields named in a validation error.
+func specViolations(err error) []apierror.FieldViolation {
+	var out []apierror.FieldViolation
+	var walk func(field string, err error)
+	walk = func(field string, err error) {
+		switch e := err.(type) {
+		case openapi3.MultiError:
+			for _, err := range e {
+				walk(field, err)
+			}
+		case *openapi3filter.RequestError:
+			if e.Parameter != nil {
+				field = e.Parameter.Name
+			}
+			if e.Err == nil {
+				out = append(out, apierror.FieldViolation{Field: field, Description: e.Reason})
+				return
+			}
+			walk(field, e.Err)
+		case *openapi3filter.ResponseError:
+			if e.Err == nil {
+				out = append(out, apierror.FieldViolation{Field: field, Description: e.Reason})
+				return
+			}
+			walk(field, e.Err)
+		case *openapi3.SchemaError:
+			if p := e.JSONPointer(); len(p) > 0 {
+				field = strings.Join(p, ".")
+			}
+			out = append(out, apierror.FieldViolation{Field: field, Description: e.Reason})
+		default:
+			out = append(out, apierror.FieldViolation{Field: field, Description: err.Error()})
+		}
+	}
+	walk("", err)
+	return out
+}
@@ -0,0 +1,179 @@
+package gateway
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tiongMax/gostocks/internal/apierror"
+)
+
+// PlaceOrder handles POST /orders
+// Places a paper order for the caller. Placing the same client_order_id
+// again returns the first order.
+func (h *Handler) PlaceOrder(c *gin.Context) {
+	var req PlaceOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, fromBinding(err))
+		return
+	}
+
+	userID, ok := resolveUser(c, req.UserID, false)
+	if !ok {
+		return
+	}
+	req.UserID = userID
+	req.Symbol = strings.ToUpper(req.Symbol)
+
+	order, err := h.trading.PlaceOrder(c.Request.Context(), &req)
+	if err != nil {
+		respondGRPC(c, "Failed to place order", err, "user_id", req.UserID, "symbol", req.Symbol)
+		return
+	}
+	c.JSON(http.StatusCreated, order)
+}
+
+// ListOrders handles GET /orders
+// Query params: user_id (optional, admin only; 0 = all users), status, symbol,
+// limit (default 100, at most 500).
+func (h *Handler) ListOrders(c *gin.Context) {
+	var q OrderQuery
+	if v := c.Query("user_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			apierror.Abort(c, http.StatusBadRequest, "invalid user_id")
+			return
+		}
+		q.UserID = int32(id)
+	}
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n <= 0 {
+			apierror.Abort(c, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		q.Limit = int32(n)
+	}
+	q.Status = strings.ToUpper(c.Query("status"))
+	switch q.Status {
+	case "", "OPEN", "FILLED", "CANCELLED", "REJECTED":
+	default:
+		apierror.Abort(c, http.StatusBadRequest, "invalid status")
+		return
+	}
+	q.Symbol = strings.ToUpper(c.Query("symbol"))
+
+	userID, ok := resolveUser(c, q.UserID, true)
+	if !ok {
+		return
+	}
+	q.UserID = userID
+
+	orders, err := h.trading.ListOrders(c.Request.Context(), q)
+	if err != nil {
+		respondGRPC(c, "Failed to fetch orders", err, "user_id", userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"orders": orders,
+		"count":  len(orders),
+	})
+}
+
+// GetOrder handles GET /orders/:id
+func (h *Handler) GetOrder(c *gin.Context) {
+	id, userID, ok := orderTarget(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.trading.GetOrder(c.Request.Context(), id, userID)
+	if err != nil {
+		respondGRPC(c, "Failed to fetch order", err, "order_id", id)
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+// CancelOrder handles DELETE /orders/:id
+// Cancels an open order and returns it.
+func (h *Handler) CancelOrder(c *gin.Context) {
+	id, userID, ok := orderTarget(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.trading.CancelOrder(c.Request.Context(), id, userID)
+	if err != nil {
+		respondGRPC(c, "Failed to cancel order", err, "order_id", id)
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+// GetAccount handles GET /account
+// Query params: user_id (optional, admin only).
+func (h *Handler) GetAccount(c *gin.Context) {
+	var userID int32
+	if v := c.Query("user_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			apierror.Abort(c, http.StatusBadRequest, "invalid user_id")
+			return
+		}
+		userID = int32(id)
+	}
+
+	userID, ok := resolveUser(c, userID, false)
+	if !ok {
+		return
+	}
+
+	account, err := h.trading.GetAccount(c.Request.Context(), userID)
+	if err != nil {
+		respondGRPC(c, "Failed to fetch account", err, "user_id", userID)
+		return
+	}
+	c.JSON(http.StatusOK, account)
+}
+
+// ResetAccount handles POST /account/reset
+// Cancels open orders, drops positions and restores the starting cash.
+func (h *Handler) ResetAccount(c *gin.Context) {
+	var req ResetAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, fromBinding(err))
+		return
+	}
+
+	userID, ok := resolveUser(c, req.UserID, false)
+	if !ok {
+		return
+	}
+	req.UserID = userID
+
+	account, err := h.trading.ResetAccount(c.Request.Context(), &req)
+	if err != nil {
+		respondGRPC(c, "Failed to reset account", err, "user_id", userID)
+		return
+	}
+	c.JSON(http.StatusOK, account)
+}
+
+// orderTarget parses the order ID from the route and resolves the owner to
+// match, like resourceTarget. It responds and returns false on errors.
+func orderTarget(c *gin.Context) (int64, int32, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		apierror.Abort(c, http.StatusBadRequest, "invalid order id")
+		return 0, 0, false
+	}
+
+	userID, ok := resolveUser(c, 0, true)
+	if !ok {
+		return 0, 0, false
+	}
+	return id, userID, true
+}
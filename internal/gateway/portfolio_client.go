@@ -0,0 +1,299 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/tiongMax/gostocks/proto/portfolio"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// PortfolioClient wraps the gRPC connection to the Portfolio Service.
+type PortfolioClient struct {
+	conn   *grpc.ClientConn
+	client pb.PortfolioServiceClient
+}
+
+// NewPortfolioClient creates a new gRPC client connection to the Portfolio
+// Service.
+func NewPortfolioClient(addr string) (*PortfolioClient, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Portfolio Service: %w", err)
+	}
+
+	return &PortfolioClient{
+		conn:   conn,
+		client: pb.NewPortfolioServiceClient(conn),
+	}, nil
+}
+
+// PortfolioData represents a single portfolio in responses.
+type PortfolioData struct {
+	ID         int32  `json:"id"`
+	UserID     int32  `json:"user_id"`
+	Name       string `json:"name"`
+	CostMethod string `json:"cost_method"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+// CreatePortfolioRequest is the body of POST /portfolios.
+type CreatePortfolioRequest struct {
+	UserID     int32  `json:"user_id,omitempty" binding:"gte=0"` // Defaults to the caller; other users need the admin scope
+	Name       string `json:"name" binding:"required"`
+	CostMethod string `json:"cost_method,omitempty" binding:"omitempty,oneof=FIFO LIFO AVERAGE"` // Default FIFO
+}
+
+// TransactionRequest is the body of POST /portfolios/:id/transactions.
+type TransactionRequest struct {
+	Symbol     string  `json:"symbol" binding:"required"`
+	Side       string  `json:"side" binding:"required,oneof=BUY SELL"`
+	Quantity   float64 `json:"quantity" binding:"required,gt=0"`
+	Price      float64 `json:"price" binding:"required,gt=0"`
+	Fee        float64 `json:"fee,omitempty" binding:"gte=0"`
+	ExecutedAt int64   `json:"executed_at,omitempty" binding:"gte=0"` // Unix ms (0 = now)
+}
+
+// TransactionData represents a single transaction in responses.
+type TransactionData struct {
+	ID          int64   `json:"id"`
+	PortfolioID int32   `json:"portfolio_id"`
+	Symbol      string  `json:"symbol"`
+	Side        string  `json:"side"`
+	Quantity    float64 `json:"quantity"`
+	Price       float64 `json:"price"`
+	Fee         float64 `json:"fee"`
+	ExecutedAt  int64   `json:"executed_at"`
+	CreatedAt   int64   `json:"created_at"`
+}
+
+// LotData is an open lot of a position.
+type LotData struct {
+	Quantity float64 `json:"quantity"`
+	Price    float64 `json:"price"`
+	OpenedAt int64   `json:"opened_at"`
+}
+
+// PositionData is the valuation of one symbol.
+type PositionData struct {
+	Symbol        string    `json:"symbol"`
+	Quantity      float64   `json:"quantity"`
+	AverageCost   float64   `json:"average_cost"`
+	CostBasis     float64   `json:"cost_basis"`
+	LastPrice     float64   `json:"last_price"`
+	PriceTime     int64     `json:"price_time"`
+	MarketValue   float64   `json:"market_value"`
+	UnrealizedPnL float64   `json:"unrealized_pnl"`
+	RealizedPnL   float64   `json:"realized_pnl"`
+	DayChange     float64   `json:"day_change"`
+	Weight        float64   `json:"weight"`
+	Priced        bool      `json:"priced"`
+	Lots          []LotData `json:"lots"`
+}
+
+// ValuationData is a point-in-time valuation of a portfolio.
+type ValuationData struct {
+	PortfolioID      int32          `json:"portfolio_id"`
+	CostMethod       string         `json:"cost_method"`
+	Positions        []PositionData `json:"positions"`
+	MarketValue      float64        `json:"market_value"`
+	CostBasis        float64        `json:"cost_basis"`
+	UnrealizedPnL    float64        `json:"unrealized_pnl"`
+	RealizedPnL      float64        `json:"realized_pnl"`
+	DayChange        float64        `json:"day_change"`
+	DayChangePercent float64        `json:"day_change_percent"`
+	Unpriced         []string       `json:"unpriced"`
+	Timestamp        int64          `json:"timestamp"`
+}
+
+// CreatePortfolio creates a portfolio via the Portfolio Service.
+func (p *PortfolioClient) CreatePortfolio(ctx context.Context, req *CreatePortfolioRequest) (*PortfolioData, error) {
+	resp, err := p.client.CreatePortfolio(ctx, &pb.CreatePortfolioRequest{
+		UserId:     req.UserID,
+		Name:       req.Name,
+		CostMethod: pb.CostMethod(pb.CostMethod_value[req.CostMethod]),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return portfolioFromProto(resp), nil
+}
+
+// GetPortfolio retrieves a portfolio. A userID of 0 matches any owner.
+func (p *PortfolioClient) GetPortfolio(ctx context.Context, id, userID int32) (*PortfolioData, error) {
+	resp, err := p.client.GetPortfolio(ctx, &pb.GetPortfolioRequest{Id: id, UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return portfolioFromProto(resp), nil
+}
+
+// ListPortfolios retrieves a user's portfolios, or every user's for user 0.
+func (p *PortfolioClient) ListPortfolios(ctx context.Context, userID int32) ([]PortfolioData, error) {
+	resp, err := p.client.ListPortfolios(ctx, &pb.ListPortfoliosRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	portfolios := make([]PortfolioData, len(resp.Portfolios))
+	for i, pf := range resp.Portfolios {
+		portfolios[i] = *portfolioFromProto(pf)
+	}
+	return portfolios, nil
+}
+
+// DeletePortfolio deletes a portfolio and its transactions.
+func (p *PortfolioClient) DeletePortfolio(ctx context.Context, id, userID int32) error {
+	_, err := p.client.DeletePortfolio(ctx, &pb.DeletePortfolioRequest{Id: id, UserId: userID})
+	return err
+}
+
+// RecordTransaction adds a buy or sell to a portfolio.
+func (p *PortfolioClient) RecordTransaction(ctx context.Context, portfolioID, userID int32, req *TransactionRequest) (*TransactionData, error) {
+	resp, err := p.client.RecordTransaction(ctx, &pb.RecordTransactionRequest{
+		PortfolioId: portfolioID,
+		UserId:      userID,
+		Symbol:      req.Symbol,
+		Side:        pb.Side(pb.Side_value[req.Side]),
+		Quantity:    req.Quantity,
+		Price:       req.Price,
+		Fee:         req.Fee,
+		ExecutedAt:  req.ExecutedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	t := transactionFromProto(resp)
+	return &t, nil
+}
+
+// ListTransactions retrieves a portfolio's transactions in execution order.
+func (p *PortfolioClient) ListTransactions(ctx context.Context, portfolioID, userID int32, symbol string) ([]TransactionData, error) {
+	resp, err := p.client.ListTransactions(ctx, &pb.ListTransactionsRequest{
+		PortfolioId: portfolioID,
+		UserId:      userID,
+		Symbol:      symbol,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]TransactionData, len(resp.Transactions))
+	for i, t := range resp.Transactions {
+		txs[i] = transactionFromProto(t)
+	}
+	return txs, nil
+}
+
+// GetValuation values a portfolio at the latest prices.
+func (p *PortfolioClient) GetValuation(ctx context.Context, portfolioID, userID int32) (*ValuationData, error) {
+	resp, err := p.client.GetValuation(ctx, &pb.GetValuationRequest{PortfolioId: portfolioID, UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return valuationFromProto(resp), nil
+}
+
+// WatchPortfolio opens a WatchPortfolio stream and calls fn for every
+// valuation until the context is cancelled, the stream fails, or fn returns
+// an error.
+func (p *PortfolioClient) WatchPortfolio(ctx context.Context, portfolioID, userID int32, fn func(*ValuationData) error) error {
+	stream, err := p.client.WatchPortfolio(ctx, &pb.GetValuationRequest{PortfolioId: portfolioID, UserId: userID})
+	if err != nil {
+		return err
+	}
+
+	for {
+		v, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err := fn(valuationFromProto(v)); err != nil {
+			return err
+		}
+	}
+}
+
+// portfolioFromProto converts a proto portfolio to its JSON form.
+func portfolioFromProto(p *pb.Portfolio) *PortfolioData {
+	return &PortfolioData{
+		ID:         p.Id,
+		UserID:     p.UserId,
+		Name:       p.Name,
+		CostMethod: p.CostMethod.String(),
+		CreatedAt:  p.CreatedAt,
+	}
+}
+
+// transactionFromProto converts a proto transaction to its JSON form.
+func transactionFromProto(t *pb.Transaction) TransactionData {
+	return TransactionData{
+		ID:          t.Id,
+		PortfolioID: t.PortfolioId,
+		Symbol:      t.Symbol,
+		Side:        t.Side.String(),
+		Quantity:    t.Quantity,
+		Price:       t.Price,
+		Fee:         t.Fee,
+		ExecutedAt:  t.ExecutedAt,
+		CreatedAt:   t.CreatedAt,
+	}
+}
+
+// valuationFromProto converts a proto valuation to its JSON form.
+func valuationFromProto(v *pb.Valuation) *ValuationData {
+	out := &ValuationData{
+		PortfolioID:      v.PortfolioId,
+		CostMethod:       v.CostMethod.String(),
+		Positions:        make([]PositionData, len(v.Positions)),
+		MarketValue:      v.MarketValue,
+		CostBasis:        v.CostBasis,
+		UnrealizedPnL:    v.UnrealizedPnl,
+		RealizedPnL:      v.RealizedPnl,
+		DayChange:        v.DayChange,
+		DayChangePercent: v.DayChangePercent,
+		Unpriced:         v.Unpriced,
+		Timestamp:        v.Timestamp,
+	}
+	if out.Unpriced == nil {
+		out.Unpriced = []string{}
+	}
+	for i, pos := range v.Positions {
+		lots := make([]LotData, len(pos.Lots))
+		for j, lot := range pos.Lots {
+			lots[j] = LotData{Quantity: lot.Quantity, Price: lot.Price, OpenedAt: lot.OpenedAt}
+		}
+		out.Positions[i] = PositionData{
+			Symbol:        pos.Symbol,
+			Quantity:      pos.Quantity,
+			AverageCost:   pos.AverageCost,
+			CostBasis:     pos.CostBasis,
+			LastPrice:     pos.LastPrice,
+			PriceTime:     pos.PriceTime,
+			MarketValue:   pos.MarketValue,
+			UnrealizedPnL: pos.UnrealizedPnl,
+			RealizedPnL:   pos.RealizedPnl,
+			DayChange:     pos.DayChange,
+			Weight:        pos.Weight,
+			Priced:        pos.Priced,
+			Lots:          lots,
+		}
+	}
+	return out
+}
+
+// Close closes the gRPC connection.
+func (p *PortfolioClient) Close() error {
+	return p.conn.Close()
+}
@@ -0,0 +1,212 @@
+package gateway
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tiongMax/gostocks/internal/apierror"
+)
+
+// CreatePortfolio handles POST /portfolios
+// Creates an empty portfolio for the caller.
+func (h *Handler) CreatePortfolio(c *gin.Context) {
+	var req CreatePortfolioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, fromBinding(err))
+		return
+	}
+
+	userID, ok := resolveUser(c, req.UserID, false)
+	if !ok {
+		return
+	}
+	req.UserID = userID
+
+	portfolio, err := h.portfolios.CreatePortfolio(c.Request.Context(), &req)
+	if err != nil {
+		respondGRPC(c, "Failed to create portfolio", err, "user_id", req.UserID)
+		return
+	}
+	c.JSON(http.StatusCreated, portfolio)
+}
+
+// ListPortfolios handles GET /portfolios
+// Query params: user_id (optional, admin only; 0 = all users).
+func (h *Handler) ListPortfolios(c *gin.Context) {
+	var userID int32
+	if v := c.Query("user_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			apierror.Abort(c, http.StatusBadRequest, "invalid user_id")
+			return
+		}
+		userID = int32(id)
+	}
+
+	userID, ok := resolveUser(c, userID, true)
+	if !ok {
+		return
+	}
+
+	portfolios, err := h.portfolios.ListPortfolios(c.Request.Context(), userID)
+	if err != nil {
+		respondGRPC(c, "Failed to fetch portfolios", err, "user_id", userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"portfolios": portfolios,
+		"count":      len(portfolios),
+	})
+}
+
+// GetPortfolio handles GET /portfolios/:id
+func (h *Handler) GetPortfolio(c *gin.Context) {
+	id, userID, ok := resourceTarget(c, "portfolio")
+	if !ok {
+		return
+	}
+
+	portfolio, err := h.portfolios.GetPortfolio(c.Request.Context(), id, userID)
+	if err != nil {
+		respondGRPC(c, "Failed to fetch portfolio", err, "portfolio_id", id)
+		return
+	}
+	c.JSON(http.StatusOK, portfolio)
+}
+
+// DeletePortfolio handles DELETE /portfolios/:id
+func (h *Handler) DeletePortfolio(c *gin.Context) {
+	id, userID, ok := resourceTarget(c, "portfolio")
+	if !ok {
+		return
+	}
+
+	if err := h.portfolios.DeletePortfolio(c.Request.Context(), id, userID); err != nil {
+		respondGRPC(c, "Failed to delete portfolio", err, "portfolio_id", id)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RecordTransaction handles POST /portfolios/:id/transactions
+// Records a buy or sell. A sell may not exceed the quantity held.
+func (h *Handler) RecordTransaction(c *gin.Context) {
+	id, userID, ok := resourceTarget(c, "portfolio")
+	if !ok {
+		return
+	}
+
+	var req TransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, fromBinding(err))
+		return
+	}
+	req.Side = strings.ToUpper(req.Side)
+
+	tx, err := h.portfolios.RecordTransaction(c.Request.Context(), id, userID, &req)
+	if err != nil {
+		respondGRPC(c, "Failed to record transaction", err, "portfolio_id", id, "symbol", req.Symbol)
+		return
+	}
+	c.JSON(http.StatusCreated, tx)
+}
+
+// ListTransactions handles GET /portfolios/:id/transactions
+// Query params: symbol (optional).
+func (h *Handler) ListTransactions(c *gin.Context) {
+	id, userID, ok := resourceTarget(c, "portfolio")
+	if !ok {
+		return
+	}
+
+	txs, err := h.portfolios.ListTransactions(c.Request.Context(), id, userID, strings.ToUpper(c.Query("symbol")))
+	if err != nil {
+		respondGRPC(c, "Failed to fetch transactions", err, "portfolio_id", id)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transactions": txs,
+		"count":        len(txs),
+	})
+}
+
+// GetValuation handles GET /portfolios/:id/valuation
+// Returns positions with market value and P&L at the latest prices.
+func (h *Handler) GetValuation(c *gin.Context) {
+	id, userID, ok := resourceTarget(c, "portfolio")
+	if !ok {
+		return
+	}
+
+	valuation, err := h.portfolios.GetValuation(c.Request.Context(), id, userID)
+	if err != nil {
+		respondGRPC(c, "Failed to value portfolio", err, "portfolio_id", id)
+		return
+	}
+	c.JSON(http.StatusOK, valuation)
+}
+
+// StreamPortfolio handles GET /stream/portfolios/:id
+// Relays the Portfolio Service's valuations as "valuation" Server-Sent Events.
+// Every event is a complete valuation, so reconnecting needs no resume point.
+func (h *Handler) StreamPortfolio(c *gin.Context) {
+	id, userID, ok := resourceTarget(c, "portfolio")
+	if !ok {
+		return
+	}
+
+	// Check access before the stream starts, so errors keep their status
+	if _, err := h.portfolios.GetPortfolio(c.Request.Context(), id, userID); err != nil {
+		respondGRPC(c, "Failed to fetch portfolio", err, "portfolio_id", id)
+		return
+	}
+
+	ctx := c.Request.Context()
+	valuations := make(chan *ValuationData)
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- h.portfolios.WatchPortfolio(ctx, id, userID, func(v *ValuationData) error {
+			select {
+			case valuations <- v:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	startSSE(c)
+
+	heartbeat := time.NewTicker(h.sseHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case v := <-valuations:
+			if err := writeSSE(c, "", "valuation", v); err != nil {
+				return
+			}
+
+		case <-heartbeat.C:
+			if err := writeSSEComment(c.Writer, "heartbeat"); err != nil {
+				return
+			}
+
+		case err := <-streamErr:
+			if err != nil {
+				slog.Warn("Portfolio stream ended", "portfolio_id", id, "error", err)
+				writeSSE(c, "", "error", gin.H{"error": "portfolio stream unavailable"})
+			}
+			return
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -3,7 +3,9 @@ package gateway
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -38,39 +40,157 @@ type PriceData struct {
 	Symbol    string  `json:"symbol"`
 	Price     float64 `json:"price"`
 	Timestamp int64   `json:"timestamp"`
+	Volume    float64 `json:"volume"`     // Volume of the latest trade
+	Open      float64 `json:"open"`       // Trading day's first price
+	High      float64 `json:"high"`       // Trading day's high
+	Low       float64 `json:"low"`        // Trading day's low
+	DayVolume float64 `json:"day_volume"` // Trading day's total volume
+
+	EventTime  int64 `json:"event_time"`  // When the trade happened, Unix ms (0 = unknown)
+	IngestTime int64 `json:"ingest_time"` // When the tick entered the pipeline, Unix ms (0 = unknown)
+	AgeMs      int64 `json:"age_ms"`      // Age of the price when served
+	Stale      bool  `json:"stale"`       // Older than the symbol's freshness SLA
 }
 
+// priceFields are the fields of a symbol's price hash, in PriceData order.
+var priceFields = []string{"price", "timestamp", "volume", "open", "high", "low", "day_volume", "event_time", "ingest_time"}
+
 // GetPrice retrieves the latest price for a symbol from Redis.
 // Returns nil if the symbol is not found.
 func (r *RedisClient) GetPrice(ctx context.Context, symbol string) (*PriceData, error) {
-	key := fmt.Sprintf("price:%s", symbol)
+	prices, _, err := r.GetPrices(ctx, []string{symbol})
+	if err != nil || len(prices) == 0 {
+		return nil, err
+	}
+	return &prices[0], nil
+}
 
-	// Get price value
-	priceStr, err := r.client.Get(ctx, key).Result()
-	if err == redis.Nil {
-		return nil, nil // Not found
+// GetPrices retrieves the latest prices of many symbols in one round-trip.
+// Symbols not in Redis are returned in missing, and so are symbols whose
+// price cannot be parsed, which are logged. Symbols the processor has not
+// written since the price hash replaced the old string keys are read from
+// those in a second round-trip. Only a failure of Redis itself is an error.
+func (r *RedisClient) GetPrices(ctx context.Context, symbols []string) (prices []PriceData, missing []string, err error) {
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.SliceCmd, len(symbols))
+	for i, s := range symbols {
+		cmds[i] = pipe.HMGet(ctx, "price:"+s, priceFields...)
 	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to get price: %w", err)
+	// Errors are checked per symbol: one old key fails only its own command
+	pipe.Exec(ctx)
+
+	var legacy []string
+	for i, cmd := range cmds {
+		if err := cmd.Err(); err != nil {
+			if isWrongType(err) {
+				legacy = append(legacy, symbols[i])
+				continue
+			}
+			return nil, nil, fmt.Errorf("failed to get prices: %w", err)
+		}
+		price, err := parsePrice(symbols[i], cmd.Val())
+		if err != nil {
+			slog.Warn("Unreadable price in Redis", "symbol", symbols[i], "error", err)
+		}
+		if price == nil {
+			missing = append(missing, symbols[i])
+			continue
+		}
+		prices = append(prices, *price)
+	}
+	if len(legacy) == 0 {
+		return prices, missing, nil
 	}
 
-	price, err := strconv.ParseFloat(priceStr, 64)
+	old, oldMissing, err := r.getLegacyPrices(ctx, legacy)
 	if err != nil {
-		return nil, fmt.Errorf("invalid price format: %w", err)
+		return nil, nil, err
 	}
+	return append(prices, old...), append(missing, oldMissing...), nil
+}
 
-	// Try to get timestamp (optional)
-	timestampKey := fmt.Sprintf("price:%s:timestamp", symbol)
-	timestamp := time.Now().Unix()
-	if ts, err := r.client.Get(ctx, timestampKey).Int64(); err == nil {
-		timestamp = ts
+// getLegacyPrices reads prices stored in the old layout: the price in the
+// string key "price:<symbol>" and its Unix timestamp in
+// "price:<symbol>:timestamp". The processor replaces them with the hash on
+// the symbol's next tick.
+func (r *RedisClient) getLegacyPrices(ctx context.Context, symbols []string) (prices []PriceData, missing []string, err error) {
+	pipe := r.client.Pipeline()
+	cmds := make([][2]*redis.StringCmd, len(symbols))
+	for i, s := range symbols {
+		cmds[i] = [2]*redis.StringCmd{pipe.Get(ctx, "price:"+s), pipe.Get(ctx, "price:"+s+":timestamp")}
 	}
+	pipe.Exec(ctx)
 
-	return &PriceData{
-		Symbol:    symbol,
-		Price:     price,
-		Timestamp: timestamp,
-	}, nil
+	for i, cmd := range cmds {
+		vals := make([]interface{}, len(priceFields))
+		for j, c := range cmd {
+			switch err := c.Err(); {
+			case err == nil:
+				vals[j] = c.Val() // price, then timestamp, as in priceFields
+			case err == redis.Nil:
+			case isWrongType(err):
+				// Converted to a hash meanwhile; the next lookup finds it
+			default:
+				return nil, nil, fmt.Errorf("failed to get prices: %w", err)
+			}
+		}
+		price, err := parsePrice(symbols[i], vals)
+		if err != nil {
+			slog.Warn("Unreadable price in Redis", "symbol", symbols[i], "error", err)
+		}
+		if price == nil {
+			missing = append(missing, symbols[i])
+			continue
+		}
+		prices = append(prices, *price)
+	}
+	return prices, missing, nil
+}
+
+// isWrongType reports whether err is Redis refusing a command for the type
+// of its key.
+func isWrongType(err error) bool {
+	return strings.HasPrefix(err.Error(), "WRONGTYPE")
+}
+
+// parsePrice builds PriceData from the values of priceFields.
+// Returns nil if the hash has no price, or with an error if it cannot be
+// parsed.
+func parsePrice(symbol string, vals []interface{}) (*PriceData, error) {
+	if len(vals) != len(priceFields) || vals[0] == nil {
+		return nil, nil // Not found
+	}
+
+	nums := make([]float64, len(vals))
+	for i, v := range vals {
+		str, ok := v.(string)
+		if !ok {
+			continue // Field not set
+		}
+		n, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s for %s: %w", priceFields[i], symbol, err)
+		}
+		nums[i] = n
+	}
+
+	price := &PriceData{
+		Symbol:     symbol,
+		Price:      nums[0],
+		Timestamp:  int64(nums[1]),
+		Volume:     nums[2],
+		Open:       nums[3],
+		High:       nums[4],
+		Low:        nums[5],
+		DayVolume:  nums[6],
+		EventTime:  int64(nums[7]),
+		IngestTime: int64(nums[8]),
+	}
+	if price.EventTime == 0 {
+		// Written before event times were stored
+		price.EventTime = price.Timestamp * 1000
+	}
+	return price, nil
 }
 
 // Close closes the Redis connection.
@@ -0,0 +1,94 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		vals    []interface{}
+		want    *PriceData
+		wantErr bool
+	}{
+		{
+			name: "full hash",
+			vals: []interface{}{"150.25", "1767225600", "100", "148", "151.5", "147.75", "123400", "1767225600123", "1767225600456"},
+			want: &PriceData{Symbol: "AAPL", Price: 150.25, Timestamp: 1767225600, Volume: 100, Open: 148, High: 151.5, Low: 147.75, DayVolume: 123400, EventTime: 1767225600123, IngestTime: 1767225600456},
+		},
+		{
+			name: "missing day stats and event time",
+			vals: []interface{}{"150.25", "1767225600", nil, nil, nil, nil, nil, nil, nil},
+			want: &PriceData{Symbol: "AAPL", Price: 150.25, Timestamp: 1767225600, EventTime: 1767225600000},
+		},
+		{
+			name: "missing timestamp is not invented",
+			vals: []interface{}{"150.25", nil, nil, nil, nil, nil, nil, nil, nil},
+			want: &PriceData{Symbol: "AAPL", Price: 150.25},
+		},
+		{
+			name: "not found",
+			vals: []interface{}{nil, nil, nil, nil, nil, nil, nil, nil, nil},
+		},
+		{
+			name:    "malformed price",
+			vals:    []interface{}{"abc", "1767225600", nil, nil, nil, nil, nil, nil, nil},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePrice("AAPL", tt.vals)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePrice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("parsePrice() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.want {
+				t.Errorf("parsePrice() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPricesSkipsUnreadableSymbols(t *testing.T) {
+	mr := miniredis.RunT(t)
+	mr.HSet("price:AAPL", "price", "150.25", "timestamp", "1767225600")
+	// Written by the processor before prices were stored as hashes
+	mr.Set("price:MSFT", "400.5")
+	mr.Set("price:MSFT:timestamp", "1767225600")
+	mr.HSet("price:BAD", "price", "not-a-number")
+	redisClient, err := NewRedisClient(mr.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer redisClient.Close()
+
+	prices, missing, err := redisClient.GetPrices(context.Background(), []string{"AAPL", "MSFT", "BAD", "NONE"})
+	if err != nil {
+		t.Fatalf("GetPrices() error = %v", err)
+	}
+	want := []PriceData{
+		{Symbol: "AAPL", Price: 150.25, Timestamp: 1767225600, EventTime: 1767225600000},
+		{Symbol: "MSFT", Price: 400.5, Timestamp: 1767225600, EventTime: 1767225600000},
+	}
+	if len(prices) != len(want) || prices[0] != want[0] || prices[1] != want[1] {
+		t.Errorf("GetPrices() prices = %+v, want %+v", prices, want)
+	}
+	if len(missing) != 2 || missing[0] != "BAD" || missing[1] != "NONE" {
+		t.Errorf("GetPrices() missing = %v, want [BAD NONE]", missing)
+	}
+
+	price, err := redisClient.GetPrice(context.Background(), "MSFT")
+	if err != nil || price == nil || *price != want[1] {
+		t.Errorf("GetPrice(MSFT) = %+v, %v, want %+v", price, err, want[1])
+	}
+}
@@ -0,0 +1,77 @@
+package gateway
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+// RouteMiddleware guards the API routes. IPLimit and Auth run on every route
+// except /health and the API docs; Read, Write and Stream are the per-caller
+// rate limits of each route class.
+type RouteMiddleware struct {
+	IPLimit gin.HandlerFunc
+	Auth    gin.HandlerFunc
+	Read    gin.HandlerFunc
+	Write   gin.HandlerFunc
+	Stream  gin.HandlerFunc
+}
+
+// RegisterRoutes registers every gateway route. Each one must be described
+// in the OpenAPI document; the contract tests check that they match.
+func RegisterRoutes(router *gin.Engine, h *Handler, spec *openapi3.T, m RouteMiddleware) {
+	// Health check and API documentation
+	router.GET("/health", h.HealthCheck)
+	router.GET("/openapi.json", ServeSpec(spec))
+	router.GET("/docs", ServeDocs)
+
+	// Everything else requires an API key or JWT. The per-IP limit runs
+	// first so that credential guessing is throttled too.
+	api := router.Group("/", m.IPLimit, m.Auth)
+
+	// Day 11: Price endpoint (Redis lookup)
+	api.GET("/price/:symbol", m.Read, h.GetPrice)
+	api.GET("/prices", m.Read, h.GetPrices)
+	api.POST("/prices", m.Read, h.GetPrices)
+
+	// Price history (ticks and candles from Postgres)
+	api.GET("/history/:symbol", m.Read, h.GetHistory)
+
+	// Day 12: Alert endpoints (gRPC to Alert Service)
+	api.POST("/alerts", m.Write, h.CreateAlert)
+	api.GET("/alerts", m.Read, h.GetAlerts)
+	api.GET("/alerts/triggers", m.Read, h.ListAlertTriggers)
+	api.GET("/alerts/watch", m.Stream, h.WatchAlerts)
+
+	// Watchlists (gRPC to Alert Service)
+	api.POST("/watchlists", m.Write, h.CreateWatchlist)
+	api.GET("/watchlists", m.Read, h.ListWatchlists)
+	api.GET("/watchlists/:id", m.Read, h.GetWatchlist)
+	api.PATCH("/watchlists/:id", m.Write, h.RenameWatchlist)
+	api.DELETE("/watchlists/:id", m.Write, h.DeleteWatchlist)
+	api.POST("/watchlists/:id/symbols", m.Write, h.AddWatchlistSymbols)
+	api.DELETE("/watchlists/:id/symbols/:symbol", m.Write, h.RemoveWatchlistSymbol)
+	api.GET("/watchlists/:id/prices", m.Read, h.GetWatchlistPrices)
+
+	// Portfolios (gRPC to Portfolio Service)
+	api.POST("/portfolios", m.Write, h.CreatePortfolio)
+	api.GET("/portfolios", m.Read, h.ListPortfolios)
+	api.GET("/portfolios/:id", m.Read, h.GetPortfolio)
+	api.DELETE("/portfolios/:id", m.Write, h.DeletePortfolio)
+	api.POST("/portfolios/:id/transactions", m.Write, h.RecordTransaction)
+	api.GET("/portfolios/:id/transactions", m.Read, h.ListTransactions)
+	api.GET("/portfolios/:id/valuation", m.Read, h.GetValuation)
+
+	// Paper trading (gRPC to Trading Service)
+	api.POST("/orders", m.Write, h.PlaceOrder)
+	api.GET("/orders", m.Read, h.ListOrders)
+	api.GET("/orders/:id", m.Read, h.GetOrder)
+	api.DELETE("/orders/:id", m.Write, h.CancelOrder)
+	api.GET("/account", m.Read, h.GetAccount)
+	api.POST("/account/reset", m.Write, h.ResetAccount)
+
+	// Live streaming (WebSocket and Server-Sent Events)
+	api.GET("/ws", m.Stream, h.StreamWebSocket)
+	api.GET("/stream/prices", m.Stream, h.StreamPrices)
+	api.GET("/stream/alerts", m.Stream, h.StreamAlerts)
+	api.GET("/stream/portfolios/:id", m.Stream, h.StreamPortfolio)
+}
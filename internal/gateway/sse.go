@@ -0,0 +1,206 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tiongMax/gostocks/internal/apierror"
+)
+
+// sseRetry is the reconnect delay suggested to EventSource clients.
+const sseRetry = 3 * time.Second
+
+// startSSE writes the event-stream headers and the reconnect hint.
+func startSSE(c *gin.Context) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", sseRetry.Milliseconds())
+	c.Writer.Flush()
+}
+
+// writeSSE writes a single event and flushes it to the client.
+// An empty id leaves the client's last event ID unchanged.
+func writeSSE(c *gin.Context, id, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(c.Writer, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+	return nil
+}
+
+// writeSSEComment writes a comment line, which clients ignore. Used as a
+// heartbeat so proxies do not close idle connections.
+func writeSSEComment(w io.Writer, text string) error {
+	if _, err := fmt.Fprintf(w, ": %s\n\n", text); err != nil {
+		return err
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// lastEventID returns the resume point sent by the client. Browsers send the
+// Last-Event-ID header on reconnect; the last_event_id query parameter lets a
+// client resume on its first connection.
+func lastEventID(c *gin.Context) string {
+	if id := c.GetHeader("Last-Event-ID"); id != "" {
+		return id
+	}
+	return c.Query("last_event_id")
+}
+
+// StreamPrices handles GET /stream/prices?symbols=AAPL,MSFT
+// Sends "price" events as Server-Sent Events. On reconnect with Last-Event-ID,
+// the latest price of every symbol that changed since that event is replayed.
+func (h *Handler) StreamPrices(c *gin.Context) {
+	symbols := parseSymbols(c.Query("symbols"))
+	if len(symbols) == 0 {
+		apierror.Abort(c, http.StatusBadRequest, "symbols is required")
+		return
+	}
+	if len(symbols) > wsMaxSymbols {
+		apierror.Abort(c, http.StatusBadRequest, "too many symbols")
+		return
+	}
+
+	ctx := c.Request.Context()
+	sub := NewSubscriber()
+	h.hub.Subscribe(sub, symbols...)
+	defer h.hub.Remove(sub)
+
+	if seq, ok := h.hub.ParseEventID(lastEventID(c)); ok {
+		// Symbols the hub has never seen have nothing newer than the snapshot
+		// the client already received.
+		h.hub.Replay(sub, symbols, seq)
+	} else {
+		h.offerSnapshot(ctx, sub, symbols)
+	}
+
+	startSSE(c)
+
+	heartbeat := time.NewTicker(h.sseHeartbeat)
+	defer heartbeat.Stop()
+
+	var lastSeq uint64
+	for {
+		select {
+		case <-sub.Ready():
+			events := sub.Take()
+			// Send in sequence order so every event ID covers all before it
+			sort.Slice(events, func(i, j int) bool { return events[i].Seq < events[j].Seq })
+			for _, event := range events {
+				if event.Seq > lastSeq {
+					lastSeq = event.Seq
+				}
+				if err := writeSSE(c, h.hub.EventID(lastSeq), "price", event.Price); err != nil {
+					return
+				}
+			}
+
+		case <-heartbeat.C:
+			if err := writeSSEComment(c.Writer, "heartbeat"); err != nil {
+				return
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// StreamAlerts handles GET /stream/alerts?user_id=1
+// Relays the user's trigger events as Server-Sent Events. Event IDs are
+// WatchAlerts cursors, so Last-Event-ID resumes without missing triggers.
+func (h *Handler) StreamAlerts(c *gin.Context) {
+	var userID int32
+	if v := c.Query("user_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			apierror.Abort(c, http.StatusBadRequest, "invalid user_id")
+			return
+		}
+		userID = int32(id)
+	}
+
+	userID, ok := resolveUser(c, userID, true)
+	if !ok {
+		return
+	}
+
+	var cursor int64
+	if v := lastEventID(c); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			apierror.Abort(c, http.StatusBadRequest, "invalid Last-Event-ID")
+			return
+		}
+		cursor = n
+	}
+
+	ctx := c.Request.Context()
+	events := make(chan AlertEventData)
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- h.alertClient.WatchAlerts(ctx, userID, parseSymbols(c.Query("symbols")), cursor, func(event AlertEventData) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	startSSE(c)
+
+	heartbeat := time.NewTicker(h.sseHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event := <-events:
+			if event.Type != "trigger" {
+				// Upstream heartbeats are covered by our own
+				continue
+			}
+			if err := writeSSE(c, strconv.FormatInt(event.Cursor, 10), "trigger", event.Trigger); err != nil {
+				return
+			}
+
+		case <-heartbeat.C:
+			if err := writeSSEComment(c.Writer, "heartbeat"); err != nil {
+				return
+			}
+
+		case err := <-streamErr:
+			if err != nil {
+				slog.Warn("Alert stream ended", "user_id", userID, "error", err)
+				writeSSE(c, "", "error", gin.H{"error": "alert stream unavailable"})
+			}
+			return
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/tiongMax/gostocks/proto/trading"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// TradingClient wraps the gRPC connection to the Trading Service.
+type TradingClient struct {
+	conn   *grpc.ClientConn
+	client pb.TradingServiceClient
+}
+
+// NewTradingClient creates a new gRPC client connection to the Trading
+// Service.
+func NewTradingClient(addr string) (*TradingClient, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Trading Service: %w", err)
+	}
+
+	return &TradingClient{
+		conn:   conn,
+		client: pb.NewTradingServiceClient(conn),
+	}, nil
+}
+
+// PlaceOrderRequest is the body of POST /orders.
+type PlaceOrderRequest struct {
+	UserID        int32   `json:"user_id,omitempty" binding:"gte=0"` // Defaults to the caller; other users need the admin scope
+	Symbol        string  `json:"symbol" binding:"required"`
+	Side          string  `json:"side" binding:"required,oneof=BUY SELL"`
+	Type          string  `json:"type" binding:"required,oneof=MARKET LIMIT STOP STOP_LIMIT"`
+	Quantity      float64 `json:"quantity" binding:"required,gt=0"`
+	LimitPrice    float64 `json:"limit_price,omitempty" binding:"gte=0"` // LIMIT and STOP_LIMIT
+	StopPrice     float64 `json:"stop_price,omitempty" binding:"gte=0"`  // STOP and STOP_LIMIT
+	ClientOrderID string  `json:"client_order_id,omitempty" binding:"max=64"`
+}
+
+// OrderData represents a single paper order in responses.
+type OrderData struct {
+	ID            int64   `json:"id"`
+	UserID        int32   `json:"user_id"`
+	ClientOrderID string  `json:"client_order_id,omitempty"`
+	Symbol        string  `json:"symbol"`
+	Side          string  `json:"side"`
+	Type          string  `json:"type"`
+	Quantity      float64 `json:"quantity"`
+	LimitPrice    float64 `json:"limit_price,omitempty"`
+	StopPrice     float64 `json:"stop_price,omitempty"`
+	Status        string  `json:"status"`
+	StopTriggered bool    `json:"stop_triggered"`
+	FillPrice     float64 `json:"fill_price,omitempty"`
+	Fee           float64 `json:"fee"`
+	RejectReason  string  `json:"reject_reason,omitempty"`
+	CreatedAt     int64   `json:"created_at"`
+	UpdatedAt     int64   `json:"updated_at"`
+	FilledAt      int64   `json:"filled_at,omitempty"`
+}
+
+// OrderQuery filters the order list.
+type OrderQuery struct {
+	UserID int32
+	Status string
+	Symbol string
+	Limit  int32
+}
+
+// PaperPositionData is a paper trading position.
+type PaperPositionData struct {
+	Symbol      string  `json:"symbol"`
+	Quantity    float64 `json:"quantity"`
+	AverageCost float64 `json:"average_cost"`
+	RealizedPnL float64 `json:"realized_pnl"`
+}
+
+// AccountData is a user's paper trading account.
+type AccountData struct {
+	UserID       int32               `json:"user_id"`
+	Cash         float64             `json:"cash"`
+	StartingCash float64             `json:"starting_cash"`
+	Positions    []PaperPositionData `json:"positions"`
+	RealizedPnL  float64             `json:"realized_pnl"`
+	UpdatedAt    int64               `json:"updated_at"`
+}
+
+// ResetAccountRequest is the body of POST /account/reset.
+type ResetAccountRequest struct {
+	UserID int32   `json:"user_id,omitempty" binding:"gte=0"`
+	Cash   float64 `json:"cash,omitempty" binding:"gte=0"` // 0 = the default starting cash
+}
+
+// PlaceOrder places a paper order via the Trading Service.
+func (t *TradingClient) PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*OrderData, error) {
+	resp, err := t.client.PlaceOrder(ctx, &pb.PlaceOrderRequest{
+		UserId:        req.UserID,
+		Symbol:        req.Symbol,
+		Side:          pb.Side(pb.Side_value[req.Side]),
+		Type:          pb.OrderType(pb.OrderType_value[req.Type]),
+		Quantity:      req.Quantity,
+		LimitPrice:    req.LimitPrice,
+		StopPrice:     req.StopPrice,
+		ClientOrderId: req.ClientOrderID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orderFromProto(resp), nil
+}
+
+// GetOrder retrieves an order. A userID of 0 matches any owner.
+func (t *TradingClient) GetOrder(ctx context.Context, id int64, userID int32) (*OrderData, error) {
+	resp, err := t.client.GetOrder(ctx, &pb.GetOrderRequest{Id: id, UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return orderFromProto(resp), nil
+}
+
+// ListOrders retrieves orders, newest first.
+func (t *TradingClient) ListOrders(ctx context.Context, q OrderQuery) ([]OrderData, error) {
+	resp, err := t.client.ListOrders(ctx, &pb.ListOrdersRequest{
+		UserId: q.UserID,
+		Status: pb.OrderStatus(pb.OrderStatus_value[q.Status]),
+		Symbol: q.Symbol,
+		Limit:  q.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]OrderData, len(resp.Orders))
+	for i, o := range resp.Orders {
+		orders[i] = *orderFromProto(o)
+	}
+	return orders, nil
+}
+
+// CancelOrder cancels an open order. A userID of 0 matches any owner.
+func (t *TradingClient) CancelOrder(ctx context.Context, id int64, userID int32) (*OrderData, error) {
+	resp, err := t.client.CancelOrder(ctx, &pb.CancelOrderRequest{Id: id, UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return orderFromProto(resp), nil
+}
+
+// GetAccount retrieves a user's paper account.
+func (t *TradingClient) GetAccount(ctx context.Context, userID int32) (*AccountData, error) {
+	resp, err := t.client.GetAccount(ctx, &pb.GetAccountRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return accountFromProto(resp), nil
+}
+
+// ResetAccount restores a user's paper account to its starting cash.
+func (t *TradingClient) ResetAccount(ctx context.Context, req *ResetAccountRequest) (*AccountData, error) {
+	resp, err := t.client.ResetAccount(ctx, &pb.ResetAccountRequest{UserId: req.UserID, Cash: req.Cash})
+	if err != nil {
+		return nil, err
+	}
+	return accountFromProto(resp), nil
+}
+
+// orderFromProto converts a proto order to its JSON form.
+func orderFromProto(o *pb.Order) *OrderData {
+	return &OrderData{
+		ID:            o.Id,
+		UserID:        o.UserId,
+		ClientOrderID: o.ClientOrderId,
+		Symbol:        o.Symbol,
+		Side:          o.Side.String(),
+		Type:          o.Type.String(),
+		Quantity:      o.Quantity,
+		LimitPrice:    o.LimitPrice,
+		StopPrice:     o.StopPrice,
+		Status:        o.Status.String(),
+		StopTriggered: o.StopTriggered,
+		FillPrice:     o.FillPrice,
+		Fee:           o.Fee,
+		RejectReason:  o.RejectReason,
+		CreatedAt:     o.CreatedAt,
+		UpdatedAt:     o.UpdatedAt,
+		FilledAt:      o.FilledAt,
+	}
+}
+
+// accountFromProto converts a proto account to its JSON form.
+func accountFromProto(a *pb.Account) *AccountData {
+	positions := make([]PaperPositionData, len(a.Positions))
+	for i, p := range a.Positions {
+		positions[i] = PaperPositionData{
+			Symbol:      p.Symbol,
+			Quantity:    p.Quantity,
+			AverageCost: p.AverageCost,
+			RealizedPnL: p.RealizedPnl,
+		}
+	}
+	return &AccountData{
+		UserID:       a.UserId,
+		Cash:         a.Cash,
+		StartingCash: a.StartingCash,
+		Positions:    positions,
+		RealizedPnL:  a.RealizedPnl,
+		UpdatedAt:    a.UpdatedAt,
+	}
+}
+
+// Close closes the gRPC connection.
+func (t *TradingClient) Close() error {
+	return t.conn.Close()
+}
@@ -0,0 +1,154 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/tiongMax/gostocks/proto/watchlist"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// WatchlistClient wraps the gRPC connection to the Watchlist Service.
+type WatchlistClient struct {
+	conn   *grpc.ClientConn
+	client pb.WatchlistServiceClient
+}
+
+// NewWatchlistClient creates a new gRPC client connection to the Watchlist
+// Service, which is served by the Alert Service process.
+func NewWatchlistClient(addr string) (*WatchlistClient, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Watchlist Service: %w", err)
+	}
+
+	return &WatchlistClient{
+		conn:   conn,
+		client: pb.NewWatchlistServiceClient(conn),
+	}, nil
+}
+
+// WatchlistData represents a single watchlist in responses.
+type WatchlistData struct {
+	ID        int32    `json:"id"`
+	UserID    int32    `json:"user_id"`
+	Name      string   `json:"name"`
+	Symbols   []string `json:"symbols"`
+	CreatedAt int64    `json:"created_at"`
+	UpdatedAt int64    `json:"updated_at"`
+}
+
+// CreateWatchlistRequest is the body of POST /watchlists.
+type CreateWatchlistRequest struct {
+	UserID  int32    `json:"user_id,omitempty" binding:"gte=0"` // Defaults to the caller; other users need the admin scope
+	Name    string   `json:"name" binding:"required"`
+	Symbols []string `json:"symbols,omitempty"`
+}
+
+// RenameWatchlistRequest is the body of PATCH /watchlists/:id.
+type RenameWatchlistRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// WatchlistSymbolsRequest is the body of POST /watchlists/:id/symbols.
+type WatchlistSymbolsRequest struct {
+	Symbols []string `json:"symbols" binding:"required"`
+}
+
+// CreateWatchlist creates a watchlist via the Watchlist Service.
+func (w *WatchlistClient) CreateWatchlist(ctx context.Context, req *CreateWatchlistRequest) (*WatchlistData, error) {
+	resp, err := w.client.CreateWatchlist(ctx, &pb.CreateWatchlistRequest{
+		UserId:  req.UserID,
+		Name:    req.Name,
+		Symbols: req.Symbols,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return watchlistFromProto(resp), nil
+}
+
+// GetWatchlist retrieves a watchlist. A userID of 0 matches any owner.
+func (w *WatchlistClient) GetWatchlist(ctx context.Context, id, userID int32) (*WatchlistData, error) {
+	resp, err := w.client.GetWatchlist(ctx, &pb.GetWatchlistRequest{Id: id, UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return watchlistFromProto(resp), nil
+}
+
+// ListWatchlists retrieves a user's watchlists, or every user's for user 0.
+func (w *WatchlistClient) ListWatchlists(ctx context.Context, userID int32) ([]WatchlistData, error) {
+	resp, err := w.client.ListWatchlists(ctx, &pb.ListWatchlistsRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	lists := make([]WatchlistData, len(resp.Watchlists))
+	for i, l := range resp.Watchlists {
+		lists[i] = *watchlistFromProto(l)
+	}
+	return lists, nil
+}
+
+// RenameWatchlist changes a watchlist's name.
+func (w *WatchlistClient) RenameWatchlist(ctx context.Context, id, userID int32, name string) (*WatchlistData, error) {
+	resp, err := w.client.RenameWatchlist(ctx, &pb.RenameWatchlistRequest{Id: id, UserId: userID, Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return watchlistFromProto(resp), nil
+}
+
+// DeleteWatchlist deletes a watchlist.
+func (w *WatchlistClient) DeleteWatchlist(ctx context.Context, id, userID int32) error {
+	_, err := w.client.DeleteWatchlist(ctx, &pb.DeleteWatchlistRequest{Id: id, UserId: userID})
+	return err
+}
+
+// AddSymbols adds symbols to a watchlist.
+func (w *WatchlistClient) AddSymbols(ctx context.Context, id, userID int32, symbols []string) (*WatchlistData, error) {
+	resp, err := w.client.AddSymbols(ctx, &pb.UpdateSymbolsRequest{Id: id, UserId: userID, Symbols: symbols})
+	if err != nil {
+		return nil, err
+	}
+	return watchlistFromProto(resp), nil
+}
+
+// RemoveSymbols removes symbols from a watchlist.
+func (w *WatchlistClient) RemoveSymbols(ctx context.Context, id, userID int32, symbols []string) (*WatchlistData, error) {
+	resp, err := w.client.RemoveSymbols(ctx, &pb.UpdateSymbolsRequest{Id: id, UserId: userID, Symbols: symbols})
+	if err != nil {
+		return nil, err
+	}
+	return watchlistFromProto(resp), nil
+}
+
+// watchlistFromProto converts a proto watchlist to its JSON form.
+func watchlistFromProto(w *pb.Watchlist) *WatchlistData {
+	symbols := w.Symbols
+	if symbols == nil {
+		symbols = []string{}
+	}
+	return &WatchlistData{
+		ID:        w.Id,
+		UserID:    w.UserId,
+		Name:      w.Name,
+		Symbols:   symbols,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+// Close closes the gRPC connection.
+func (w *WatchlistClient) Close() error {
+	return w.conn.Close()
+}
@@ -0,0 +1,169 @@
+package gateway
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tiongMax/gostocks/internal/apierror"
+)
+
+// CreateWatchlist handles POST /watchlists
+// Creates a watchlist, optionally with initial symbols, for the caller.
+func (h *Handler) CreateWatchlist(c *gin.Context) {
+	var req CreateWatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, fromBinding(err))
+		return
+	}
+
+	userID, ok := resolveUser(c, req.UserID, false)
+	if !ok {
+		return
+	}
+	req.UserID = userID
+
+	list, err := h.watchlists.CreateWatchlist(c.Request.Context(), &req)
+	if err != nil {
+		respondGRPC(c, "Failed to create watchlist", err, "user_id", req.UserID)
+		return
+	}
+	c.JSON(http.StatusCreated, list)
+}
+
+// ListWatchlists handles GET /watchlists
+// Query params: user_id (optional, admin only; 0 = all users).
+func (h *Handler) ListWatchlists(c *gin.Context) {
+	var userID int32
+	if v := c.Query("user_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			apierror.Abort(c, http.StatusBadRequest, "invalid user_id")
+			return
+		}
+		userID = int32(id)
+	}
+
+	userID, ok := resolveUser(c, userID, true)
+	if !ok {
+		return
+	}
+
+	lists, err := h.watchlists.ListWatchlists(c.Request.Context(), userID)
+	if err != nil {
+		respondGRPC(c, "Failed to fetch watchlists", err, "user_id", userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"watchlists": lists,
+		"count":      len(lists),
+	})
+}
+
+// GetWatchlist handles GET /watchlists/:id
+func (h *Handler) GetWatchlist(c *gin.Context) {
+	id, userID, ok := resourceTarget(c, "watchlist")
+	if !ok {
+		return
+	}
+
+	list, err := h.watchlists.GetWatchlist(c.Request.Context(), id, userID)
+	if err != nil {
+		respondGRPC(c, "Failed to fetch watchlist", err, "watchlist_id", id)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// RenameWatchlist handles PATCH /watchlists/:id
+func (h *Handler) RenameWatchlist(c *gin.Context) {
+	id, userID, ok := resourceTarget(c, "watchlist")
+	if !ok {
+		return
+	}
+
+	var req RenameWatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, fromBinding(err))
+		return
+	}
+
+	list, err := h.watchlists.RenameWatchlist(c.Request.Context(), id, userID, req.Name)
+	if err != nil {
+		respondGRPC(c, "Failed to rename watchlist", err, "watchlist_id", id)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// DeleteWatchlist handles DELETE /watchlists/:id
+func (h *Handler) DeleteWatchlist(c *gin.Context) {
+	id, userID, ok := resourceTarget(c, "watchlist")
+	if !ok {
+		return
+	}
+
+	if err := h.watchlists.DeleteWatchlist(c.Request.Context(), id, userID); err != nil {
+		respondGRPC(c, "Failed to delete watchlist", err, "watchlist_id", id)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AddWatchlistSymbols handles POST /watchlists/:id/symbols
+// Appends symbols to a watchlist; the ingestor starts streaming any symbol it
+// did not stream yet.
+func (h *Handler) AddWatchlistSymbols(c *gin.Context) {
+	id, userID, ok := resourceTarget(c, "watchlist")
+	if !ok {
+		return
+	}
+
+	var req WatchlistSymbolsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, fromBinding(err))
+		return
+	}
+
+	list, err := h.watchlists.AddSymbols(c.Request.Context(), id, userID, req.Symbols)
+	if err != nil {
+		respondGRPC(c, "Failed to add watchlist symbols", err, "watchlist_id", id)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// RemoveWatchlistSymbol handles DELETE /watchlists/:id/symbols/:symbol
+func (h *Handler) RemoveWatchlistSymbol(c *gin.Context) {
+	id, userID, ok := resourceTarget(c, "watchlist")
+	if !ok {
+		return
+	}
+
+	symbol := strings.ToUpper(c.Param("symbol"))
+	list, err := h.watchlists.RemoveSymbols(c.Request.Context(), id, userID, []string{symbol})
+	if err != nil {
+		respondGRPC(c, "Failed to remove watchlist symbol", err, "watchlist_id", id, "symbol", symbol)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetWatchlistPrices handles GET /watchlists/:id/prices
+// Returns the latest prices of every symbol in the watchlist in the format of
+// GET /prices.
+func (h *Handler) GetWatchlistPrices(c *gin.Context) {
+	id, userID, ok := resourceTarget(c, "watchlist")
+	if !ok {
+		return
+	}
+
+	list, err := h.watchlists.GetWatchlist(c.Request.Context(), id, userID)
+	if err != nil {
+		respondGRPC(c, "Failed to fetch watchlist", err, "watchlist_id", id)
+		return
+	}
+	h.writePrices(c, list.Symbols)
+}
@@ -0,0 +1,231 @@
+package gateway
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// Time allowed to write a message. A client that cannot absorb a write
+	// within this window is a slow consumer and is disconnected.
+	wsWriteWait = 10 * time.Second
+
+	// Time allowed between pongs before the connection is considered dead.
+	wsPongWait = 60 * time.Second
+
+	// Ping period; must be shorter than wsPongWait.
+	wsPingPeriod = wsPongWait * 9 / 10
+
+	// Maximum size of a client message.
+	wsMaxMessageSize = 4096
+
+	// Maximum number of symbols a single connection may subscribe to.
+	wsMaxSymbols = 200
+
+	// Size of the per-connection queue for replies to client requests.
+	// A client that lets it fill up is evicted.
+	wsSendBuffer = 16
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+}
+
+// wsRequest is a message sent by the client.
+type wsRequest struct {
+	Action  string   `json:"action"` // "subscribe" or "unsubscribe"
+	Symbols []string `json:"symbols"`
+}
+
+// wsMessage is a message sent to the client.
+type wsMessage struct {
+	Type    string     `json:"type"` // "price", "subscribed", "unsubscribed" or "error"
+	Data    *PriceData `json:"data,omitempty"`
+	Symbols []string   `json:"symbols,omitempty"`
+	Error   string     `json:"error,omitempty"`
+}
+
+// wsConn is a single WebSocket client.
+type wsConn struct {
+	conn    *websocket.Conn
+	handler *Handler
+	hub     *Hub
+	sub     *Subscriber
+	send    chan wsMessage
+	symbols map[string]bool
+	done    chan struct{}
+}
+
+// StreamWebSocket handles GET /ws
+// Clients send {"action":"subscribe","symbols":["AAPL"]} (or "unsubscribe")
+// and receive {"type":"price","data":{...}} messages as prices change.
+func (h *Handler) StreamWebSocket(c *gin.Context) {
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		slog.Warn("WebSocket upgrade failed", "error", err)
+		return
+	}
+
+	ws := &wsConn{
+		conn:    conn,
+		handler: h,
+		hub:     h.hub,
+		sub:     NewSubscriber(),
+		send:    make(chan wsMessage, wsSendBuffer),
+		symbols: make(map[string]bool),
+		done:    make(chan struct{}),
+	}
+	slog.Debug("WebSocket client connected", "remote", c.ClientIP())
+
+	go ws.writePump()
+	ws.readPump()
+}
+
+// readPump processes client requests until the connection fails.
+func (ws *wsConn) readPump() {
+	defer func() {
+		// writePump sends the close frame and closes the connection
+		ws.hub.Remove(ws.sub)
+		close(ws.done)
+	}()
+
+	ws.conn.SetReadLimit(wsMaxMessageSize)
+	ws.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	ws.conn.SetPongHandler(func(string) error {
+		return ws.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		var req wsRequest
+		if err := ws.conn.ReadJSON(&req); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				slog.Debug("WebSocket read error", "error", err)
+			}
+			return
+		}
+
+		var symbols []string
+		for _, s := range req.Symbols {
+			if s = strings.ToUpper(strings.TrimSpace(s)); s != "" {
+				symbols = append(symbols, s)
+			}
+		}
+
+		var reply wsMessage
+		switch req.Action {
+		case "subscribe":
+			reply = ws.subscribe(symbols)
+		case "unsubscribe":
+			ws.hub.Unsubscribe(ws.sub, symbols...)
+			for _, s := range symbols {
+				delete(ws.symbols, s)
+			}
+			reply = wsMessage{Type: "unsubscribed", Symbols: symbols}
+		default:
+			reply = wsMessage{Type: "error", Error: "unknown action: " + req.Action}
+		}
+
+		select {
+		case ws.send <- reply:
+		default:
+			slog.Warn("Evicting slow WebSocket client", "reason", "send buffer full")
+			return
+		}
+	}
+}
+
+// subscribe registers symbols with the hub and queues their current prices
+// so the client does not have to wait for the next tick.
+func (ws *wsConn) subscribe(symbols []string) wsMessage {
+	added := 0
+	for _, s := range symbols {
+		if !ws.symbols[s] {
+			added++
+		}
+	}
+	if len(ws.symbols)+added > wsMaxSymbols {
+		return wsMessage{Type: "error", Error: "too many symbols"}
+	}
+
+	for _, s := range symbols {
+		ws.symbols[s] = true
+	}
+	ws.hub.Subscribe(ws.sub, symbols...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ws.handler.offerSnapshot(ctx, ws.sub, symbols)
+
+	return wsMessage{Type: "subscribed", Symbols: symbols}
+}
+
+// writePump sends replies, conflated price updates and keepalive pings.
+func (ws *wsConn) writePump() {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer func() {
+		ticker.Stop()
+		ws.conn.Close()
+	}()
+
+	write := func(msg wsMessage) bool {
+		ws.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+		if err := ws.conn.WriteJSON(msg); err != nil {
+			slog.Debug("WebSocket write failed", "error", err)
+			return false
+		}
+		return true
+	}
+
+	for {
+		select {
+		case msg := <-ws.send:
+			if !write(msg) {
+				return
+			}
+
+		case <-ws.sub.Ready():
+			for _, event := range ws.sub.Take() {
+				if !write(wsMessage{Type: "price", Data: &event.Price}) {
+					return
+				}
+			}
+
+		case <-ticker.C:
+			ws.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := ws.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+
+		case <-ws.done:
+			ws.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			ws.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		}
+	}
+}
+
+// offerSnapshot queues the current price of each symbol for a new subscriber.
+// Symbols the hub has not seen since it started are looked up in Redis, and
+// marked with their age like any other price served from Redis.
+func (h *Handler) offerSnapshot(ctx context.Context, sub *Subscriber, symbols []string) {
+	unseen := h.hub.Replay(sub, symbols, 0)
+	if len(unseen) == 0 {
+		return
+	}
+	prices, _, err := h.redis.GetPrices(ctx, unseen)
+	if err != nil {
+		slog.Warn("Snapshot lookup failed", "symbols", len(unseen), "error", err)
+		return
+	}
+	now := time.Now()
+	for _, price := range prices {
+		h.markFreshness(&price, now)
+		sub.Offer(PriceEvent{Price: price})
+	}
+}
@@ -0,0 +1,56 @@
+package history
+
+import (
+	"fmt"
+	"time"
+)
+
+// BaseInterval is the resolution of stored candles; coarser intervals are
+// aggregated from it at query time.
+const BaseInterval = time.Minute
+
+// intervals lists the supported candle intervals, finest first.
+var intervals = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"1m", time.Minute},
+	{"5m", 5 * time.Minute},
+	{"15m", 15 * time.Minute},
+	{"30m", 30 * time.Minute},
+	{"1h", time.Hour},
+	{"4h", 4 * time.Hour},
+	{"1d", 24 * time.Hour},
+}
+
+// ParseInterval converts a name such as "5m" or "1d" to its duration.
+func ParseInterval(name string) (time.Duration, error) {
+	for _, iv := range intervals {
+		if iv.name == name {
+			return iv.duration, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported interval %q", name)
+}
+
+// IntervalName returns the name of a supported interval duration.
+func IntervalName(d time.Duration) string {
+	for _, iv := range intervals {
+		if iv.duration == d {
+			return iv.name
+		}
+	}
+	return d.String()
+}
+
+// ChooseInterval returns the finest supported interval that covers
+// [from, to) in at most maxPoints candles, or the coarsest one if none does.
+func ChooseInterval(from, to time.Time, maxPoints int) time.Duration {
+	span := to.Sub(from)
+	for _, iv := range intervals {
+		if int(span/iv.duration) <= maxPoints {
+			return iv.duration
+		}
+	}
+	return intervals[len(intervals)-1].duration
+}
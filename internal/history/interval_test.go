@@ -0,0 +1,57 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"1m", time.Minute, false},
+		{"4h", 4 * time.Hour, false},
+		{"1d", 24 * time.Hour, false},
+		{"2m", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInterval(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInterval(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseInterval(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChooseInterval(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		span      time.Duration
+		maxPoints int
+		want      time.Duration
+	}{
+		{"one hour fits in minutes", time.Hour, 100, time.Minute},
+		{"one day needs 15m", 24 * time.Hour, 100, 15 * time.Minute},
+		{"exact fit", 500 * time.Minute, 100, 5 * time.Minute},
+		{"one year falls back to days", 365 * 24 * time.Hour, 100, 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ChooseInterval(from, from.Add(tt.span), tt.maxPoints)
+			if got != tt.want {
+				t.Errorf("ChooseInterval(%v, %d) = %v, want %v", tt.span, tt.maxPoints, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// partitioning describes how a table is split into time-range partitions.
+// Partitions are named <table>_p<suffix>, e.g. ticks_p20260302, and cover
+// [start, next(start)) in UTC.
+type partitioning struct {
+	table  string
+	layout string // time layout of the name suffix
+	start  func(t time.Time) time.Time
+	next   func(start time.Time) time.Time
+}
+
+// tickPartitions splits ticks into daily partitions.
+var tickPartitions = partitioning{
+	table:  "ticks",
+	layout: "20060102",
+	start: func(t time.Time) time.Time {
+		t = t.UTC()
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	},
+	next: func(start time.Time) time.Time { return start.AddDate(0, 0, 1) },
+}
+
+// candlePartitions splits one-minute candles into monthly partitions.
+var candlePartitions = partitioning{
+	table:  "candles_1m",
+	layout: "200601",
+	start: func(t time.Time) time.Time {
+		t = t.UTC()
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	},
+	next: func(start time.Time) time.Time { return start.AddDate(0, 1, 0) },
+}
+
+// bounds returns the name and range of the partition holding t.
+func (p partitioning) bounds(t time.Time) (name string, from, to time.Time) {
+	from = p.start(t)
+	return p.name(from), from, p.next(from)
+}
+
+func (p partitioning) name(start time.Time) string {
+	return fmt.Sprintf("%s_p%s", p.table, start.Format(p.layout))
+}
+
+// parse returns the range of a partition from its name. It reports false for
+// tables that were not created by this package.
+func (p partitioning) parse(name string) (from, to time.Time, ok bool) {
+	suffix, found := strings.CutPrefix(name, p.table+"_p")
+	if !found {
+		return time.Time{}, time.Time{}, false
+	}
+	from, err := time.ParseInLocation(p.layout, suffix, time.UTC)
+	if err != nil || p.name(from) != name {
+		return time.Time{}, time.Time{}, false
+	}
+	return from, p.next(from), true
+}
@@ -0,0 +1,61 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPartitionBounds(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+
+	tests := []struct {
+		name     string
+		p        partitioning
+		t        time.Time
+		wantName string
+		wantFrom time.Time
+		wantTo   time.Time
+	}{
+		{
+			name:     "tick partition is the UTC day",
+			p:        tickPartitions,
+			t:        time.Date(2026, 3, 2, 21, 30, 0, 0, ny), // 02:30 UTC on the 3rd
+			wantName: "ticks_p20260303",
+			wantFrom: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "candle partition wraps the year",
+			p:        candlePartitions,
+			t:        time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC),
+			wantName: "candles_1m_p202612",
+			wantFrom: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, from, to := tt.p.bounds(tt.t)
+			if name != tt.wantName || !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("bounds() = %s [%v, %v), want %s [%v, %v)", name, from, to, tt.wantName, tt.wantFrom, tt.wantTo)
+			}
+
+			pFrom, pTo, ok := tt.p.parse(name)
+			if !ok || !pFrom.Equal(from) || !pTo.Equal(to) {
+				t.Errorf("parse(%q) = [%v, %v) %v, want [%v, %v)", name, pFrom, pTo, ok, from, to)
+			}
+		})
+	}
+}
+
+func TestPartitionParseRejectsForeignTables(t *testing.T) {
+	for _, name := range []string{"ticks", "ticks_default", "ticks_p2026", "ticks_p20261301"} {
+		if _, _, ok := tickPartitions.parse(name); ok {
+			t.Errorf("parse(%q) accepted a foreign table", name)
+		}
+	}
+	if _, _, ok := candlePartitions.parse("ticks_p20260301"); ok {
+		t.Error("candle partitioning accepted a tick partition")
+	}
+}
@@ -0,0 +1,87 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Reader queries stored ticks and candles.
+type Reader struct {
+	db *gorm.DB
+}
+
+// NewReader opens a read connection to the time-series store.
+func NewReader(connStr string) (*Reader, error) {
+	db, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{db: db}, nil
+}
+
+// Close closes the database connection.
+func (r *Reader) Close() error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// Ticks returns up to limit ticks for a symbol in [from, to), oldest first.
+func (r *Reader) Ticks(ctx context.Context, symbol string, from, to time.Time, limit int) ([]Tick, error) {
+	var ticks []Tick
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT symbol, ts AS time, seq, price, volume
+		FROM ticks
+		WHERE symbol = ? AND ts >= ? AND ts < ?
+		ORDER BY ts, seq
+		LIMIT ?`, symbol, from, to, limit).Scan(&ticks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ticks: %w", err)
+	}
+	return ticks, nil
+}
+
+// Candles returns up to limit candles of the given interval for a symbol in
+// [from, to), oldest first. Intervals coarser than BaseInterval are aggregated
+// from the stored one-minute candles, aligned to the Unix epoch in UTC.
+func (r *Reader) Candles(ctx context.Context, symbol string, interval time.Duration, from, to time.Time, limit int) ([]Candle, error) {
+	if interval < BaseInterval || interval%BaseInterval != 0 {
+		return nil, fmt.Errorf("interval must be a multiple of %s", BaseInterval)
+	}
+
+	var candles []Candle
+	var err error
+	if interval == BaseInterval {
+		err = r.db.WithContext(ctx).Raw(`
+			SELECT symbol, bucket AS start, open, high, low, close, volume, trades
+			FROM candles_1m
+			WHERE symbol = ? AND bucket >= ? AND bucket < ?
+			ORDER BY bucket
+			LIMIT ?`, symbol, from, to, limit).Scan(&candles).Error
+	} else {
+		err = r.db.WithContext(ctx).Raw(`
+			SELECT symbol,
+				date_bin(?::interval, bucket, TIMESTAMPTZ '1970-01-01 00:00:00+00') AS start,
+				(array_agg(open ORDER BY bucket))[1] AS open,
+				max(high) AS high,
+				min(low) AS low,
+				(array_agg(close ORDER BY bucket DESC))[1] AS close,
+				sum(volume) AS volume,
+				sum(trades) AS trades
+			FROM candles_1m
+			WHERE symbol = ? AND bucket >= ? AND bucket < ?
+			GROUP BY symbol, start
+			ORDER BY start
+			LIMIT ?`, fmt.Sprintf("%d seconds", int64(interval/time.Second)), symbol, from, to, limit).Scan(&candles).Error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query candles: %w", err)
+	}
+	return candles, nil
+}
@@ -0,0 +1,27 @@
+package history
+
+import (
+	"time"
+)
+
+// Tick is a single stored trade. Seq disambiguates ticks of the same symbol
+// that share a timestamp; the processor uses the Kafka offset.
+type Tick struct {
+	Symbol string    `json:"symbol"`
+	Time   time.Time `json:"time"`
+	Seq    int64     `json:"seq"`
+	Price  float64   `json:"price"`
+	Volume float64   `json:"volume"`
+}
+
+// Candle is an OHLCV bar covering [Start, Start+interval).
+type Candle struct {
+	Symbol string    `json:"symbol"`
+	Start  time.Time `json:"start"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Volume float64   `json:"volume"`
+	Trades int64     `json:"trades"`
+}
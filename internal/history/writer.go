@@ -0,0 +1,392 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	// candleGrace is how long after a minute ends its candle is written when
+	// no later tick for the symbol has arrived to close it.
+	candleGrace = 5 * time.Second
+
+	// partitionLock is the advisory lock key serializing partition DDL
+	// across processor instances.
+	partitionLock = 0x7469636b // "tick"
+)
+
+// schema creates the partitioned parent tables. Partitions are created on
+// demand by the Writer.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS ticks (
+		symbol text NOT NULL,
+		ts timestamptz NOT NULL,
+		seq bigint NOT NULL,
+		price double precision NOT NULL,
+		volume double precision NOT NULL DEFAULT 0,
+		PRIMARY KEY (symbol, ts, seq)
+	) PARTITION BY RANGE (ts)`,
+	`CREATE TABLE IF NOT EXISTS candles_1m (
+		symbol text NOT NULL,
+		bucket timestamptz NOT NULL,
+		open double precision NOT NULL,
+		high double precision NOT NULL,
+		low double precision NOT NULL,
+		close double precision NOT NULL,
+		volume double precision NOT NULL DEFAULT 0,
+		trades bigint NOT NULL DEFAULT 0,
+		PRIMARY KEY (symbol, bucket)
+	) PARTITION BY RANGE (bucket)`,
+}
+
+type candleKey struct {
+	symbol string
+	bucket time.Time
+}
+
+// Writer persists ticks and one-minute candles.
+//
+// Ticks are bulk-loaded with COPY and upserted on (symbol, ts, seq), so
+// writing the same batch twice (e.g. after a consumer restart) is harmless.
+// Candles are rebuilt from the stored ticks once their minute has closed,
+// which keeps them correct under redelivery and late ticks.
+type Writer struct {
+	db              *gorm.DB
+	tickRetention   time.Duration
+	candleRetention time.Duration
+
+	mu         sync.Mutex
+	partitions map[string]bool        // Partitions known to exist
+	open       map[candleKey]struct{} // Minutes with ticks not yet folded into a candle
+	watermark  map[string]time.Time   // symbol -> latest tick time written
+}
+
+// NewWriter connects to Postgres and creates the tables if needed.
+// Partitions older than the retention periods are dropped by Run;
+// a zero retention keeps data forever.
+func NewWriter(connStr string, tickRetention, candleRetention time.Duration) (*Writer, error) {
+	db, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stmt := range schema {
+		if err := db.Exec(stmt).Error; err != nil {
+			return nil, fmt.Errorf("failed to create history tables: %w", err)
+		}
+	}
+
+	return &Writer{
+		db:              db,
+		tickRetention:   tickRetention,
+		candleRetention: candleRetention,
+		partitions:      make(map[string]bool),
+		open:            make(map[candleKey]struct{}),
+		watermark:       make(map[string]time.Time),
+	}, nil
+}
+
+// WriteTicks stores a batch of ticks and writes the candles it closes.
+// Ticks older than the tick retention period are skipped.
+func (w *Writer) WriteTicks(ctx context.Context, ticks []Tick) error {
+	if w.tickRetention > 0 {
+		cutoff := time.Now().Add(-w.tickRetention)
+		kept := ticks[:0:0]
+		for _, t := range ticks {
+			if !t.Time.Before(cutoff) {
+				kept = append(kept, t)
+			}
+		}
+		if dropped := len(ticks) - len(kept); dropped > 0 {
+			slog.Debug("Skipping ticks past retention", "count", dropped)
+		}
+		ticks = kept
+	}
+	if len(ticks) == 0 {
+		return nil
+	}
+
+	times := make([]time.Time, len(ticks))
+	for i, t := range ticks {
+		times[i] = t.Time
+	}
+	if err := w.ensurePartitions(ctx, tickPartitions, times); err != nil {
+		return err
+	}
+	if err := w.copyTicks(ctx, ticks); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	for _, t := range ticks {
+		w.open[candleKey{t.Symbol, t.Time.Truncate(BaseInterval)}] = struct{}{}
+		if t.Time.After(w.watermark[t.Symbol]) {
+			w.watermark[t.Symbol] = t.Time
+		}
+	}
+	w.mu.Unlock()
+
+	return w.CloseCandles(ctx, time.Now())
+}
+
+// copyTicks bulk-loads ticks into a staging table and upserts them.
+func (w *Writer) copyTicks(ctx context.Context, ticks []Tick) error {
+	sqlDB, err := w.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	rows := make([][]any, len(ticks))
+	for i, t := range ticks {
+		rows[i] = []any{t.Symbol, t.Time, t.Seq, t.Price, t.Volume}
+	}
+
+	err = conn.Raw(func(driverConn any) error {
+		pgConn := driverConn.(*stdlib.Conn).Conn()
+
+		tx, err := pgConn.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+
+		if _, err := tx.Exec(ctx, `CREATE TEMP TABLE ticks_staging (LIKE ticks) ON COMMIT DROP`); err != nil {
+			return err
+		}
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{"ticks_staging"},
+			[]string{"symbol", "ts", "seq", "price", "volume"}, pgx.CopyFromRows(rows)); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO ticks (symbol, ts, seq, price, volume)
+			SELECT symbol, ts, seq, price, volume FROM ticks_staging
+			ON CONFLICT (symbol, ts, seq) DO NOTHING`); err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write ticks: %w", err)
+	}
+	return nil
+}
+
+// CloseCandles writes the candle of every minute that has closed: a later
+// tick for the symbol has been written, or the minute ended more than
+// candleGrace before now.
+func (w *Writer) CloseCandles(ctx context.Context, now time.Time) error {
+	w.mu.Lock()
+	var closed []candleKey
+	for k := range w.open {
+		end := k.bucket.Add(BaseInterval)
+		if !end.After(w.watermark[k.symbol]) || !end.Add(candleGrace).After(now) {
+			closed = append(closed, k)
+			// Taken out now so that a tick arriving during the write reopens it
+			delete(w.open, k)
+		}
+	}
+	w.mu.Unlock()
+
+	if len(closed) == 0 {
+		return nil
+	}
+
+	if err := w.writeCandles(ctx, closed); err != nil {
+		w.mu.Lock()
+		for _, k := range closed {
+			w.open[k] = struct{}{}
+		}
+		w.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// writeCandles aggregates the stored ticks of each minute into its candle.
+func (w *Writer) writeCandles(ctx context.Context, keys []candleKey) error {
+	buckets := make([]time.Time, len(keys))
+	pairs := make([][]interface{}, len(keys))
+	from, to := keys[0].bucket, keys[0].bucket
+	for i, k := range keys {
+		buckets[i] = k.bucket
+		pairs[i] = []interface{}{k.symbol, k.bucket}
+		if k.bucket.Before(from) {
+			from = k.bucket
+		}
+		if k.bucket.After(to) {
+			to = k.bucket
+		}
+	}
+	if err := w.ensurePartitions(ctx, candlePartitions, buckets); err != nil {
+		return err
+	}
+
+	// The ts range lets Postgres prune tick partitions
+	err := w.db.WithContext(ctx).Exec(`
+		INSERT INTO candles_1m (symbol, bucket, open, high, low, close, volume, trades)
+		SELECT symbol, date_trunc('minute', ts) AS bucket,
+			(array_agg(price ORDER BY ts, seq))[1],
+			max(price),
+			min(price),
+			(array_agg(price ORDER BY ts DESC, seq DESC))[1],
+			sum(volume),
+			count(*)
+		FROM ticks
+		WHERE ts >= ? AND ts < ? AND (symbol, date_trunc('minute', ts)) IN ?
+		GROUP BY symbol, bucket
+		ON CONFLICT (symbol, bucket) DO UPDATE SET
+			open = EXCLUDED.open,
+			high = EXCLUDED.high,
+			low = EXCLUDED.low,
+			close = EXCLUDED.close,
+			volume = EXCLUDED.volume,
+			trades = EXCLUDED.trades`,
+		from, to.Add(BaseInterval), pairs).Error
+	if err != nil {
+		return fmt.Errorf("failed to write candles: %w", err)
+	}
+	return nil
+}
+
+// ensurePartitions creates the partitions covering the given times.
+func (w *Writer) ensurePartitions(ctx context.Context, p partitioning, times []time.Time) error {
+	type span struct{ from, to time.Time }
+	missing := make(map[string]span)
+
+	w.mu.Lock()
+	for _, t := range times {
+		name, from, to := p.bounds(t)
+		if !w.partitions[name] {
+			missing[name] = span{from, to}
+		}
+	}
+	w.mu.Unlock()
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	err := w.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// CREATE TABLE IF NOT EXISTS is not safe against concurrent creators
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", partitionLock).Error; err != nil {
+			return err
+		}
+		for name, s := range missing {
+			stmt := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')`,
+				name, p.table, s.from.Format(time.RFC3339), s.to.Format(time.RFC3339))
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create %s partitions: %w", p.table, err)
+	}
+
+	w.mu.Lock()
+	for name := range missing {
+		w.partitions[name] = true
+	}
+	w.mu.Unlock()
+	return nil
+}
+
+// dropPartitions drops the partitions of p that end before now-retention.
+func (w *Writer) dropPartitions(ctx context.Context, p partitioning, retention time.Duration, now time.Time) error {
+	if retention <= 0 {
+		return nil
+	}
+	cutoff := now.Add(-retention)
+
+	var names []string
+	err := w.db.WithContext(ctx).Raw(`
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class parent ON parent.oid = i.inhparent
+		WHERE parent.relname = ?`, p.table).Scan(&names).Error
+	if err != nil {
+		return fmt.Errorf("failed to list %s partitions: %w", p.table, err)
+	}
+
+	for _, name := range names {
+		_, to, ok := p.parse(name)
+		if !ok || to.After(cutoff) {
+			continue
+		}
+		if err := w.db.WithContext(ctx).Exec("DROP TABLE IF EXISTS " + name).Error; err != nil {
+			return fmt.Errorf("failed to drop partition %s: %w", name, err)
+		}
+		w.mu.Lock()
+		delete(w.partitions, name)
+		w.mu.Unlock()
+		slog.Info("Dropped partition past retention", "partition", name)
+	}
+	return nil
+}
+
+// Maintain creates the partitions for the current and next period ahead of
+// time and drops partitions past retention.
+func (w *Writer) Maintain(ctx context.Context, now time.Time) error {
+	for _, p := range []partitioning{tickPartitions, candlePartitions} {
+		_, _, next := p.bounds(now)
+		if err := w.ensurePartitions(ctx, p, []time.Time{now, next}); err != nil {
+			return err
+		}
+	}
+	if err := w.dropPartitions(ctx, tickPartitions, w.tickRetention, now); err != nil {
+		return err
+	}
+	return w.dropPartitions(ctx, candlePartitions, w.candleRetention, now)
+}
+
+// Run closes candles of quiet symbols and performs partition maintenance
+// until the context is cancelled.
+func (w *Writer) Run(ctx context.Context, maintenanceInterval time.Duration) {
+	if err := w.Maintain(ctx, time.Now()); err != nil {
+		slog.Error("History maintenance failed", "error", err)
+	}
+
+	candles := time.NewTicker(candleGrace)
+	defer candles.Stop()
+	maintenance := time.NewTicker(maintenanceInterval)
+	defer maintenance.Stop()
+
+	for {
+		select {
+		case <-candles.C:
+			if err := w.CloseCandles(ctx, time.Now()); err != nil {
+				slog.Error("Failed to close candles", "error", err)
+			}
+		case <-maintenance.C:
+			if err := w.Maintain(ctx, time.Now()); err != nil {
+				slog.Error("History maintenance failed", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Close closes the database connection.
+func (w *Writer) Close() error {
+	sqlDB, err := w.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
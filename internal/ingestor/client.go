@@ -1,42 +1,44 @@
 package ingestor
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
+	"sync"
+	"time"
 
-	"github.com/IBM/sarama"
 	"github.com/gorilla/websocket"
+	"github.com/tiongMax/gostocks/internal/bus"
 	stock "github.com/tiongMax/gostocks/proto/stock"
-	"google.golang.org/protobuf/proto"
 )
 
 // Client represents the ingestor client.
 type Client struct {
-	apiKey   string
-	conn     *websocket.Conn
-	symbols  []string
-	producer sarama.SyncProducer
-	done     chan struct{}
+	apiKey  string
+	conn    *websocket.Conn
+	symbols []string
+	pub     bus.Publisher
+	done    chan struct{}
+
+	mu         sync.Mutex // Serializes WebSocket writes
+	subscribed map[string]bool
 }
 
-// NewClient creates a new ingestor client.
-func NewClient(apiKey string, symbols []string, kafkaBrokers []string) (*Client, error) {
-	config := sarama.NewConfig()
-	config.Producer.Return.Successes = true
-	config.Producer.RequiredAcks = sarama.WaitForAll
-	config.Producer.Retry.Max = 5
-
-	producer, err := sarama.NewSyncProducer(kafkaBrokers, config)
-	if err != nil {
-		return nil, err
-	}
+// SymbolSource lists the symbols that should be streamed, such as the
+// symbols.Registry.
+type SymbolSource interface {
+	List(ctx context.Context) ([]string, error)
+}
 
+// NewClient creates a new ingestor client publishing ticks through pub.
+func NewClient(apiKey string, symbols []string, pub bus.Publisher) *Client {
 	return &Client{
-		apiKey:   apiKey,
-		symbols:  symbols,
-		producer: producer,
-		done:     make(chan struct{}),
-	}, nil
+		apiKey:     apiKey,
+		symbols:    symbols,
+		pub:        pub,
+		done:       make(chan struct{}),
+		subscribed: make(map[string]bool),
+	}
 }
 
 // Start connects to WebSocket and starts the read loop.
@@ -48,9 +50,28 @@ func (c *Client) Start() error {
 	if err != nil {
 		return err
 	}
+	c.mu.Lock()
 	c.conn = conn
+	c.mu.Unlock()
 
-	for _, s := range c.symbols {
+	if err := c.Subscribe(c.symbols...); err != nil {
+		return err
+	}
+
+	go c.readLoop()
+	return nil
+}
+
+// Subscribe subscribes to symbols that are not streamed yet. It must be
+// called after Start.
+func (c *Client) Subscribe(symbols ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, s := range symbols {
+		if c.subscribed[s] {
+			continue
+		}
 		msg := map[string]interface{}{
 			"type":   "subscribe",
 			"symbol": s,
@@ -58,13 +79,35 @@ func (c *Client) Start() error {
 		if err := c.conn.WriteJSON(msg); err != nil {
 			return err
 		}
+		c.subscribed[s] = true
 		slog.Info("Subscribed to symbol", "symbol", s)
 	}
-
-	go c.readLoop()
 	return nil
 }
 
+// SyncSubscriptions polls source every interval and subscribes to the
+// symbols it lists, so that symbols added at runtime (for example to a
+// watchlist) start streaming without a restart. It returns when ctx is done.
+func (c *Client) SyncSubscriptions(ctx context.Context, source SymbolSource, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		symbols, err := source.List(ctx)
+		if err != nil {
+			slog.Warn("Failed to list symbols", "error", err)
+		} else if err := c.Subscribe(symbols...); err != nil {
+			slog.Error("Failed to subscribe to symbols", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (c *Client) readLoop() {
 	defer c.conn.Close()
 
@@ -106,25 +149,20 @@ func (c *Client) processTrades(trades []TradeData) {
 			Symbol:    trade.Symbol,
 			Price:     trade.Price,
 			Timestamp: trade.Timestamp,
+			Volume:    trade.Volume,
 		}
 
-		bytes, err := proto.Marshal(tick)
+		// Keyed by symbol so that each symbol's ticks stay in order
+		msg, err := bus.Encode("market_ticks", trade.Symbol, tick)
 		if err != nil {
 			slog.Error("Protobuf marshal error", "error", err)
 			continue
 		}
 
-		msg := &sarama.ProducerMessage{
-			Topic: "market_ticks",
-			Key:   sarama.StringEncoder(trade.Symbol),
-			Value: sarama.ByteEncoder(bytes),
-		}
-
-		partition, offset, err := c.producer.SendMessage(msg)
-		if err != nil {
-			slog.Error("Kafka send error", "error", err)
+		if err := c.pub.Publish(context.Background(), msg); err != nil {
+			slog.Error("Bus publish error", "error", err)
 		} else {
-			slog.Debug("Message sent", "partition", partition, "offset", offset)
+			slog.Debug("Message sent", "partition", msg.Partition, "offset", msg.Offset)
 		}
 	}
 }
@@ -133,14 +171,12 @@ func (c *Client) processTrades(trades []TradeData) {
 func (c *Client) Close() error {
 	close(c.done)
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.conn != nil {
 		// Send close message
 		c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
 		c.conn.Close()
 	}
-
-	if c.producer != nil {
-		return c.producer.Close()
-	}
 	return nil
 }
@@ -0,0 +1,32 @@
+package lag
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+)
+
+// NewDebugServer returns a server for addr with the lag of monitors as JSON
+// at /debug/lag, and at /debug/vars the process's expvar metrics, which
+// include the lag as consumer_lag.
+func NewDebugServer(addr string, monitors ...*Monitor) *http.Server {
+	snapshot := func() []Group {
+		groups := make([]Group, len(monitors))
+		for i, m := range monitors {
+			groups[i] = m.Snapshot()
+		}
+		return groups
+	}
+	// Published once per process; the monitors of the first server win
+	if expvar.Get("consumer_lag") == nil {
+		expvar.Publish("consumer_lag", expvar.Func(func() any { return snapshot() }))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /debug/lag", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string][]Group{"groups": snapshot()})
+	})
+	mux.Handle("GET /debug/vars", expvar.Handler())
+	return &http.Server{Addr: addr, Handler: mux}
+}
@@ -0,0 +1,249 @@
+// Package lag measures how far consumers are behind the bus, and tells them
+// when to degrade so that they catch up.
+package lag
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/bus"
+)
+
+// Thresholds are the lags past which a partition is degraded. It recovers
+// once its lags are below half of them again. A zero threshold is disabled.
+type Thresholds struct {
+	Offsets int64         // Messages behind the high water mark
+	Event   time.Duration // Age of the latest event processed
+}
+
+// DefaultThresholds degrade a partition 10000 messages or 30 seconds behind.
+var DefaultThresholds = Thresholds{Offsets: 10000, Event: 30 * time.Second}
+
+// ParseThresholds parses an offset threshold such as "10000" and an event
+// time threshold such as "30s". Either string may be empty to keep the
+// threshold of def, or "0" to disable it.
+func ParseThresholds(offsets, event string, def Thresholds) (Thresholds, error) {
+	t := def
+	if offsets != "" {
+		n, err := strconv.ParseInt(offsets, 10, 64)
+		if err != nil || n < 0 {
+			return Thresholds{}, fmt.Errorf("invalid offset lag threshold %q", offsets)
+		}
+		t.Offsets = n
+	}
+	if event != "" {
+		d, err := time.ParseDuration(event)
+		if event == "0" {
+			d, err = 0, nil
+		}
+		if err != nil || d < 0 {
+			return Thresholds{}, fmt.Errorf("invalid event time lag threshold %q", event)
+		}
+		t.Event = d
+	}
+	return t, nil
+}
+
+// exceeded reports whether either lag is above its threshold divided by div.
+func (t Thresholds) exceeded(offsets int64, event time.Duration, div int64) bool {
+	return (t.Offsets > 0 && offsets > t.Offsets/div) ||
+		(t.Event > 0 && event > t.Event/time.Duration(div))
+}
+
+// Partition is the lag of a claimed partition.
+type Partition struct {
+	Topic         string    `json:"topic"`
+	Partition     int32     `json:"partition"`
+	Offset        int64     `json:"offset"` // Next offset to process
+	HighWaterMark int64     `json:"high_water_mark"`
+	Lag           int64     `json:"lag"`          // Messages behind
+	EventLagMs    int64     `json:"event_lag_ms"` // Age of the latest event when it was processed
+	Degraded      bool      `json:"degraded"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Group is the lag of the partitions a consumer group claimed in this
+// process, with its counters.
+type Group struct {
+	Group      string           `json:"group"`
+	Partitions []Partition      `json:"partitions"`
+	Counters   map[string]int64 `json:"counters"`
+}
+
+type partitionKey struct {
+	topic     string
+	partition int32
+}
+
+// Monitor tracks the lag of the partitions claimed by a consumer group, and
+// counts what the group has done.
+type Monitor struct {
+	group      string
+	thresholds Thresholds
+
+	mu         sync.Mutex
+	partitions map[partitionKey]*Partition
+	counters   map[string]int64
+}
+
+// NewMonitor creates a Monitor for a consumer group that degrades partitions
+// past thresholds.
+func NewMonitor(group string, thresholds Thresholds) *Monitor {
+	return &Monitor{
+		group:      group,
+		thresholds: thresholds,
+		partitions: make(map[partitionKey]*Partition),
+		counters:   make(map[string]int64),
+	}
+}
+
+// Add adds n to a counter, such as the number of alerts triggered.
+func (m *Monitor) Add(counter string, n int64) {
+	m.mu.Lock()
+	m.counters[counter] += n
+	m.mu.Unlock()
+}
+
+// Snapshot returns the lag of the claimed partitions, in order, and the
+// counters.
+func (m *Monitor) Snapshot() Group {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g := Group{Group: m.group, Partitions: []Partition{}, Counters: maps.Clone(m.counters)}
+	for _, p := range m.partitions {
+		g.Partitions = append(g.Partitions, *p)
+	}
+	slices.SortFunc(g.Partitions, func(a, b Partition) int {
+		return cmp.Or(strings.Compare(a.Topic, b.Topic), cmp.Compare(a.Partition, b.Partition))
+	})
+	return g
+}
+
+// Track starts tracking a claim until its Tracker is released.
+func (m *Monitor) Track(claim bus.Claim) *Tracker {
+	key := partitionKey{topic: claim.Topic(), partition: claim.Partition()}
+	p := &Partition{
+		Topic:         claim.Topic(),
+		Partition:     claim.Partition(),
+		Offset:        claim.InitialOffset(),
+		HighWaterMark: claim.HighWaterMark(),
+		UpdatedAt:     time.Now(),
+	}
+	p.Lag = max(p.HighWaterMark-p.Offset, 0)
+
+	m.mu.Lock()
+	m.partitions[key] = p
+	m.mu.Unlock()
+	return &Tracker{m: m, claim: claim, key: key, p: p}
+}
+
+// Tracker tracks the lag of one claim. It is used by the claim's handler
+// only.
+type Tracker struct {
+	m     *Monitor
+	claim bus.Claim
+	key   partitionKey
+	p     *Partition // Guarded by m.mu
+}
+
+// Done records that msg was processed, carrying an event from the given
+// time, and returns whether the partition is degraded. A zero event time
+// leaves the event time lag as it was, and so does the offset for a message
+// before the latest one processed.
+func (t *Tracker) Done(msg *bus.Message, event time.Time) bool {
+	now := time.Now()
+	hwm := t.claim.HighWaterMark()
+
+	t.m.mu.Lock()
+	defer t.m.mu.Unlock()
+	t.m.counters["processed"]++
+
+	p := t.p
+	p.Offset = max(p.Offset, msg.Offset+1)
+	p.HighWaterMark = max(hwm, p.Offset)
+	p.Lag = p.HighWaterMark - p.Offset
+	if !event.IsZero() {
+		p.EventLagMs = max(now.Sub(event).Milliseconds(), 0)
+	}
+	p.UpdatedAt = now
+
+	eventLag := time.Duration(p.EventLagMs) * time.Millisecond
+	switch {
+	case !p.Degraded && t.m.thresholds.exceeded(p.Lag, eventLag, 1):
+		p.Degraded = true
+		slog.Warn("Consumer lagging, degrading", "group", t.m.group, "topic", p.Topic, "partition", p.Partition,
+			"lag", p.Lag, "event_lag_ms", p.EventLagMs)
+	case p.Degraded && !t.m.thresholds.exceeded(p.Lag, eventLag, 2):
+		p.Degraded = false
+		slog.Info("Consumer caught up", "group", t.m.group, "topic", p.Topic, "partition", p.Partition,
+			"lag", p.Lag, "event_lag_ms", p.EventLagMs)
+	}
+	return p.Degraded
+}
+
+// Conflated records that n messages were skipped for later ones.
+func (t *Tracker) Conflated(n int) {
+	if n > 0 {
+		t.m.Add("conflated", int64(n))
+	}
+}
+
+// Degraded reports whether the partition is degraded.
+func (t *Tracker) Degraded() bool {
+	t.m.mu.Lock()
+	defer t.m.mu.Unlock()
+	return t.p.Degraded
+}
+
+// Release stops tracking the claim.
+func (t *Tracker) Release() {
+	t.m.mu.Lock()
+	defer t.m.mu.Unlock()
+	if t.m.partitions[t.key] == t.p {
+		delete(t.m.partitions, t.key)
+	}
+}
+
+// Drain returns msg followed by the messages already waiting on messages,
+// up to limit in all, without blocking. It reports false if messages was
+// closed.
+func Drain(msg *bus.Message, messages <-chan *bus.Message, limit int) ([]*bus.Message, bool) {
+	msgs := []*bus.Message{msg}
+	for len(msgs) < limit {
+		select {
+		case next, ok := <-messages:
+			if !ok {
+				return msgs, false
+			}
+			msgs = append(msgs, next)
+		default:
+			return msgs, true
+		}
+	}
+	return msgs, true
+}
+
+// Superseded reports, for each message, whether a later one has the same
+// key, such as a later tick of the same symbol. Messages without a key are
+// never superseded.
+func Superseded(msgs []*bus.Message) []bool {
+	superseded := make([]bool, len(msgs))
+	seen := make(map[string]bool)
+	for i := len(msgs) - 1; i >= 0; i-- {
+		key := msgs[i].Key
+		if key == "" {
+			continue
+		}
+		superseded[i] = seen[key]
+		seen[key] = true
+	}
+	return superseded
+}
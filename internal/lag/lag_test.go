@@ -0,0 +1,109 @@
+package lag
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/bus"
+)
+
+// fakeClaim is a claim whose high water mark is set by the test.
+type fakeClaim struct {
+	hwm int64
+}
+
+func (c *fakeClaim) Topic() string                 { return "ticks" }
+func (c *fakeClaim) Partition() int32              { return 0 }
+func (c *fakeClaim) InitialOffset() int64          { return 0 }
+func (c *fakeClaim) HighWaterMark() int64          { return c.hwm }
+func (c *fakeClaim) Messages() <-chan *bus.Message { return nil }
+func (c *fakeClaim) Commit(*bus.Message)           {}
+func (c *fakeClaim) Context() context.Context      { return context.Background() }
+
+func TestTrackerDegrades(t *testing.T) {
+	m := NewMonitor("alerts", Thresholds{Offsets: 100, Event: time.Minute})
+	claim := &fakeClaim{hwm: 1000}
+	track := m.Track(claim)
+	now := time.Now()
+
+	steps := []struct {
+		offset int64
+		event  time.Time
+		want   bool
+	}{
+		{offset: 850, event: now, want: true},                        // 149 behind
+		{offset: 920, event: now, want: true},                        // 79 behind, above half
+		{offset: 960, event: now, want: false},                       // 39 behind
+		{offset: 965, event: now.Add(-2 * time.Minute), want: true},  // Old events
+		{offset: 970, event: now.Add(-45 * time.Second), want: true}, // Above half
+		{offset: 975, event: time.Time{}, want: true},                // Event lag kept
+		{offset: 980, event: now, want: false},
+	}
+	for _, s := range steps {
+		if got := track.Done(&bus.Message{Offset: s.offset}, s.event); got != s.want {
+			t.Errorf("Done(%d) degraded = %v, want %v", s.offset, got, s.want)
+		}
+	}
+
+	g := m.Snapshot()
+	if len(g.Partitions) != 1 || g.Partitions[0].Lag != 1000-981 || g.Partitions[0].Offset != 981 {
+		t.Errorf("Snapshot() partitions = %+v", g.Partitions)
+	}
+	if g.Counters["processed"] != int64(len(steps)) {
+		t.Errorf("processed = %d, want %d", g.Counters["processed"], len(steps))
+	}
+
+	track.Release()
+	if g := m.Snapshot(); len(g.Partitions) != 0 {
+		t.Errorf("after Release() partitions = %+v", g.Partitions)
+	}
+}
+
+func TestSuperseded(t *testing.T) {
+	var msgs []*bus.Message
+	for _, key := range []string{"AAPL", "MSFT", "AAPL", "", "", "MSFT", "AAPL"} {
+		msgs = append(msgs, &bus.Message{Key: key})
+	}
+	want := "[true true true false false false false]"
+	if got := fmt.Sprint(Superseded(msgs)); got != want {
+		t.Errorf("Superseded() = %s, want %s", got, want)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	ch := make(chan *bus.Message, 10)
+	for i := range 5 {
+		ch <- &bus.Message{Offset: int64(i + 1)}
+	}
+	msgs, ok := Drain(&bus.Message{}, ch, 4)
+	if len(msgs) != 4 || !ok {
+		t.Errorf("Drain() = %d messages, %v; want 4, true", len(msgs), ok)
+	}
+	close(ch)
+	msgs, ok = Drain(&bus.Message{}, ch, 10)
+	if len(msgs) != 3 || ok {
+		t.Errorf("Drain() of a closed channel = %d messages, %v; want 3, false", len(msgs), ok)
+	}
+}
+
+func TestParseThresholds(t *testing.T) {
+	tests := []struct {
+		offsets, event string
+		want           Thresholds
+		wantErr        bool
+	}{
+		{"", "", DefaultThresholds, false},
+		{"500", "10s", Thresholds{Offsets: 500, Event: 10 * time.Second}, false},
+		{"0", "0", Thresholds{}, false},
+		{"-1", "", Thresholds{}, true},
+		{"", "soon", Thresholds{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseThresholds(tt.offsets, tt.event, DefaultThresholds)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("ParseThresholds(%q, %q) = %+v, %v", tt.offsets, tt.event, got, err)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Package pgerror classifies the Postgres errors that the stores turn into
+// errors of their own.
+package pgerror
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the SQLSTATE of a unique constraint error.
+const uniqueViolation = "23505"
+
+// IsUniqueViolation reports whether err is a Postgres unique constraint error.
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
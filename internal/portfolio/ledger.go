@@ -0,0 +1,131 @@
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// quantityEpsilon absorbs floating-point residue when lots are closed.
+const quantityEpsilon = 1e-9
+
+// ErrInsufficientQuantity is returned when a sell exceeds the quantity held
+// at its execution time. Short positions are not supported.
+var ErrInsufficientQuantity = errors.New("sell exceeds the quantity held")
+
+// Lot is an open purchase. Its price includes the buy fee.
+type Lot struct {
+	Quantity float64   `json:"quantity"`
+	Price    float64   `json:"price"`
+	OpenedAt time.Time `json:"opened_at"`
+}
+
+// Holding is the position in one symbol after replaying its transactions.
+type Holding struct {
+	Symbol      string
+	Quantity    float64
+	RealizedPnL float64
+	Lots        []Lot // Oldest first
+}
+
+// CostBasis returns the total cost of the open lots.
+func (h *Holding) CostBasis() float64 {
+	var cost float64
+	for _, lot := range h.Lots {
+		cost += lot.Quantity * lot.Price
+	}
+	return cost
+}
+
+// Replay rebuilds holdings from transactions, which are applied in execution
+// order. Buys open a lot; sells close lots according to method (FIFO, LIFO
+// or AVERAGE) and realize the proceeds net of fees minus the cost closed.
+// Symbols whose positions were closed are kept for their realized P&L.
+func Replay(method string, txs []Transaction) (map[string]*Holding, error) {
+	ordered := make([]Transaction, len(txs))
+	copy(ordered, txs)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if !ordered[i].ExecutedAt.Equal(ordered[j].ExecutedAt) {
+			return ordered[i].ExecutedAt.Before(ordered[j].ExecutedAt)
+		}
+		// Unsaved transactions (ID 0) come after saved ones
+		if ordered[i].ID == 0 || ordered[j].ID == 0 {
+			return ordered[j].ID == 0 && ordered[i].ID != 0
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	holdings := make(map[string]*Holding)
+	for _, tx := range ordered {
+		h := holdings[tx.Symbol]
+		if h == nil {
+			h = &Holding{Symbol: tx.Symbol}
+			holdings[tx.Symbol] = h
+		}
+
+		switch tx.Side {
+		case SideBuy:
+			h.buy(method, tx)
+		case SideSell:
+			if err := h.sell(method, tx); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("transaction %d has unknown side %q", tx.ID, tx.Side)
+		}
+	}
+	return holdings, nil
+}
+
+// buy opens a lot, or merges it into the single average-cost lot.
+func (h *Holding) buy(method string, tx Transaction) {
+	lot := Lot{
+		Quantity: tx.Quantity,
+		Price:    (tx.Quantity*tx.Price + tx.Fee) / tx.Quantity,
+		OpenedAt: tx.ExecutedAt,
+	}
+	h.Quantity += tx.Quantity
+
+	if method == CostAverage && len(h.Lots) > 0 {
+		avg := &h.Lots[0]
+		avg.Price = (avg.Quantity*avg.Price + lot.Quantity*lot.Price) / (avg.Quantity + lot.Quantity)
+		avg.Quantity += lot.Quantity
+		return
+	}
+	h.Lots = append(h.Lots, lot)
+}
+
+// sell closes lots for the sold quantity and realizes the P&L.
+func (h *Holding) sell(method string, tx Transaction) error {
+	if tx.Quantity > h.Quantity+quantityEpsilon {
+		return fmt.Errorf("%w: %s on %s sells %g of %g", ErrInsufficientQuantity,
+			tx.Symbol, tx.ExecutedAt.UTC().Format(time.RFC3339), tx.Quantity, h.Quantity)
+	}
+
+	remaining := tx.Quantity
+	var cost float64
+	for remaining > quantityEpsilon {
+		i := 0
+		if method == CostLIFO {
+			i = len(h.Lots) - 1
+		}
+		lot := &h.Lots[i]
+
+		closed := min(remaining, lot.Quantity)
+		cost += closed * lot.Price
+		lot.Quantity -= closed
+		remaining -= closed
+		if lot.Quantity <= quantityEpsilon {
+			h.Lots = append(h.Lots[:i], h.Lots[i+1:]...)
+		}
+	}
+
+	h.Quantity -= tx.Quantity
+	if h.Quantity <= quantityEpsilon {
+		h.Quantity = 0
+		h.Lots = nil
+	}
+	h.RealizedPnL += tx.Quantity*tx.Price - tx.Fee - cost
+	return nil
+}
@@ -0,0 +1,73 @@
+package portfolio
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestReplay(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 15, 0, 0, 0, time.UTC) }
+	txs := []Transaction{
+		{ID: 3, Symbol: "AAPL", Side: SideSell, Quantity: 15, Price: 130, Fee: 5, ExecutedAt: day(3)},
+		{ID: 1, Symbol: "AAPL", Side: SideBuy, Quantity: 10, Price: 100, ExecutedAt: day(1)},
+		{ID: 2, Symbol: "AAPL", Side: SideBuy, Quantity: 10, Price: 120, Fee: 10, ExecutedAt: day(2)},
+	}
+
+	tests := []struct {
+		method       string
+		wantRealized float64
+		wantCost     float64
+		wantLots     int
+	}{
+		// Closes 10 @ 100 and 5 @ 121; 5 @ 121 remain
+		{CostFIFO, 1945 - 1000 - 605, 605, 1},
+		// Closes 10 @ 121 and 5 @ 100; 5 @ 100 remain
+		{CostLIFO, 1945 - 1210 - 500, 500, 1},
+		// Every share costs 110.5
+		{CostAverage, 1945 - 15*110.5, 5 * 110.5, 1},
+	}
+
+	for _, tt := range tests {
+		holdings, err := Replay(tt.method, txs)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.method, err)
+		}
+		h := holdings["AAPL"]
+		if h.Quantity != 5 {
+			t.Errorf("%s: quantity = %g, want 5", tt.method, h.Quantity)
+		}
+		if math.Abs(h.RealizedPnL-tt.wantRealized) > 1e-9 {
+			t.Errorf("%s: realized = %g, want %g", tt.method, h.RealizedPnL, tt.wantRealized)
+		}
+		if math.Abs(h.CostBasis()-tt.wantCost) > 1e-9 {
+			t.Errorf("%s: cost basis = %g, want %g", tt.method, h.CostBasis(), tt.wantCost)
+		}
+		if len(h.Lots) != tt.wantLots {
+			t.Errorf("%s: %d lots, want %d", tt.method, len(h.Lots), tt.wantLots)
+		}
+	}
+}
+
+func TestReplayRejectsOversell(t *testing.T) {
+	now := time.Now()
+	txs := []Transaction{
+		{ID: 1, Symbol: "MSFT", Side: SideBuy, Quantity: 5, Price: 300, ExecutedAt: now},
+		// Backdated before the buy
+		{ID: 2, Symbol: "MSFT", Side: SideSell, Quantity: 5, Price: 310, ExecutedAt: now.Add(-time.Hour)},
+	}
+	if _, err := Replay(CostFIFO, txs); !errors.Is(err, ErrInsufficientQuantity) {
+		t.Errorf("Replay() error = %v, want ErrInsufficientQuantity", err)
+	}
+
+	// Selling the whole position closes it
+	txs[1].ExecutedAt = now.Add(time.Hour)
+	holdings, err := Replay(CostFIFO, txs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := holdings["MSFT"]; h.Quantity != 0 || h.Lots != nil || h.RealizedPnL != 50 {
+		t.Errorf("closed holding = %+v", h)
+	}
+}
@@ -0,0 +1,78 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// QuoteSource provides the latest quotes of symbols. Symbols without a
+// price are left out of the result.
+type QuoteSource interface {
+	Quotes(ctx context.Context, symbols []string) (map[string]Quote, error)
+}
+
+// quoteFields are the fields of a symbol's price hash read for a quote.
+var quoteFields = []string{"price", "open", "event_time", "timestamp"}
+
+// RedisQuotes reads quotes from the price hashes the processor maintains.
+type RedisQuotes struct {
+	client *redis.Client
+}
+
+// NewRedisQuotes connects to Redis.
+func NewRedisQuotes(addr string) (*RedisQuotes, error) {
+	client := redis.NewClient(&redis.Options{Addr: addr})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	return &RedisQuotes{client: client}, nil
+}
+
+// Quotes reads the quotes of symbols in one round-trip.
+func (r *RedisQuotes) Quotes(ctx context.Context, symbols []string) (map[string]Quote, error) {
+	quotes := make(map[string]Quote, len(symbols))
+	if len(symbols) == 0 {
+		return quotes, nil
+	}
+
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.SliceCmd, len(symbols))
+	for i, s := range symbols {
+		cmds[i] = pipe.HMGet(ctx, "price:"+s, quoteFields...)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to read quotes: %w", err)
+	}
+
+	for i, cmd := range cmds {
+		var nums [4]float64
+		for j, v := range cmd.Val() {
+			if str, ok := v.(string); ok {
+				nums[j], _ = strconv.ParseFloat(str, 64)
+			}
+		}
+		if nums[0] <= 0 {
+			continue
+		}
+
+		eventTime := int64(nums[2])
+		if eventTime == 0 {
+			// Written before event times were stored
+			eventTime = int64(nums[3]) * 1000
+		}
+		quotes[symbols[i]] = Quote{Price: nums[0], Open: nums[1], Time: time.UnixMilli(eventTime)}
+	}
+	return quotes, nil
+}
+
+// Close closes the Redis connection.
+func (r *RedisQuotes) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,416 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/rpcerror"
+	"github.com/tiongMax/gostocks/internal/symbols"
+	pb "github.com/tiongMax/gostocks/proto/portfolio"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxNameLen caps portfolio names.
+	maxNameLen = 64
+
+	// watchRefreshInterval is how often idle WatchPortfolio streams get a
+	// fresh valuation. It also picks up transactions recorded through other
+	// instances and the next session's open.
+	watchRefreshInterval = 30 * time.Second
+
+	// maxClockSkew is how far in the future an execution time may be.
+	maxClockSkew = time.Minute
+)
+
+// Server implements the PortfolioService gRPC server.
+type Server struct {
+	pb.UnimplementedPortfolioServiceServer
+	store    *Store
+	tracker  *Tracker
+	updates  *signals // Keyed by portfolio ID
+	interval time.Duration
+}
+
+// NewServer creates a PortfolioService server. Valuations use the tracker's
+// quotes; WatchPortfolio streams send at most one valuation per interval.
+func NewServer(store *Store, tracker *Tracker, interval time.Duration) *Server {
+	return &Server{store: store, tracker: tracker, updates: newSignals(), interval: interval}
+}
+
+// CreatePortfolio creates an empty portfolio.
+func (s *Server) CreatePortfolio(ctx context.Context, req *pb.CreatePortfolioRequest) (*pb.Portfolio, error) {
+	if req.UserId <= 0 {
+		return nil, rpcerror.InvalidField("user_id", "must be positive")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, rpcerror.InvalidField("name", "is required")
+	}
+	if len(name) > maxNameLen {
+		return nil, rpcerror.InvalidField("name", fmt.Sprintf("must be at most %d characters", maxNameLen))
+	}
+
+	method := CostFIFO
+	switch req.CostMethod {
+	case pb.CostMethod_COST_METHOD_UNSPECIFIED, pb.CostMethod_FIFO:
+	case pb.CostMethod_LIFO:
+		method = CostLIFO
+	case pb.CostMethod_AVERAGE:
+		method = CostAverage
+	default:
+		return nil, rpcerror.InvalidField("cost_method", "must be FIFO, LIFO or AVERAGE")
+	}
+
+	p := &Portfolio{UserID: int(req.UserId), Name: name, CostMethod: method}
+	if err := s.store.CreatePortfolio(p); err != nil {
+		return nil, portfolioError(err)
+	}
+	return portfolioToProto(p), nil
+}
+
+// GetPortfolio retrieves a single portfolio.
+func (s *Server) GetPortfolio(ctx context.Context, req *pb.GetPortfolioRequest) (*pb.Portfolio, error) {
+	p, err := s.store.GetPortfolio(int(req.Id), int(req.UserId))
+	if err != nil {
+		return nil, portfolioError(err)
+	}
+	return portfolioToProto(p), nil
+}
+
+// ListPortfolios retrieves a user's portfolios, or every user's for user 0.
+func (s *Server) ListPortfolios(ctx context.Context, req *pb.ListPortfoliosRequest) (*pb.ListPortfoliosResponse, error) {
+	portfolios, err := s.store.ListPortfolios(int(req.UserId))
+	if err != nil {
+		return nil, portfolioError(err)
+	}
+
+	resp := &pb.ListPortfoliosResponse{Portfolios: make([]*pb.Portfolio, len(portfolios))}
+	for i := range portfolios {
+		resp.Portfolios[i] = portfolioToProto(&portfolios[i])
+	}
+	return resp, nil
+}
+
+// DeletePortfolio deletes a portfolio and its transactions.
+func (s *Server) DeletePortfolio(ctx context.Context, req *pb.DeletePortfolioRequest) (*pb.DeletePortfolioResponse, error) {
+	if err := s.store.DeletePortfolio(int(req.Id), int(req.UserId)); err != nil {
+		return nil, portfolioError(err)
+	}
+	s.updates.signal(strconv.Itoa(int(req.Id)))
+	return &pb.DeletePortfolioResponse{}, nil
+}
+
+// RecordTransaction adds a buy or sell to a portfolio.
+func (s *Server) RecordTransaction(ctx context.Context, req *pb.RecordTransactionRequest) (*pb.Transaction, error) {
+	symbol, err := symbols.Normalize(req.Symbol)
+	if err != nil {
+		return nil, rpcerror.InvalidField("symbol", err.Error())
+	}
+
+	var side string
+	switch req.Side {
+	case pb.Side_BUY:
+		side = SideBuy
+	case pb.Side_SELL:
+		side = SideSell
+	default:
+		return nil, rpcerror.InvalidField("side", "must be BUY or SELL")
+	}
+
+	if req.Quantity <= 0 {
+		return nil, rpcerror.InvalidField("quantity", "must be positive")
+	}
+	if req.Price <= 0 {
+		return nil, rpcerror.InvalidField("price", "must be positive")
+	}
+	if req.Fee < 0 {
+		return nil, rpcerror.InvalidField("fee", "must not be negative")
+	}
+
+	executedAt := time.Now()
+	switch {
+	case req.ExecutedAt < 0:
+		return nil, rpcerror.InvalidField("executed_at", "must not be negative")
+	case req.ExecutedAt > 0:
+		executedAt = time.UnixMilli(req.ExecutedAt)
+		if executedAt.After(time.Now().Add(maxClockSkew)) {
+			return nil, rpcerror.InvalidField("executed_at", "must not be in the future")
+		}
+	}
+
+	t := &Transaction{
+		PortfolioID: int(req.PortfolioId),
+		Symbol:      symbol,
+		Side:        side,
+		Quantity:    req.Quantity,
+		Price:       req.Price,
+		Fee:         req.Fee,
+		ExecutedAt:  executedAt,
+	}
+	if err := s.store.RecordTransaction(int(req.UserId), t); err != nil {
+		if errors.Is(err, ErrInsufficientQuantity) {
+			return nil, rpcerror.FieldError(codes.FailedPrecondition, "quantity", err.Error())
+		}
+		return nil, portfolioError(err)
+	}
+
+	s.updates.signal(strconv.Itoa(t.PortfolioID))
+	return transactionToProto(t), nil
+}
+
+// ListTransactions retrieves a portfolio's transactions in execution order.
+func (s *Server) ListTransactions(ctx context.Context, req *pb.ListTransactionsRequest) (*pb.ListTransactionsResponse, error) {
+	p, err := s.store.GetPortfolio(int(req.PortfolioId), int(req.UserId))
+	if err != nil {
+		return nil, portfolioError(err)
+	}
+	txs, err := s.store.ListTransactions(p.ID, strings.ToUpper(strings.TrimSpace(req.Symbol)))
+	if err != nil {
+		return nil, portfolioError(err)
+	}
+
+	resp := &pb.ListTransactionsResponse{Transactions: make([]*pb.Transaction, len(txs))}
+	for i := range txs {
+		resp.Transactions[i] = transactionToProto(&txs[i])
+	}
+	return resp, nil
+}
+
+// GetValuation values a portfolio at the latest prices.
+func (s *Server) GetValuation(ctx context.Context, req *pb.GetValuationRequest) (*pb.Valuation, error) {
+	p, err := s.store.GetPortfolio(int(req.PortfolioId), int(req.UserId))
+	if err != nil {
+		return nil, portfolioError(err)
+	}
+	v, err := Evaluate(ctx, s.store, s.tracker, p)
+	if err != nil {
+		return nil, portfolioError(err)
+	}
+	return valuationToProto(v), nil
+}
+
+// WatchPortfolio streams valuations of a portfolio: one now, then one after
+// every tick of a held symbol or recorded transaction, at most once per
+// interval, and one every watchRefreshInterval while idle.
+func (s *Server) WatchPortfolio(req *pb.GetValuationRequest, stream pb.PortfolioService_WatchPortfolioServer) error {
+	ctx := stream.Context()
+	p, err := s.store.GetPortfolio(int(req.PortfolioId), int(req.UserId))
+	if err != nil {
+		return portfolioError(err)
+	}
+
+	// Subscribe before the first valuation so no change can be missed
+	txWake, stopTx := s.updates.subscribe([]string{strconv.Itoa(p.ID)})
+	defer stopTx()
+
+	var tickWake <-chan struct{}
+	stopTicks := func() {}
+	defer func() { stopTicks() }()
+	var held []string
+
+	send := func() error {
+		// Re-read the portfolio so deletion ends the stream
+		if _, err := s.store.GetPortfolio(p.ID, int(req.UserId)); err != nil {
+			return portfolioError(err)
+		}
+		v, err := Evaluate(ctx, s.store, s.tracker, p)
+		if err != nil {
+			return portfolioError(err)
+		}
+
+		if open := openSymbols(v); !equalSymbols(open, held) {
+			stopTicks()
+			tickWake, stopTicks = s.tracker.Watch(open)
+			held = open
+		}
+		return stream.Send(valuationToProto(v))
+	}
+
+	if err := send(); err != nil {
+		return err
+	}
+	lastSent := time.Now()
+
+	refresh := time.NewTicker(watchRefreshInterval)
+	defer refresh.Stop()
+
+	for {
+		select {
+		case <-tickWake:
+		case <-txWake:
+		case <-refresh.C:
+		case <-ctx.Done():
+			return nil
+		}
+
+		// Coalesce bursts of ticks into one valuation per interval
+		if wait := s.interval - time.Since(lastSent); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		if err := send(); err != nil {
+			return err
+		}
+		lastSent = time.Now()
+	}
+}
+
+// Evaluate values a portfolio at the latest quotes.
+func Evaluate(ctx context.Context, store *Store, quotes QuoteSource, p *Portfolio) (*Valuation, error) {
+	txs, err := store.ListTransactions(p.ID, "")
+	if err != nil {
+		return nil, err
+	}
+	holdings, err := Replay(p.CostMethod, txs)
+	if err != nil {
+		return nil, err
+	}
+
+	var open []string
+	for symbol, h := range holdings {
+		if h.Quantity > 0 {
+			open = append(open, symbol)
+		}
+	}
+	prices, err := quotes.Quotes(ctx, open)
+	if err != nil {
+		return nil, err
+	}
+	return Value(p, holdings, prices, time.Now()), nil
+}
+
+// openSymbols returns the symbols of a valuation's open positions.
+func openSymbols(v *Valuation) []string {
+	var open []string
+	for _, pos := range v.Positions {
+		if pos.Quantity > 0 {
+			open = append(open, pos.Symbol)
+		}
+	}
+	return open
+}
+
+// equalSymbols reports whether two sorted symbol lists are equal.
+func equalSymbols(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// portfolioError converts a store error to a gRPC status.
+func portfolioError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return status.Error(codes.NotFound, "portfolio not found")
+	case errors.Is(err, ErrExists):
+		return rpcerror.FieldError(codes.AlreadyExists, "name", "is already used by another portfolio")
+	case errors.Is(err, ErrLimit):
+		return status.Errorf(codes.ResourceExhausted, "portfolios are limited to %d per user", MaxPortfoliosPerUser)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request cancelled")
+	}
+	return status.Errorf(codes.Internal, "portfolio operation failed: %v", err)
+}
+
+// costMethodToProto converts a cost method to its proto enum.
+func costMethodToProto(method string) pb.CostMethod {
+	switch method {
+	case CostLIFO:
+		return pb.CostMethod_LIFO
+	case CostAverage:
+		return pb.CostMethod_AVERAGE
+	default:
+		return pb.CostMethod_FIFO
+	}
+}
+
+// portfolioToProto converts a Portfolio to its proto form.
+func portfolioToProto(p *Portfolio) *pb.Portfolio {
+	return &pb.Portfolio{
+		Id:         int32(p.ID),
+		UserId:     int32(p.UserID),
+		Name:       p.Name,
+		CostMethod: costMethodToProto(p.CostMethod),
+		CreatedAt:  p.CreatedAt.Unix(),
+	}
+}
+
+// transactionToProto converts a Transaction to its proto form.
+func transactionToProto(t *Transaction) *pb.Transaction {
+	side := pb.Side_BUY
+	if t.Side == SideSell {
+		side = pb.Side_SELL
+	}
+	return &pb.Transaction{
+		Id:          t.ID,
+		PortfolioId: int32(t.PortfolioID),
+		Symbol:      t.Symbol,
+		Side:        side,
+		Quantity:    t.Quantity,
+		Price:       t.Price,
+		Fee:         t.Fee,
+		ExecutedAt:  t.ExecutedAt.UnixMilli(),
+		CreatedAt:   t.CreatedAt.Unix(),
+	}
+}
+
+// valuationToProto converts a Valuation to its proto form.
+func valuationToProto(v *Valuation) *pb.Valuation {
+	out := &pb.Valuation{
+		PortfolioId:      int32(v.PortfolioID),
+		CostMethod:       costMethodToProto(v.CostMethod),
+		Positions:        make([]*pb.Position, len(v.Positions)),
+		MarketValue:      v.MarketValue,
+		CostBasis:        v.CostBasis,
+		UnrealizedPnl:    v.UnrealizedPnL,
+		RealizedPnl:      v.RealizedPnL,
+		DayChange:        v.DayChange,
+		DayChangePercent: v.DayChangePercent,
+		Unpriced:         v.Unpriced,
+		Timestamp:        v.Time.UnixMilli(),
+	}
+	for i, pos := range v.Positions {
+		lots := make([]*pb.Lot, len(pos.Lots))
+		for j, lot := range pos.Lots {
+			lots[j] = &pb.Lot{Quantity: lot.Quantity, Price: lot.Price, OpenedAt: lot.OpenedAt.UnixMilli()}
+		}
+		var priceTime int64
+		if pos.Priced {
+			priceTime = pos.PriceTime.UnixMilli()
+		}
+		out.Positions[i] = &pb.Position{
+			Symbol:        pos.Symbol,
+			Quantity:      pos.Quantity,
+			AverageCost:   pos.AverageCost,
+			CostBasis:     pos.CostBasis,
+			LastPrice:     pos.LastPrice,
+			PriceTime:     priceTime,
+			MarketValue:   pos.MarketValue,
+			UnrealizedPnl: pos.UnrealizedPnL,
+			RealizedPnl:   pos.RealizedPnL,
+			DayChange:     pos.DayChange,
+			Weight:        pos.Weight,
+			Priced:        pos.Priced,
+			Lots:          lots,
+		}
+	}
+	return out
+}
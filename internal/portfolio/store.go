@@ -0,0 +1,205 @@
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tiongMax/gostocks/internal/pgerror"
+	"github.com/tiongMax/gostocks/internal/symbols"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxPortfoliosPerUser caps the portfolios a user may own.
+const MaxPortfoliosPerUser = 20
+
+var (
+	// ErrNotFound is returned when a portfolio does not exist or belongs to
+	// another user.
+	ErrNotFound = errors.New("portfolio not found")
+
+	// ErrExists is returned when the user already has a portfolio with the
+	// requested name.
+	ErrExists = errors.New("portfolio name already in use")
+
+	// ErrLimit is returned when a user already has MaxPortfoliosPerUser
+	// portfolios.
+	ErrLimit = errors.New("portfolio limit exceeded")
+)
+
+// portfolioLock is the advisory lock class held per user while creating a
+// portfolio, so that concurrent creates cannot exceed the per-user limit.
+const portfolioLock = 0x67735f70 // "gs_p"
+
+// Store persists portfolios and their transactions in Postgres.
+type Store struct {
+	db *gorm.DB
+}
+
+// NewStore connects to the database.
+func NewStore(connStr string) (*Store, error) {
+	db, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// AutoMigrate creates the portfolio tables.
+func (s *Store) AutoMigrate() error {
+	if err := s.db.AutoMigrate(&Portfolio{}, &Transaction{}, &symbols.Subscription{}); err != nil {
+		return fmt.Errorf("failed to migrate portfolio schema: %w", err)
+	}
+	return nil
+}
+
+// Close closes the database connection.
+func (s *Store) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// CreatePortfolio inserts a portfolio. Its ID and CreatedAt are filled in.
+func (s *Store) CreatePortfolio(p *Portfolio) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", portfolioLock, p.UserID).Error; err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&Portfolio{}).Where("user_id = ?", p.UserID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= MaxPortfoliosPerUser {
+			return ErrLimit
+		}
+		return tx.Create(p).Error
+	})
+	switch {
+	case errors.Is(err, ErrLimit):
+		return err
+	case pgerror.IsUniqueViolation(err):
+		return ErrExists
+	case err != nil:
+		return fmt.Errorf("failed to create portfolio: %w", err)
+	}
+	return nil
+}
+
+// GetPortfolio retrieves a portfolio. A userID of 0 matches any owner.
+func (s *Store) GetPortfolio(id, userID int) (*Portfolio, error) {
+	return getPortfolio(s.db, id, userID, false)
+}
+
+// getPortfolio loads a portfolio through db, locking its row when forUpdate
+// is set.
+func getPortfolio(db *gorm.DB, id, userID int, forUpdate bool) (*Portfolio, error) {
+	query := db.Where("id = ?", id)
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	var p Portfolio
+	if err := query.First(&p).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+	return &p, nil
+}
+
+// ListPortfolios retrieves a user's portfolios ordered by name, or every
+// user's if userID is 0.
+func (s *Store) ListPortfolios(userID int) ([]Portfolio, error) {
+	query := s.db.Order("user_id, name")
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	var portfolios []Portfolio
+	if err := query.Find(&portfolios).Error; err != nil {
+		return nil, fmt.Errorf("failed to list portfolios: %w", err)
+	}
+	return portfolios, nil
+}
+
+// DeletePortfolio deletes a portfolio and its transactions.
+func (s *Store) DeletePortfolio(id, userID int) error {
+	query := s.db.Where("id = ?", id)
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	result := query.Delete(&Portfolio{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete portfolio: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RecordTransaction inserts a transaction into a portfolio owned by userID
+// (0 = any owner) and registers its symbol for ingestion. The portfolio row
+// is locked while the symbol's history is replayed with the new transaction,
+// so that no sell, including a backdated one, can leave the position short.
+func (s *Store) RecordTransaction(userID int, t *Transaction) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		p, err := getPortfolio(tx, t.PortfolioID, userID, true)
+		if err != nil {
+			return err
+		}
+
+		var history []Transaction
+		if err := tx.Where("portfolio_id = ? AND symbol = ?", p.ID, t.Symbol).Find(&history).Error; err != nil {
+			return err
+		}
+		if _, err := Replay(p.CostMethod, append(history, *t)); err != nil {
+			return err
+		}
+		if err := tx.Create(t).Error; err != nil {
+			return err
+		}
+		return symbols.Register(tx, []string{t.Symbol})
+	})
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrInsufficientQuantity) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record transaction: %w", err)
+	}
+	return nil
+}
+
+// ListTransactions retrieves a portfolio's transactions in execution order,
+// optionally only those of one symbol.
+func (s *Store) ListTransactions(portfolioID int, symbol string) ([]Transaction, error) {
+	query := s.db.Where("portfolio_id = ?", portfolioID).Order("executed_at, id")
+	if symbol != "" {
+		query = query.Where("symbol = ?", symbol)
+	}
+
+	var txs []Transaction
+	if err := query.Find(&txs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	return txs, nil
+}
+
+// PortfoliosTrading returns the IDs of the portfolios among portfolioIDs
+// with transactions in symbol.
+func (s *Store) PortfoliosTrading(symbol string, portfolioIDs []int) ([]int, error) {
+	var ids []int
+	if err := s.db.Model(&Transaction{}).Where("symbol = ? AND portfolio_id IN ?", symbol, portfolioIDs).
+		Distinct().Pluck("portfolio_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to find portfolios trading %s: %w", symbol, err)
+	}
+	return ids, nil
+}
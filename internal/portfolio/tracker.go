@@ -0,0 +1,147 @@
+package portfolio
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/bus"
+	stock "github.com/tiongMax/gostocks/proto/stock"
+)
+
+// Tracker follows the tick stream so that valuations move with every tick.
+//
+// Every Tracker reads all partitions from the newest offset without a
+// consumer group: each service instance needs every tick for the streams it
+// serves, and nothing is committed because the latest ticks are only a cache
+// in front of the base quote source.
+type Tracker struct {
+	sub   bus.Subscriber
+	topic string
+	base  QuoteSource
+
+	mu     sync.RWMutex
+	latest map[string]Quote // Latest tick per symbol; Open is unset
+	wakes  *signals
+}
+
+// NewTracker creates a Tracker for topic that serves quotes from base,
+// overlaid with newer ticks.
+func NewTracker(sub bus.Subscriber, topic string, base QuoteSource) *Tracker {
+	return &Tracker{
+		sub:    sub,
+		topic:  topic,
+		base:   base,
+		latest: make(map[string]Quote),
+		wakes:  newSignals(),
+	}
+}
+
+// Start consumes every partition of the topic until ctx is done.
+func (t *Tracker) Start(ctx context.Context) error {
+	return t.sub.Subscribe(ctx, bus.Subscription{
+		Topics: []string{t.topic},
+		Start:  bus.Newest,
+		OnAssign: func(claims map[string][]int32) {
+			slog.Info("Tracking ticks", "topic", t.topic, "partitions", len(claims[t.topic]))
+		},
+	}, bus.EachProto(func(_ context.Context, _ *bus.Message, tick *stock.StockTick) error {
+		t.Observe(tick)
+		return nil
+	}))
+}
+
+// Observe records a tick and wakes the watchers of its symbol.
+func (t *Tracker) Observe(tick *stock.StockTick) {
+	tickTime := time.UnixMilli(tick.Timestamp)
+	if tick.Timestamp == 0 {
+		tickTime = time.Now()
+	}
+
+	t.mu.Lock()
+	if prev, ok := t.latest[tick.Symbol]; !ok || !tickTime.Before(prev.Time) {
+		t.latest[tick.Symbol] = Quote{Price: tick.Price, Time: tickTime}
+	}
+	t.mu.Unlock()
+
+	t.wakes.signal(tick.Symbol)
+}
+
+// Quotes returns the base quotes of symbols, with the price replaced by the
+// latest tick where that is newer.
+func (t *Tracker) Quotes(ctx context.Context, symbols []string) (map[string]Quote, error) {
+	quotes, err := t.base.Quotes(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, s := range symbols {
+		tick, ok := t.latest[s]
+		if !ok {
+			continue
+		}
+		if q, ok := quotes[s]; !ok || tick.Time.After(q.Time) {
+			q.Price, q.Time = tick.Price, tick.Time
+			quotes[s] = q
+		}
+	}
+	return quotes, nil
+}
+
+// Watch returns a wake-up that fires when any of symbols ticks. Call the
+// returned function when done.
+func (t *Tracker) Watch(symbols []string) (<-chan struct{}, func()) {
+	return t.wakes.subscribe(symbols)
+}
+
+// signals wakes subscribers by key. A subscriber's channel holds at most one
+// pending signal, so bursts coalesce into a single wake-up.
+type signals struct {
+	mu   sync.Mutex
+	subs map[string]map[chan struct{}]struct{}
+}
+
+func newSignals() *signals {
+	return &signals{subs: make(map[string]map[chan struct{}]struct{})}
+}
+
+// subscribe registers a channel for keys and returns it with its
+// unsubscribe function.
+func (s *signals) subscribe(keys []string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	for _, k := range keys {
+		if s.subs[k] == nil {
+			s.subs[k] = make(map[chan struct{}]struct{})
+		}
+		s.subs[k][ch] = struct{}{}
+	}
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, k := range keys {
+			delete(s.subs[k], ch)
+			if len(s.subs[k]) == 0 {
+				delete(s.subs, k)
+			}
+		}
+	}
+}
+
+// signal wakes every subscriber of key without blocking.
+func (s *signals) signal(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subs[key] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package portfolio
+
+import "time"
+
+// Cost methods decide which lots a sell closes.
+const (
+	CostFIFO    = "FIFO"
+	CostLIFO    = "LIFO"
+	CostAverage = "AVERAGE"
+)
+
+// Transaction sides.
+const (
+	SideBuy  = "BUY"
+	SideSell = "SELL"
+)
+
+// Portfolio is a set of positions owned by a user. Positions are not stored:
+// they are rebuilt from the transactions with the portfolio's cost method.
+type Portfolio struct {
+	ID         int       `json:"id" gorm:"primaryKey"`
+	UserID     int       `json:"user_id" gorm:"not null;uniqueIndex:idx_portfolio_user_name"`
+	Name       string    `json:"name" gorm:"not null;uniqueIndex:idx_portfolio_user_name"`
+	CostMethod string    `json:"cost_method" gorm:"not null;default:FIFO"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	Transactions []Transaction `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// Transaction is a buy or sell of a symbol in a portfolio.
+type Transaction struct {
+	ID          int64     `json:"id" gorm:"primaryKey"`
+	PortfolioID int       `json:"portfolio_id" gorm:"not null;index:idx_transaction_portfolio_symbol"`
+	Symbol      string    `json:"symbol" gorm:"not null;index:idx_transaction_portfolio_symbol;index:idx_transaction_symbol"`
+	Side        string    `json:"side" gorm:"not null"` // "BUY" or "SELL"
+	Quantity    float64   `json:"quantity" gorm:"not null"`
+	Price       float64   `json:"price" gorm:"not null"` // Per unit
+	Fee         float64   `json:"fee" gorm:"not null;default:0"`
+	ExecutedAt  time.Time `json:"executed_at" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Quote is the latest price of a symbol and its session open, which day
+// changes are measured against. Open is 0 if unknown.
+type Quote struct {
+	Price float64
+	Open  float64
+	Time  time.Time
+}
@@ -0,0 +1,108 @@
+package portfolio
+
+import (
+	"sort"
+	"time"
+)
+
+// PositionValue is the valuation of one holding.
+type PositionValue struct {
+	Symbol        string
+	Quantity      float64
+	AverageCost   float64
+	CostBasis     float64
+	LastPrice     float64
+	PriceTime     time.Time
+	MarketValue   float64
+	UnrealizedPnL float64
+	RealizedPnL   float64
+	DayChange     float64 // Since the session open
+	Weight        float64 // Share of the portfolio's market value
+	Priced        bool
+	Lots          []Lot
+}
+
+// Valuation is a point-in-time valuation of a portfolio. The totals other
+// than RealizedPnL only cover priced positions.
+type Valuation struct {
+	PortfolioID      int
+	CostMethod       string
+	Positions        []PositionValue // By symbol
+	MarketValue      float64
+	CostBasis        float64
+	UnrealizedPnL    float64
+	RealizedPnL      float64
+	DayChange        float64
+	DayChangePercent float64 // Relative to the value at the session open
+	Unpriced         []string
+	Time             time.Time
+}
+
+// Position returns the valuation of symbol, or nil if the portfolio never
+// held it.
+func (v *Valuation) Position(symbol string) *PositionValue {
+	for i := range v.Positions {
+		if v.Positions[i].Symbol == symbol {
+			return &v.Positions[i]
+		}
+	}
+	return nil
+}
+
+// Value values holdings at quotes. Holdings without a quote are listed as
+// unpriced and left out of the market value totals.
+func Value(p *Portfolio, holdings map[string]*Holding, quotes map[string]Quote, now time.Time) *Valuation {
+	v := &Valuation{
+		PortfolioID: p.ID,
+		CostMethod:  p.CostMethod,
+		Positions:   make([]PositionValue, 0, len(holdings)),
+		Unpriced:    []string{},
+		Time:        now,
+	}
+
+	for _, h := range holdings {
+		pos := PositionValue{
+			Symbol:      h.Symbol,
+			Quantity:    h.Quantity,
+			CostBasis:   h.CostBasis(),
+			RealizedPnL: h.RealizedPnL,
+			Lots:        h.Lots,
+		}
+		if h.Quantity > 0 {
+			pos.AverageCost = pos.CostBasis / h.Quantity
+		}
+		v.RealizedPnL += h.RealizedPnL
+
+		if q, ok := quotes[h.Symbol]; ok && q.Price > 0 {
+			pos.Priced = true
+			pos.LastPrice = q.Price
+			pos.PriceTime = q.Time
+			pos.MarketValue = h.Quantity * q.Price
+			pos.UnrealizedPnL = pos.MarketValue - pos.CostBasis
+			if q.Open > 0 {
+				pos.DayChange = h.Quantity * (q.Price - q.Open)
+			}
+
+			v.MarketValue += pos.MarketValue
+			v.CostBasis += pos.CostBasis
+			v.UnrealizedPnL += pos.UnrealizedPnL
+			v.DayChange += pos.DayChange
+		} else if h.Quantity > 0 {
+			v.Unpriced = append(v.Unpriced, h.Symbol)
+		}
+		v.Positions = append(v.Positions, pos)
+	}
+
+	sort.Slice(v.Positions, func(i, j int) bool { return v.Positions[i].Symbol < v.Positions[j].Symbol })
+	sort.Strings(v.Unpriced)
+
+	if v.MarketValue > 0 {
+		for i := range v.Positions {
+			v.Positions[i].Weight = v.Positions[i].MarketValue / v.MarketValue
+		}
+	}
+	if open := v.MarketValue - v.DayChange; open > 0 {
+		v.DayChangePercent = v.DayChange / open * 100
+	}
+	return v
+}
@@ -0,0 +1,49 @@
+package portfolio
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValue(t *testing.T) {
+	now := time.Now()
+	holdings := map[string]*Holding{
+		"AAPL": {Symbol: "AAPL", Quantity: 10, Lots: []Lot{{Quantity: 10, Price: 100}}},
+		"MSFT": {Symbol: "MSFT", Quantity: 5, Lots: []Lot{{Quantity: 5, Price: 200}}},
+		"NVDA": {Symbol: "NVDA", Quantity: 2, Lots: []Lot{{Quantity: 2, Price: 50}}},
+		"TSLA": {Symbol: "TSLA", RealizedPnL: -40},
+	}
+	quotes := map[string]Quote{
+		"AAPL": {Price: 110, Open: 105, Time: now},
+		"MSFT": {Price: 180, Time: now}, // Open unknown
+	}
+
+	v := Value(&Portfolio{ID: 1, CostMethod: CostFIFO}, holdings, quotes, now)
+
+	approx := func(name string, got, want float64) {
+		t.Helper()
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s = %g, want %g", name, got, want)
+		}
+	}
+	approx("market value", v.MarketValue, 1100+900)
+	approx("cost basis", v.CostBasis, 1000+1000)
+	approx("unrealized", v.UnrealizedPnL, 100-100)
+	approx("realized", v.RealizedPnL, -40)
+	approx("day change", v.DayChange, 50)
+	approx("day change percent", v.DayChangePercent, 50.0/1950*100)
+	approx("AAPL weight", v.Position("AAPL").Weight, 0.55)
+
+	if !reflect.DeepEqual(v.Unpriced, []string{"NVDA"}) {
+		t.Errorf("unpriced = %v, want [NVDA]", v.Unpriced)
+	}
+	var symbols []string
+	for _, pos := range v.Positions {
+		symbols = append(symbols, pos.Symbol)
+	}
+	if want := []string{"AAPL", "MSFT", "NVDA", "TSLA"}; !reflect.DeepEqual(symbols, want) {
+		t.Errorf("positions = %v, want %v", symbols, want)
+	}
+}
@@ -5,107 +5,274 @@ import (
 	"log/slog"
 	"time"
 
-	"github.com/IBM/sarama"
+	"github.com/tiongMax/gostocks/internal/bus"
+	"github.com/tiongMax/gostocks/internal/history"
+	"github.com/tiongMax/gostocks/internal/lag"
 	stock "github.com/tiongMax/gostocks/proto/stock"
-	"google.golang.org/protobuf/proto"
 )
 
-// Consumer manages the connection to Kafka and processing logic.
+const (
+	// historyBatchSize is the number of ticks written to the history store
+	// in one batch.
+	historyBatchSize = 500
+
+	// historyFlushInterval bounds how long a tick waits in a partial batch.
+	historyFlushInterval = time.Second
+
+	// historyMaxPending stops consumption of a partition while the history
+	// store is failing and this many ticks are waiting to be written.
+	historyMaxPending = 10 * historyBatchSize
+
+	// drainTimeout bounds the final history write when a partition is
+	// revoked, whose context is already done by then.
+	drainTimeout = 5 * time.Second
+)
+
+// Consumer manages the subscription to the tick topic and processing logic.
 type Consumer struct {
-	brokers []string
-	topic   string
-	groupID string
+	sub      bus.Subscriber
+	dlq      bus.Publisher
+	topic    string
+	writer   *RedisWriter
+	history  *history.Writer
+	watchdog *Watchdog
+	groupID  string
+	monitor  *lag.Monitor
+	workers  int
 }
 
-// NewConsumer creates a Consumer instance.
-func NewConsumer(brokers []string, topic string) *Consumer {
+// NewConsumer creates a Consumer instance that writes ticks to Redis and
+// the history store, reporting each arrival to the watchdog. Ticks that
+// cannot be decoded are dead-lettered through dlq. Partitions lagging past
+// thresholds are degraded. Each claimed partition writes to Redis on up to
+// workers goroutines.
+func NewConsumer(sub bus.Subscriber, dlq bus.Publisher, topic string, writer *RedisWriter, hist *history.Writer, watchdog *Watchdog, thresholds lag.Thresholds, workers int) *Consumer {
+	groupID := "processor-group"
 	return &Consumer{
-		brokers: brokers,
-		topic:   topic,
-		groupID: "processor-group",
+		sub:      sub,
+		dlq:      dlq,
+		topic:    topic,
+		writer:   writer,
+		history:  hist,
+		watchdog: watchdog,
+		groupID:  groupID,
+		monitor:  lag.NewMonitor(groupID, thresholds),
+		workers:  workers,
 	}
 }
 
-// Start initializes the Kafka consumer group and starts processing.
-func (c *Consumer) Start(ctx context.Context) error {
-	config := sarama.NewConfig()
-	config.Consumer.Return.Errors = true
-	// Start consuming from the oldest offset if no offset is committed
-	config.Consumer.Offsets.Initial = sarama.OffsetOldest
-
-	group, err := sarama.NewConsumerGroup(c.brokers, c.groupID, config)
-	if err != nil {
-		return err
-	}
-	defer group.Close()
-
-	slog.Info("Connected to Kafka Consumer Group", "group", c.groupID, "topic", c.topic)
-
-	// Handler for consumer group
-	handler := &GroupHandler{}
+// Lag returns the lag monitor of the consumer's partitions.
+func (c *Consumer) Lag() *lag.Monitor {
+	return c.monitor
+}
 
-	for {
-		// Consume claims runs the handler for the claimed partitions
-		if err := group.Consume(ctx, []string{c.topic}, handler); err != nil {
-			slog.Error("Error from consumer group", "error", err)
-			return err
-		}
-		// Check if context was cancelled
-		if ctx.Err() != nil {
-			return nil
-		}
+// Start joins the consumer group and processes ticks until ctx is done.
+func (c *Consumer) Start(ctx context.Context) error {
+	handler := &GroupHandler{
+		writer:   c.writer,
+		history:  c.history,
+		watchdog: c.watchdog,
+		dlq:      bus.NewDeadLetter(c.dlq, c.groupID, bus.DefaultRetry),
+		monitor:  c.monitor,
+		workers:  c.workers,
 	}
+	return c.sub.Subscribe(ctx, bus.Subscription{
+		Topics: []string{c.topic},
+		Group:  c.groupID,
+		// Start consuming from the oldest offset if no offset is committed
+		Start: bus.Oldest,
+		OnAssign: func(claims map[string][]int32) {
+			slog.Info("Consumer group session setup", "claims", claims)
+		},
+		OnRevoke: func(claims map[string][]int32) {
+			slog.Info("Consumer group session cleanup", "claims", claims)
+		},
+	}, handler)
 }
 
-// GroupHandler implements sarama.ConsumerGroupHandler
+// GroupHandler processes the ticks of the claimed partitions.
 type GroupHandler struct {
-	msgCount int
+	writer   *RedisWriter
+	history  *history.Writer
+	watchdog *Watchdog
+	dlq      *bus.DeadLetter
+	monitor  *lag.Monitor
+	workers  int
 }
 
-func (h *GroupHandler) Setup(sarama.ConsumerGroupSession) error {
-	slog.Info("Consumer group session setup")
-	return nil
+// redisWrite writes the latest tick of a symbol to Redis, merged with the
+// ticks it supersedes.
+type redisWrite struct {
+	ticks    []*stock.StockTick
+	msgs     []*bus.Message
+	ingested time.Time
 }
 
-func (h *GroupHandler) Cleanup(sarama.ConsumerGroupSession) error {
-	slog.Info("Consumer group session cleanup")
-	return nil
-}
+// Consume updates Redis for every tick and writes ticks to the history
+// store in batches. Redis writes run on a pool of workers, concurrently
+// across symbols and in order for each. Offsets are marked only once a
+// batch is stored and every tick before them is written to Redis; ticks
+// redelivered after a crash or rebalance are deduplicated by the store.
+// Ticks that cannot be decoded are dead-lettered and committed with the
+// batch. When the claim is revoked, the batch is still stored and the ticks
+// written to Redis by then are committed.
+//
+// While the partition is degraded, the ticks waiting to be processed are
+// taken at once and only the latest of each symbol is written to Redis and
+// published, carrying the day stats of the others, which it would overwrite
+// straight away. History still gets every tick.
+func (h *GroupHandler) Consume(claim bus.Claim) error {
+	track := h.monitor.Track(claim)
+	defer track.Release()
 
-func (h *GroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	// Report throughput
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
+	// A failed write is not retried: the next tick for the symbol
+	// overwrites the price anyway
+	pool := bus.NewPool(claim.Context(), h.workers, func(ctx context.Context, w *redisWrite) error {
+		if err := h.writer.WriteMerged(ctx, w.ticks, w.ingested); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			slog.Error("Error writing price to Redis", "symbol", w.ticks[0].Symbol, "error", err)
+		}
+		return nil
+	})
 
-	msgChan := claim.Messages()
+	flushTicker := time.NewTicker(historyFlushInterval)
+	defer flushTicker.Stop()
+
+	var batch []history.Tick
+	flush := func(ctx context.Context) {
+		// Every tick up to the committable one is in the batch or was
+		// stored before
+		done := pool.Committable()
+		if len(batch) > 0 {
+			if err := h.history.WriteTicks(ctx, batch); err != nil {
+				// Kept for the next flush; the offset is not marked
+				slog.Error("Error writing ticks to history", "count", len(batch), "error", err)
+				return
+			}
+			batch = batch[:0]
+		}
+		if done != nil {
+			claim.Commit(done)
+		}
+	}
+	defer func() {
+		pool.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		defer cancel()
+		flush(ctx)
+	}()
 
 	for {
+		msgChan := claim.Messages()
+		if len(batch) >= historyMaxPending {
+			// Back-pressure: stop reading until the store recovers
+			msgChan = nil
+		}
+
 		select {
 		case msg, ok := <-msgChan:
 			if !ok {
 				return nil
 			}
-
-			// Process message
-			var tick stock.StockTick
-			if err := proto.Unmarshal(msg.Value, &tick); err != nil {
-				slog.Error("Error unmarshaling message", "error", err)
-				continue
+			msgs := []*bus.Message{msg}
+			if track.Degraded() {
+				msgs, ok = lag.Drain(msg, msgChan, historyBatchSize)
 			}
+			own := msgs[:0]
+			for _, msg := range msgs {
+				pool.Track(msg)
+				if h.dlq.Owns(msg) {
+					own = append(own, msg)
+					continue
+				}
+				// Redriven to another group
+				pool.Done(msg)
+				track.Done(msg, time.Time{})
+			}
+			msgs = own
+			superseded := lag.Superseded(msgs)
+			pending := make(map[string]*redisWrite) // Superseded ticks by key
+
+			for i, msg := range msgs {
+				// Process message
+				tick, err := bus.Decode[stock.StockTick](msg)
+				if err != nil {
+					if h.dlq.Reject(claim.Context(), msg, err) != nil {
+						return nil
+					}
+					pool.Done(msg)
+					track.Done(msg, time.Time{})
+					// The ticks it superseded are written on their own
+					if w, found := pending[msg.Key]; found {
+						delete(pending, msg.Key)
+						if !pool.Submit(msg.Key, w, w.msgs...) {
+							return nil
+						}
+					}
+					continue
+				}
 
-			h.msgCount++
+				// The message timestamp is when the ingestor produced the tick
+				ingested := msg.Timestamp
+				if ingested.IsZero() {
+					ingested = time.Now()
+				}
+				h.watchdog.Observe(tick.Symbol, time.Now())
 
-			// Mark message as marked
-			session.MarkMessage(msg, "")
+				// Update the speed layer, merging superseded ticks into the
+				// latest
+				w, found := pending[msg.Key]
+				if !found {
+					w = &redisWrite{}
+				}
+				w.ticks, w.msgs, w.ingested = append(w.ticks, tick), append(w.msgs, msg), ingested
+				if superseded[i] {
+					pending[msg.Key] = w
+					track.Conflated(1)
+				} else {
+					delete(pending, msg.Key)
+					if !pool.Submit(msg.Key, w, w.msgs...) {
+						return nil
+					}
+				}
 
-		case <-ticker.C:
-			if h.msgCount > 0 {
-				slog.Info("Throughput", "msgs_per_sec", h.msgCount)
-				h.msgCount = 0
+				// The offset orders ticks of a symbol, which is keyed to a
+				// single partition, and is stable across redelivery and
+				// redrive.
+				_, offset := bus.Origin(msg)
+				batch = append(batch, history.Tick{
+					Symbol: tick.Symbol,
+					Time:   time.UnixMilli(tick.Timestamp),
+					Seq:    offset,
+					Price:  tick.Price,
+					Volume: tick.Volume,
+				})
+				track.Done(msg, eventTime(tick, ingested))
 			}
 
-		case <-session.Context().Done():
+			if !ok {
+				return nil
+			}
+			if len(batch) >= historyBatchSize {
+				flush(claim.Context())
+			}
+
+		case <-flushTicker.C:
+			flush(claim.Context())
+
+		case <-claim.Context().Done():
 			return nil
 		}
 	}
 }
+
+// eventTime returns when a tick traded, or when it was ingested if the
+// feed did not say.
+func eventTime(tick *stock.StockTick, ingested time.Time) time.Time {
+	if tick.Timestamp == 0 {
+		return ingested
+	}
+	return time.UnixMilli(tick.Timestamp)
+}
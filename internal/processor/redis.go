@@ -0,0 +1,161 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	stock "github.com/tiongMax/gostocks/proto/stock"
+)
+
+// PriceChannelPrefix prefixes the Redis pub/sub channel of each symbol,
+// e.g. "prices:AAPL". The gateway subscribes to "prices:*" for live fan-out.
+const PriceChannelPrefix = "prices:"
+
+// SymbolStatusChannel carries symbol_stale and symbol_fresh events.
+const SymbolStatusChannel = "symbol_status"
+
+// updatePrice stores a tick in the symbol's price hash, maintains the day's
+// open/high/low/volume, and publishes the result, atomically. A tick older
+// than the one stored, such as a redriven dead letter, is ignored: it would
+// roll the price back and count its volume twice. It returns 1 if the tick
+// was stored, 0 if it was ignored.
+//
+// KEYS[1] is the price hash. KEYS[2] is the timestamp key of the old
+// string layout, removed when a symbol is first written as a hash.
+// ARGV: symbol, price, timestamp (Unix seconds), volume, trading day, channel,
+// event time and ingest time (Unix milliseconds), then the first price, high,
+// low and total volume of the ticks merged into this one.
+var updatePrice = redis.NewScript(`
+local key = KEYS[1]
+if redis.call('TYPE', key).ok ~= 'hash' then
+	redis.call('DEL', key, KEYS[2])
+end
+
+local stored = tonumber(redis.call('HGET', key, 'event_time'))
+if stored and tonumber(ARGV[7]) < stored then
+	return 0
+end
+
+local price = tonumber(ARGV[2])
+local volume = tonumber(ARGV[4])
+local open, high, low, dayVolume = tonumber(ARGV[9]), tonumber(ARGV[10]), tonumber(ARGV[11]), tonumber(ARGV[12])
+
+local day = redis.call('HMGET', key, 'day', 'open', 'high', 'low', 'day_volume')
+if day[1] == ARGV[5] then
+	open = tonumber(day[2])
+	high = math.max(tonumber(day[3]), high)
+	low = math.min(tonumber(day[4]), low)
+	dayVolume = tonumber(day[5]) + dayVolume
+end
+
+redis.call('HSET', key,
+	'price', ARGV[2], 'timestamp', ARGV[3], 'volume', ARGV[4], 'day', ARGV[5],
+	'open', open, 'high', high, 'low', low, 'day_volume', dayVolume,
+	'event_time', ARGV[7], 'ingest_time', ARGV[8])
+
+redis.call('PUBLISH', ARGV[6], cjson.encode({
+	symbol = ARGV[1], price = price, timestamp = tonumber(ARGV[3]), volume = volume,
+	open = open, high = high, low = low, day_volume = dayVolume,
+	event_time = tonumber(ARGV[7]), ingest_time = tonumber(ARGV[8]),
+}))
+return 1
+`)
+
+// RedisWriter keeps one hash per symbol in Redis ("price:AAPL") holding the
+// latest price, timestamp and volume together with the trading day's stats,
+// and publishes every tick for live streaming.
+type RedisWriter struct {
+	client   *redis.Client
+	location *time.Location // Timezone that decides the trading day
+}
+
+// NewRedisWriter creates a new Redis connection for the speed layer.
+// Day stats reset at midnight in loc.
+func NewRedisWriter(addr string, loc *time.Location) (*RedisWriter, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr: addr,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &RedisWriter{client: client, location: loc}, nil
+}
+
+// Write stores the tick in the symbol's price hash and publishes it,
+// in a single round-trip. ingested is when the tick entered the pipeline.
+func (w *RedisWriter) Write(ctx context.Context, tick *stock.StockTick, ingested time.Time) error {
+	return w.WriteMerged(ctx, []*stock.StockTick{tick}, ingested)
+}
+
+// WriteMerged stores consecutive ticks of one symbol as if each had been
+// written, but stores and publishes only the latest of each trading day,
+// with the day stats of all of them. Ticks older than the symbol's stored
+// price are ignored.
+func (w *RedisWriter) WriteMerged(ctx context.Context, ticks []*stock.StockTick, ingested time.Time) error {
+	for len(ticks) > 0 {
+		day := w.day(ticks[0])
+		n := 1
+		for n < len(ticks) && w.day(ticks[n]) == day {
+			n++
+		}
+		if err := w.write(ctx, ticks[:n], day, ingested); err != nil {
+			return err
+		}
+		ticks = ticks[n:]
+	}
+	return nil
+}
+
+// day returns the trading day of a tick.
+func (w *RedisWriter) day(tick *stock.StockTick) string {
+	return time.UnixMilli(tick.Timestamp).In(w.location).Format(time.DateOnly)
+}
+
+// write stores the latest of ticks of one trading day.
+func (w *RedisWriter) write(ctx context.Context, ticks []*stock.StockTick, day string, ingested time.Time) error {
+	tick := ticks[len(ticks)-1]
+	high, low, volume := tick.Price, tick.Price, 0.0
+	for _, t := range ticks {
+		high, low, volume = max(high, t.Price), min(low, t.Price), volume+t.Volume
+	}
+
+	ts := time.UnixMilli(tick.Timestamp)
+	key := "price:" + tick.Symbol
+
+	err := updatePrice.Run(ctx, w.client,
+		[]string{key, key + ":timestamp"},
+		tick.Symbol, tick.Price, ts.Unix(), tick.Volume,
+		day, PriceChannelPrefix+tick.Symbol,
+		tick.Timestamp, ingested.UnixMilli(),
+		ticks[0].Price, high, low, volume,
+	).Err()
+	if err != nil {
+		return fmt.Errorf("failed to write price: %w", err)
+	}
+	return nil
+}
+
+// PublishStatus announces a symbol freshness change on SymbolStatusChannel.
+func (w *RedisWriter) PublishStatus(ctx context.Context, status SymbolStatus) error {
+	payload, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	if err := w.client.Publish(ctx, SymbolStatusChannel, payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish symbol status: %w", err)
+	}
+	return nil
+}
+
+// Close closes the Redis connection.
+func (w *RedisWriter) Close() error {
+	return w.client.Close()
+}
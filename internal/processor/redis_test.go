@@ -0,0 +1,86 @@
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	stock "github.com/tiongMax/gostocks/proto/stock"
+)
+
+func newTestWriter(t *testing.T) (*RedisWriter, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	w, err := NewRedisWriter(mr.Addr(), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { w.Close() })
+	return w, mr
+}
+
+func TestWriteMerged(t *testing.T) {
+	day := time.Date(2026, 3, 2, 23, 59, 0, 0, time.UTC)
+	var ticks []*stock.StockTick
+	for i, price := range []float64{100, 104, 97, 101, 99, 103} {
+		ticks = append(ticks, &stock.StockTick{
+			Symbol:    "AAPL",
+			Price:     price,
+			Volume:    float64(10 * (i + 1)),
+			Timestamp: day.Add(time.Duration(i) * 20 * time.Second).UnixMilli(), // The last three ticks are the next day
+		})
+	}
+	ctx := context.Background()
+	ingested := day.Add(2 * time.Minute)
+
+	// Writing the ticks merged leaves the same price hash as writing each
+	one, oneRedis := newTestWriter(t)
+	for _, tick := range ticks {
+		if err := one.Write(ctx, tick, ingested); err != nil {
+			t.Fatal(err)
+		}
+	}
+	merged, mergedRedis := newTestWriter(t)
+	if err := merged.WriteMerged(ctx, ticks, ingested); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, field := range []string{"price", "timestamp", "volume", "day", "open", "high", "low", "day_volume", "event_time"} {
+		want := oneRedis.HGet("price:AAPL", field)
+		if got := mergedRedis.HGet("price:AAPL", field); got != want {
+			t.Errorf("%s = %s, want %s", field, got, want)
+		}
+	}
+	if got := mergedRedis.HGet("price:AAPL", "day_volume"); got != "150" {
+		t.Errorf("day_volume = %s, want 150 for the next day's three ticks", got)
+	}
+}
+
+func TestWriteIgnoresOlderTicks(t *testing.T) {
+	w, mr := newTestWriter(t)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+
+	latest := &stock.StockTick{Symbol: "AAPL", Price: 101, Volume: 10, Timestamp: now.UnixMilli()}
+	if err := w.Write(ctx, latest, now); err != nil {
+		t.Fatal(err)
+	}
+
+	// A redriven tick from a minute earlier neither rolls the price back nor
+	// counts its volume again
+	redriven := &stock.StockTick{Symbol: "AAPL", Price: 95, Volume: 500, Timestamp: now.Add(-time.Minute).UnixMilli()}
+	if err := w.Write(ctx, redriven, now); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := mr.HGet("price:AAPL", "price"); got != "101" {
+		t.Errorf("price = %s, want 101", got)
+	}
+	if got := mr.HGet("price:AAPL", "day_volume"); got != "10" {
+		t.Errorf("day_volume = %s, want 10", got)
+	}
+	if got := mr.HGet("price:AAPL", "low"); got != "101" {
+		t.Errorf("low = %s, want 101", got)
+	}
+}
@@ -0,0 +1,105 @@
+package processor
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/freshness"
+)
+
+// SymbolStatus is published when a symbol stops ticking ("symbol_stale")
+// and when it resumes ("symbol_fresh").
+type SymbolStatus struct {
+	Type      string `json:"type"`
+	Symbol    string `json:"symbol"`
+	LastTick  int64  `json:"last_tick"` // Arrival of the latest tick, Unix ms
+	AgeMs     int64  `json:"age_ms"`
+	SLAMs     int64  `json:"sla_ms"`
+	Timestamp int64  `json:"timestamp"` // Unix ms
+}
+
+// Watchdog watches every symbol the processor has seen and reports the ones
+// that have not ticked within their freshness SLA. It goes by arrival time,
+// so replaying old ticks after an outage does not mark symbols stale.
+type Watchdog struct {
+	sla      freshness.SLA
+	writer   *RedisWriter
+	interval time.Duration
+
+	mu    sync.Mutex
+	last  map[string]time.Time // symbol -> arrival of the latest tick
+	stale map[string]bool
+}
+
+// NewWatchdog creates a Watchdog checking every interval.
+func NewWatchdog(sla freshness.SLA, writer *RedisWriter, interval time.Duration) *Watchdog {
+	return &Watchdog{
+		sla:      sla,
+		writer:   writer,
+		interval: interval,
+		last:     make(map[string]time.Time),
+		stale:    make(map[string]bool),
+	}
+}
+
+// Observe records that a tick for symbol arrived at t.
+func (w *Watchdog) Observe(symbol string, t time.Time) {
+	w.mu.Lock()
+	w.last[symbol] = t
+	w.mu.Unlock()
+}
+
+// Run publishes status changes until the context is cancelled.
+func (w *Watchdog) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			for _, status := range w.check(now) {
+				if status.Type == "symbol_stale" {
+					slog.Warn("Symbol stopped ticking", "symbol", status.Symbol, "age_ms", status.AgeMs, "sla_ms", status.SLAMs)
+				} else {
+					slog.Info("Symbol ticking again", "symbol", status.Symbol)
+				}
+				if err := w.writer.PublishStatus(ctx, status); err != nil {
+					slog.Error("Error publishing symbol status", "symbol", status.Symbol, "error", err)
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check returns the symbols whose staleness changed since the last check.
+func (w *Watchdog) check(now time.Time) []SymbolStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var changed []SymbolStatus
+	for symbol, last := range w.last {
+		stale := w.sla.Stale(symbol, last, now)
+		if stale == w.stale[symbol] {
+			continue
+		}
+		w.stale[symbol] = stale
+
+		status := SymbolStatus{
+			Type:      "symbol_fresh",
+			Symbol:    symbol,
+			LastTick:  last.UnixMilli(),
+			AgeMs:     now.Sub(last).Milliseconds(),
+			SLAMs:     w.sla.For(symbol).Milliseconds(),
+			Timestamp: now.UnixMilli(),
+		}
+		if stale {
+			status.Type = "symbol_stale"
+		}
+		changed = append(changed, status)
+	}
+	return changed
+}
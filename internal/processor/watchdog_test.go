@@ -0,0 +1,49 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/freshness"
+)
+
+func TestWatchdogCheck(t *testing.T) {
+	sla := freshness.NewSLA(time.Minute, map[string]time.Duration{"BTC": 10 * time.Second})
+	w := NewWatchdog(sla, nil, time.Second)
+
+	start := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	w.Observe("AAPL", start)
+	w.Observe("BTC", start)
+
+	types := func(statuses []SymbolStatus) map[string]string {
+		m := make(map[string]string)
+		for _, s := range statuses {
+			m[s.Symbol] = s.Type
+		}
+		return m
+	}
+
+	// Fresh symbols produce no events
+	if got := w.check(start.Add(5 * time.Second)); len(got) != 0 {
+		t.Fatalf("check() = %v, want no changes", got)
+	}
+
+	// BTC passes its override first
+	got := types(w.check(start.Add(30 * time.Second)))
+	if len(got) != 1 || got["BTC"] != "symbol_stale" {
+		t.Fatalf("check() = %v, want BTC stale", got)
+	}
+
+	// Staleness is reported once
+	got = types(w.check(start.Add(40 * time.Second)))
+	if len(got) != 0 {
+		t.Fatalf("check() = %v, want no changes", got)
+	}
+
+	// AAPL goes stale, BTC recovers
+	w.Observe("BTC", start.Add(65*time.Second))
+	got = types(w.check(start.Add(70 * time.Second)))
+	if len(got) != 2 || got["AAPL"] != "symbol_stale" || got["BTC"] != "symbol_fresh" {
+		t.Fatalf("check() = %v, want AAPL stale and BTC fresh", got)
+	}
+}
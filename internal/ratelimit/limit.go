@@ -0,0 +1,105 @@
+// Package ratelimit implements token-bucket rate limiting for the gateway.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit is a token bucket: Burst tokens at most, refilled at Rate per second.
+// The zero Limit means unlimited.
+type Limit struct {
+	Rate   float64
+	Burst  int
+	Period time.Duration // Unit the limit was written in, reported in RateLimit-Policy
+}
+
+// Unlimited reports whether the limit is disabled.
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+// ParseLimit parses "<n>/<unit>[:<burst>]", e.g. "20/s", "30/m:10" or
+// "1000/h". The burst defaults to n. "off" or "" means unlimited.
+func ParseLimit(v string) (Limit, error) {
+	if v == "" || v == "off" {
+		return Limit{}, nil
+	}
+
+	spec, burstStr, hasBurst := strings.Cut(v, ":")
+	nStr, unit, ok := strings.Cut(spec, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q", v)
+	}
+	n, err := strconv.Atoi(nStr)
+	if err != nil || n <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q", v)
+	}
+
+	var period time.Duration
+	switch unit {
+	case "s":
+		period = time.Second
+	case "m":
+		period = time.Minute
+	case "h":
+		period = time.Hour
+	default:
+		return Limit{}, fmt.Errorf("invalid rate limit unit in %q", v)
+	}
+
+	burst := n
+	if hasBurst {
+		burst, err = strconv.Atoi(burstStr)
+		if err != nil || burst <= 0 {
+			return Limit{}, fmt.Errorf("invalid burst in %q", v)
+		}
+	}
+
+	return Limit{Rate: float64(n) / period.Seconds(), Burst: burst, Period: period}, nil
+}
+
+// Result is the outcome of taking a token.
+type Result struct {
+	Allowed    bool
+	Remaining  int           // Whole tokens left
+	Reset      time.Duration // Until the bucket is full again
+	RetryAfter time.Duration // Until a token is available; zero if allowed
+}
+
+// Limiter takes tokens from named buckets.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// take refills a bucket holding tokens after elapsed and takes one token.
+// It returns the new token count and the result.
+func take(tokens float64, elapsed time.Duration, limit Limit) (float64, Result) {
+	tokens = math.Min(float64(limit.Burst), tokens+math.Max(0, elapsed.Seconds())*limit.Rate)
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+	return tokens, result(allowed, tokens, limit)
+}
+
+// result describes a bucket left holding tokens.
+func result(allowed bool, tokens float64, limit Limit) Result {
+	res := Result{
+		Allowed:   allowed,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(limit.Burst) - tokens) / limit.Rate),
+	}
+	if !allowed {
+		res.RetryAfter = seconds((1 - tokens) / limit.Rate)
+	}
+	return res
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
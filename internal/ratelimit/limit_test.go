@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in        string
+		wantRate  float64
+		wantBurst int
+		wantErr   bool
+	}{
+		{"20/s", 20, 20, false},
+		{"30/m:10", 0.5, 10, false},
+		{"3600/h", 1, 3600, false},
+		{"off", 0, 0, false},
+		{"", 0, 0, false},
+		{"20", 0, 0, true},
+		{"20/d", 0, 0, true},
+		{"0/s", 0, 0, true},
+		{"20/s:0", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseLimit(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLimit(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got.Rate != tt.wantRate || got.Burst != tt.wantBurst {
+				t.Errorf("ParseLimit(%q) = %+v, want rate %v burst %d", tt.in, got, tt.wantRate, tt.wantBurst)
+			}
+		})
+	}
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	now := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	m := NewMemoryLimiter()
+	m.now = func() time.Time { return now }
+
+	limit := Limit{Rate: 1, Burst: 3} // 1 token per second, burst 3
+	ctx := context.Background()
+
+	// The burst is available immediately
+	for i := 2; i >= 0; i-- {
+		res, _ := m.Allow(ctx, "a", limit)
+		if !res.Allowed || res.Remaining != i {
+			t.Fatalf("request %d: got %+v, want allowed with %d remaining", 3-i, res, i)
+		}
+	}
+
+	// Then requests are rejected until a token refills
+	res, _ := m.Allow(ctx, "a", limit)
+	if res.Allowed || res.RetryAfter != time.Second || res.Reset != 3*time.Second {
+		t.Fatalf("over limit: got %+v, want rejected, retry in 1s, reset in 3s", res)
+	}
+
+	// Other keys have their own bucket
+	if res, _ := m.Allow(ctx, "b", limit); !res.Allowed {
+		t.Fatalf("other key: got %+v, want allowed", res)
+	}
+
+	now = now.Add(1500 * time.Millisecond)
+	res, _ = m.Allow(ctx, "a", limit)
+	if !res.Allowed || res.Remaining != 0 {
+		t.Fatalf("after refill: got %+v, want allowed with 0 remaining", res)
+	}
+
+	// The bucket never holds more than the burst
+	now = now.Add(time.Hour)
+	res, _ = m.Allow(ctx, "a", limit)
+	if !res.Allowed || res.Remaining != 2 {
+		t.Fatalf("after idle: got %+v, want allowed with 2 remaining", res)
+	}
+}
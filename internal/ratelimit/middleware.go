@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tiongMax/gostocks/internal/apierror"
+	"github.com/tiongMax/gostocks/internal/auth"
+)
+
+// remainingKey is the gin context key holding the lowest remaining count
+// reported so far, so that the headers describe the tightest bucket.
+const remainingKey = "ratelimit.remaining"
+
+// KeyFunc names the caller a bucket belongs to.
+type KeyFunc func(c *gin.Context) string
+
+// ByIP gives each client IP its own bucket.
+func ByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// ByCaller gives each API key, or each JWT user, its own bucket. Requests
+// without an identity fall back to the client IP.
+func ByCaller(c *gin.Context) string {
+	identity := auth.FromContext(c)
+	switch {
+	case identity == nil:
+		return ByIP(c)
+	case identity.KeyID > 0:
+		return fmt.Sprintf("key:%d", identity.KeyID)
+	default:
+		return fmt.Sprintf("user:%d", identity.UserID)
+	}
+}
+
+// Middleware limits requests of a route class. Every caller has a bucket per
+// class, so cheap reads and expensive writes have separate budgets.
+// Responses carry RateLimit-* headers; rejected requests get 429 with
+// Retry-After. If the limiter fails the request is let through, so an outage
+// of the shared store does not take the API down with it.
+func Middleware(l Limiter, class string, limit Limit, key KeyFunc) gin.HandlerFunc {
+	if limit.Unlimited() {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	policy := fmt.Sprintf("%d;w=%d", limit.Burst, int(math.Ceil(float64(limit.Burst)/limit.Rate)))
+
+	return func(c *gin.Context) {
+		res, err := l.Allow(c.Request.Context(), class+":"+key(c), limit)
+		if err != nil {
+			slog.Warn("Rate limiter unavailable", "class", class, "error", err)
+			c.Next()
+			return
+		}
+
+		if prev, ok := c.Get(remainingKey); !ok || res.Remaining < prev.(int) || !res.Allowed {
+			c.Set(remainingKey, res.Remaining)
+			c.Header("RateLimit-Policy", policy)
+			c.Header("RateLimit-Limit", strconv.Itoa(limit.Burst))
+			c.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+		}
+
+		if !res.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+			apierror.Abort(c, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyPrefix namespaces bucket keys in Redis.
+const keyPrefix = "ratelimit:"
+
+// takeToken refills and takes from a bucket atomically, using the Redis
+// clock so every gateway replica agrees on elapsed time. It mirrors take.
+//
+// KEYS[1] is the bucket hash. ARGV: rate (tokens/s), burst.
+// Returns {allowed (0/1), tokens}.
+var takeToken = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local b = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(b[1])
+local ts = tonumber(b[2])
+if tokens == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tokens, 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisLimiter keeps buckets in Redis, shared by every gateway replica.
+type RedisLimiter struct {
+	client *redis.Client
+}
+
+// NewRedisLimiter creates a new Redis connection for rate limiting.
+func NewRedisLimiter(addr string) (*RedisLimiter, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr: addr,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &RedisLimiter{client: client}, nil
+}
+
+// Allow takes a token from the bucket named key.
+func (r *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	vals, err := takeToken.Run(ctx, r.client, []string{keyPrefix + key}, limit.Rate, limit.Burst).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to take token: %w", err)
+	}
+	if len(vals) != 2 {
+		return Result{}, fmt.Errorf("unexpected rate limit reply %v", vals)
+	}
+
+	allowed, _ := vals[0].(int64)
+	tokensStr, _ := vals[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid token count %q", tokensStr)
+	}
+
+	return result(allowed == 1, tokens, limit), nil
+}
+
+// Close closes the Redis connection.
+func (r *RedisLimiter) Close() error {
+	return r.client.Close()
+}
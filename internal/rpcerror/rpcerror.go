@@ -0,0 +1,30 @@
+// Package rpcerror builds gRPC status errors that name the offending request
+// field. The gateway turns their BadRequest details into the field
+// violations of its JSON error envelope.
+package rpcerror
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InvalidField returns an InvalidArgument error whose message reads
+// "<field> <description>" and which carries the field violation as a
+// BadRequest detail, so that clients can attach it to the offending field.
+func InvalidField(field, description string) error {
+	return FieldError(codes.InvalidArgument, field, description)
+}
+
+// FieldError is InvalidField with another status code, for errors such as
+// AlreadyExists that are also caused by a single field.
+func FieldError(code codes.Code, field, description string) error {
+	st := status.New(code, field+" "+description)
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
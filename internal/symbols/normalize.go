@@ -0,0 +1,49 @@
+package symbols
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxLen is the longest accepted symbol.
+const MaxLen = 32
+
+// Normalize trims and upper-cases a symbol and checks that it only uses
+// characters found in exchange symbols, such as "AAPL", "BRK.B",
+// "BINANCE:BTCUSDT" or "IC MARKETS:1".
+func Normalize(symbol string) (string, error) {
+	s := strings.ToUpper(strings.TrimSpace(symbol))
+	if s == "" {
+		return "", fmt.Errorf("symbol is empty")
+	}
+	if len(s) > MaxLen {
+		return "", fmt.Errorf("symbol %q is longer than %d characters", s, MaxLen)
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune(".:-_^= ", r):
+		default:
+			return "", fmt.Errorf("symbol %q contains %q", s, r)
+		}
+	}
+	return s, nil
+}
+
+// NormalizeAll normalizes symbols, dropping repeats and keeping the first
+// occurrence's position.
+func NormalizeAll(symbols []string) ([]string, error) {
+	out := make([]string, 0, len(symbols))
+	seen := make(map[string]bool, len(symbols))
+	for _, s := range symbols {
+		n, err := Normalize(s)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[n] {
+			seen[n] = true
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
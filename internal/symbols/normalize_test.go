@@ -0,0 +1,45 @@
+package symbols
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"aapl", "AAPL", false},
+		{"  brk.b ", "BRK.B", false},
+		{"binance:btcusdt", "BINANCE:BTCUSDT", false},
+		{"IC MARKETS:1", "IC MARKETS:1", false},
+		{"", "", true},
+		{"   ", "", true},
+		{"AAPL/USD", "", true},
+		{"ÄPPLE", "", true},
+		{"ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := Normalize(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Normalize(%q) = %q, %v, want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestNormalizeAll(t *testing.T) {
+	got, err := NormalizeAll([]string{"msft", "AAPL", "MSFT ", "aapl"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"MSFT", "AAPL"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeAll() = %v, want %v", got, want)
+	}
+
+	if _, err := NormalizeAll([]string{"AAPL", ""}); err == nil {
+		t.Error("NormalizeAll() accepted an empty symbol")
+	}
+}
@@ -0,0 +1,83 @@
+// Package symbols keeps the registry of symbols the ingestor streams.
+// Services add symbols when users start tracking them (for example by adding
+// them to a watchlist); the ingestor polls the registry and subscribes to
+// new entries.
+package symbols
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Subscription is a registered symbol. Entries are never removed
+// automatically: other users or alerts may still depend on the stream.
+type Subscription struct {
+	Symbol    string    `gorm:"primaryKey"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName keeps the table name stable across renames of the type.
+func (Subscription) TableName() string {
+	return "symbol_subscriptions"
+}
+
+// Register adds symbols to the registry through db, which may be a
+// transaction of the caller. Symbols already registered are ignored.
+func Register(db *gorm.DB, symbols []string) error {
+	if len(symbols) == 0 {
+		return nil
+	}
+	subs := make([]Subscription, len(symbols))
+	for i, s := range symbols {
+		subs[i] = Subscription{Symbol: s}
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&subs).Error; err != nil {
+		return fmt.Errorf("failed to register symbols: %w", err)
+	}
+	return nil
+}
+
+// Registry reads the registered symbols.
+type Registry struct {
+	db *gorm.DB
+}
+
+// NewRegistry connects to the database holding the registry.
+func NewRegistry(connStr string) (*Registry, error) {
+	db, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	return &Registry{db: db}, nil
+}
+
+// AutoMigrate creates the registry table.
+func (r *Registry) AutoMigrate() error {
+	if err := r.db.AutoMigrate(&Subscription{}); err != nil {
+		return fmt.Errorf("failed to migrate symbol registry: %w", err)
+	}
+	return nil
+}
+
+// List returns every registered symbol, oldest first.
+func (r *Registry) List(ctx context.Context) ([]string, error) {
+	var symbols []string
+	if err := r.db.WithContext(ctx).Model(&Subscription{}).Order("created_at, symbol").Pluck("symbol", &symbols).Error; err != nil {
+		return nil, fmt.Errorf("failed to list symbols: %w", err)
+	}
+	return symbols, nil
+}
+
+// Close closes the database connection.
+func (r *Registry) Close() error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
@@ -0,0 +1,199 @@
+package trading
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/bus"
+	"github.com/tiongMax/gostocks/internal/lag"
+	stock "github.com/tiongMax/gostocks/proto/stock"
+	pb "github.com/tiongMax/gostocks/proto/trading"
+)
+
+// Engine matches open orders against the tick stream and publishes the
+// resulting order events.
+type Engine struct {
+	sub     bus.Subscriber
+	dlq     bus.Publisher
+	topic   string
+	store   *Store
+	model   ExecutionModel
+	events  *EventPublisher
+	groupID string
+	monitor *lag.Monitor
+}
+
+// NewEngine creates a matching engine consuming topic. Ticks that cannot be
+// decoded or matched are dead-lettered through dlq.
+func NewEngine(sub bus.Subscriber, dlq bus.Publisher, topic string, store *Store, model ExecutionModel, events *EventPublisher) *Engine {
+	groupID := "paper-trading-group"
+	return &Engine{
+		sub:     sub,
+		dlq:     dlq,
+		topic:   topic,
+		store:   store,
+		model:   model,
+		events:  events,
+		groupID: groupID,
+		// Never degraded: every tick may fill an order, so none is skipped
+		monitor: lag.NewMonitor(groupID, lag.Thresholds{}),
+	}
+}
+
+// Lag returns the lag monitor of the engine's partitions, which also counts
+// the orders filled.
+func (e *Engine) Lag() *lag.Monitor {
+	return e.monitor
+}
+
+// Start consumes ticks until ctx is cancelled. A new consumer group starts
+// at the newest ticks: orders only trade against ticks that happened after
+// they were placed, so older ones could never match.
+func (e *Engine) Start(ctx context.Context) error {
+	return e.sub.Subscribe(ctx, bus.Subscription{
+		Topics: []string{e.topic},
+		Group:  e.groupID,
+		Start:  bus.Newest,
+	}, &matcher{engine: e, dlq: bus.NewDeadLetter(e.dlq, e.groupID, bus.DefaultRetry)})
+}
+
+// matcher matches the ticks of the claimed partitions.
+type matcher struct {
+	engine *Engine
+	dlq    *bus.DeadLetter
+}
+
+// Consume matches the ticks of a claimed partition against open orders.
+// Store errors are retried; ticks that cannot be decoded, or still fail
+// after retrying, are dead-lettered.
+func (m *matcher) Consume(claim bus.Claim) error {
+	monitor := m.engine.monitor
+	track := monitor.Track(claim)
+	defer track.Release()
+
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			if !m.dlq.Owns(msg) {
+				// Redriven to another group
+				claim.Commit(msg)
+				track.Done(msg, time.Time{})
+				continue
+			}
+
+			tick, err := bus.Decode[stock.StockTick](msg)
+			if err != nil {
+				if m.dlq.Reject(claim.Context(), msg, err) != nil {
+					return nil
+				}
+				claim.Commit(msg)
+				track.Done(msg, time.Time{})
+				continue
+			}
+
+			// Fills are recorded once per order, so a retry does not fill
+			// an order twice
+			err = m.dlq.Process(claim.Context(), msg, func(context.Context) error {
+				filled, err := m.engine.match(tick)
+				monitor.Add("orders_filled", int64(filled))
+				return err
+			})
+			if err != nil {
+				// Revoked while retrying
+				return nil
+			}
+			claim.Commit(msg)
+			var event time.Time
+			if tick.Timestamp > 0 {
+				event = time.UnixMilli(tick.Timestamp)
+			}
+			track.Done(msg, event)
+
+		case <-claim.Context().Done():
+			return nil
+		}
+	}
+}
+
+// match executes the open orders on the tick's symbol that its price
+// reaches, and returns how many filled. A stop order whose stop is reached
+// without filling is marked triggered and stays open. An order that fails
+// to fill does not stop the others; the failures are returned together so
+// that the tick is retried, which skips the orders already filled.
+func (e *Engine) match(tick *stock.StockTick) (int, error) {
+	orders, err := e.store.OpenOrders(tick.Symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	tickTime := time.UnixMilli(tick.Timestamp)
+	if tick.Timestamp == 0 {
+		tickTime = time.Now()
+	}
+
+	filled := 0
+	var errs []error
+	for i := range orders {
+		o := &orders[i]
+		if tickTime.Before(o.CreatedAt) {
+			continue
+		}
+
+		triggered, fill := e.model.Match(o, tick.Price)
+		if fill == nil {
+			if triggered {
+				e.trigger(o)
+			}
+			continue
+		}
+
+		done, acct, err := e.store.FillOrder(o.ID, *fill, tickTime)
+		if err != nil {
+			slog.Error("Failed to fill order", "order_id", o.ID, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		if done == nil {
+			// Cancelled meanwhile, or filled by an earlier delivery of this tick
+			continue
+		}
+
+		if done.Status == StatusFilled {
+			filled++
+			slog.Info("Order filled", "order_id", done.ID, "user_id", done.UserID, "symbol", done.Symbol,
+				"side", done.Side, "quantity", done.Quantity, "price", done.FillPrice)
+			e.publish(pb.EventType_ORDER_FILLED, done, acct.Cash)
+		} else {
+			slog.Info("Order rejected", "order_id", done.ID, "user_id", done.UserID, "reason", done.RejectReason)
+			e.publish(pb.EventType_ORDER_REJECTED, done, acct.Cash)
+		}
+	}
+	return filled, errors.Join(errs...)
+}
+
+// trigger records that a stop order's stop price traded.
+func (e *Engine) trigger(o *Order) {
+	ok, err := e.store.MarkTriggered(o.ID)
+	if err != nil {
+		slog.Error("Failed to trigger order", "order_id", o.ID, "error", err)
+		return
+	}
+	if ok {
+		o.StopTriggered = true
+		e.publish(pb.EventType_ORDER_TRIGGERED, o, 0)
+	}
+}
+
+// publish sends an order event, logging failures: the order's state in
+// Postgres stays authoritative.
+func (e *Engine) publish(eventType pb.EventType, o *Order, cash float64) {
+	if err := e.events.Publish(eventType, o, cash); err != nil {
+		slog.Error("Failed to publish order event", "order_id", o.ID, "type", eventType, "error", err)
+	}
+}
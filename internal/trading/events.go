@@ -0,0 +1,42 @@
+package trading
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/bus"
+	pb "github.com/tiongMax/gostocks/proto/trading"
+)
+
+// EventPublisher publishes order events, keyed by user so that each user's
+// events stay in order.
+type EventPublisher struct {
+	pub   bus.Publisher
+	topic string
+}
+
+// NewEventPublisher creates an EventPublisher for topic.
+func NewEventPublisher(pub bus.Publisher, topic string) *EventPublisher {
+	return &EventPublisher{pub: pub, topic: topic}
+}
+
+// Publish sends an event about o. cash is the account's cash after a fill.
+func (p *EventPublisher) Publish(eventType pb.EventType, o *Order, cash float64) error {
+	event := &pb.OrderEvent{
+		Type:      eventType,
+		Order:     orderToProto(o),
+		Cash:      cash,
+		Timestamp: time.Now().UnixMilli(),
+	}
+	msg, err := bus.Encode(p.topic, strconv.Itoa(o.UserID), event)
+	if err != nil {
+		return err
+	}
+	// Events are sent even if the request that caused them was cancelled
+	if err := p.pub.Publish(context.Background(), msg); err != nil {
+		return fmt.Errorf("failed to publish order event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package trading
+
+import "math"
+
+// ExecutionModel decides when and at what price a simulated order fills.
+// Paper trading and backtests share it, so a strategy sees the same fills
+// in both.
+type ExecutionModel struct {
+	SlippageBps float64 // Price concession on every fill, in basis points
+	FeePerOrder float64 // Fixed fee per fill
+	FeeBps      float64 // Fee in basis points of the fill's value
+}
+
+// Fill is the execution of an order.
+type Fill struct {
+	Price float64 // Per unit, after slippage
+	Fee   float64
+}
+
+// Match checks an open order against a traded price. It reports whether the
+// price reached the order's stop, turning a stop order into a market order
+// and a stop-limit order into a limit order, and returns the fill if the
+// order executes at this price. Orders fill in full.
+//
+// Buys pay and sells receive the price moved against them by the slippage,
+// but never beyond a limit price.
+func (m ExecutionModel) Match(o *Order, price float64) (triggered bool, fill *Fill) {
+	buy := o.Side == SideBuy
+
+	if (o.Type == TypeStop || o.Type == TypeStopLimit) && !o.StopTriggered {
+		if buy && price < o.StopPrice || !buy && price > o.StopPrice {
+			return false, nil
+		}
+		triggered = true
+	}
+
+	p := m.slip(buy, price)
+	switch o.Type {
+	case TypeMarket, TypeStop:
+		return triggered, m.fill(o.Quantity, p)
+	case TypeLimit, TypeStopLimit:
+		if buy && price > o.LimitPrice || !buy && price < o.LimitPrice {
+			return triggered, nil
+		}
+		if buy {
+			p = math.Min(p, o.LimitPrice)
+		} else {
+			p = math.Max(p, o.LimitPrice)
+		}
+		return triggered, m.fill(o.Quantity, p)
+	default:
+		return triggered, nil
+	}
+}
+
+// slip moves a price against the side trading at it.
+func (m ExecutionModel) slip(buy bool, price float64) float64 {
+	if buy {
+		return price * (1 + m.SlippageBps/10000)
+	}
+	return price * (1 - m.SlippageBps/10000)
+}
+
+// fill prices the execution of quantity at price.
+func (m ExecutionModel) fill(quantity, price float64) *Fill {
+	return &Fill{Price: price, Fee: m.FeePerOrder + quantity*price*m.FeeBps/10000}
+}
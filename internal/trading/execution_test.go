@@ -0,0 +1,54 @@
+package trading
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExecutionModelMatch(t *testing.T) {
+	model := ExecutionModel{SlippageBps: 10, FeePerOrder: 1, FeeBps: 5}
+
+	tests := []struct {
+		name          string
+		order         Order
+		price         float64
+		wantTriggered bool
+		wantFill      bool
+		wantPrice     float64
+	}{
+		{"market buy slips up", Order{Side: SideBuy, Type: TypeMarket}, 100, false, true, 100.1},
+		{"market sell slips down", Order{Side: SideSell, Type: TypeMarket}, 100, false, true, 99.9},
+		{"limit buy above limit waits", Order{Side: SideBuy, Type: TypeLimit, LimitPrice: 99}, 100, false, false, 0},
+		{"limit buy capped at limit", Order{Side: SideBuy, Type: TypeLimit, LimitPrice: 100}, 99.95, false, true, 100},
+		{"limit buy below limit improves", Order{Side: SideBuy, Type: TypeLimit, LimitPrice: 100}, 90, false, true, 90.09},
+		{"limit sell below limit waits", Order{Side: SideSell, Type: TypeLimit, LimitPrice: 101}, 100, false, false, 0},
+		{"limit sell floored at limit", Order{Side: SideSell, Type: TypeLimit, LimitPrice: 100}, 100.05, false, true, 100},
+		{"stop sell not reached", Order{Side: SideSell, Type: TypeStop, StopPrice: 95}, 96, false, false, 0},
+		{"stop sell triggers and fills", Order{Side: SideSell, Type: TypeStop, StopPrice: 95}, 94, true, true, 93.906},
+		{"stop buy triggers and fills", Order{Side: SideBuy, Type: TypeStop, StopPrice: 105}, 105, true, true, 105.105},
+		{"stop limit triggers above limit", Order{Side: SideBuy, Type: TypeStopLimit, StopPrice: 105, LimitPrice: 105.5}, 106, true, false, 0},
+		{"triggered stop limit fills at limit", Order{Side: SideBuy, Type: TypeStopLimit, StopPrice: 105, LimitPrice: 105.5, StopTriggered: true}, 105.4, false, true, 105.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.order.Quantity = 10
+			triggered, fill := model.Match(&tt.order, tt.price)
+			if triggered != tt.wantTriggered {
+				t.Errorf("triggered = %v, want %v", triggered, tt.wantTriggered)
+			}
+			if (fill != nil) != tt.wantFill {
+				t.Fatalf("fill = %+v, want fill %v", fill, tt.wantFill)
+			}
+			if fill == nil {
+				return
+			}
+			if math.Abs(fill.Price-tt.wantPrice) > 1e-9 {
+				t.Errorf("fill price = %v, want %v", fill.Price, tt.wantPrice)
+			}
+			if wantFee := 1 + 10*fill.Price*5/10000; math.Abs(fill.Fee-wantFee) > 1e-9 {
+				t.Errorf("fee = %v, want %v", fill.Fee, wantFee)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package trading
+
+import (
+	"errors"
+	"fmt"
+)
+
+// quantityEpsilon absorbs floating-point residue when positions are closed.
+const quantityEpsilon = 1e-9
+
+var (
+	// ErrInsufficientCash is returned when a buy costs more than the cash left.
+	ErrInsufficientCash = errors.New("insufficient cash")
+
+	// ErrInsufficientShares is returned when a sell exceeds the position.
+	// Short selling is not supported.
+	ErrInsufficientShares = errors.New("insufficient shares")
+)
+
+// Apply books a fill of quantity on side against an account and its
+// position in the symbol. Buys pay the value plus the fee, which is added to
+// the average cost; sells receive the value less the fee and realize it
+// against the average cost. Nothing is changed if the fill is not covered.
+func Apply(acct *Account, pos *Position, side string, quantity float64, fill Fill) error {
+	value := quantity * fill.Price
+
+	switch side {
+	case SideBuy:
+		cost := value + fill.Fee
+		if cost > acct.Cash+quantityEpsilon {
+			return ErrInsufficientCash
+		}
+		acct.Cash -= cost
+		pos.AverageCost = (pos.Quantity*pos.AverageCost + cost) / (pos.Quantity + quantity)
+		pos.Quantity += quantity
+
+	case SideSell:
+		if quantity > pos.Quantity+quantityEpsilon {
+			return ErrInsufficientShares
+		}
+		proceeds := value - fill.Fee
+		acct.Cash += proceeds
+		pos.RealizedPnL += proceeds - quantity*pos.AverageCost
+		pos.Quantity -= quantity
+		if pos.Quantity < quantityEpsilon {
+			pos.Quantity, pos.AverageCost = 0, 0
+		}
+
+	default:
+		return fmt.Errorf("unknown side %q", side)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package trading
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	acct := &Account{Cash: 1000}
+	pos := &Position{Symbol: "AAPL"}
+
+	steps := []struct {
+		side     string
+		quantity float64
+		fill     Fill
+		wantErr  error
+		wantCash float64
+		wantQty  float64
+		wantAvg  float64
+		wantPnL  float64
+	}{
+		{SideBuy, 5, Fill{Price: 100, Fee: 2}, nil, 498, 5, 100.4, 0},
+		{SideBuy, 5, Fill{Price: 100, Fee: 2}, ErrInsufficientCash, 498, 5, 100.4, 0},
+		{SideBuy, 2, Fill{Price: 110, Fee: 0}, nil, 278, 7, (502 + 220) / 7.0, 0},
+		{SideSell, 8, Fill{Price: 120, Fee: 1}, ErrInsufficientShares, 278, 7, (502 + 220) / 7.0, 0},
+		{SideSell, 7, Fill{Price: 120, Fee: 1}, nil, 1117, 0, 0, 839 - 722},
+	}
+
+	for i, s := range steps {
+		err := Apply(acct, pos, s.side, s.quantity, s.fill)
+		if !errors.Is(err, s.wantErr) {
+			t.Fatalf("step %d: error = %v, want %v", i, err, s.wantErr)
+		}
+		for _, c := range []struct {
+			name      string
+			got, want float64
+		}{
+			{"cash", acct.Cash, s.wantCash},
+			{"quantity", pos.Quantity, s.wantQty},
+			{"average cost", pos.AverageCost, s.wantAvg},
+			{"realized P&L", pos.RealizedPnL, s.wantPnL},
+		} {
+			if math.Abs(c.got-c.want) > 1e-9 {
+				t.Errorf("step %d: %s = %v, want %v", i, c.name, c.got, c.want)
+			}
+		}
+	}
+}
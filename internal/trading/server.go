@@ -0,0 +1,273 @@
+package trading
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/tiongMax/gostocks/internal/rpcerror"
+	"github.com/tiongMax/gostocks/internal/symbols"
+	pb "github.com/tiongMax/gostocks/proto/trading"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxClientOrderIDLen caps caller-chosen order IDs.
+	maxClientOrderIDLen = 64
+
+	// Page size limits for ListOrders.
+	defaultOrderPageSize = 100
+	maxOrderPageSize     = 500
+)
+
+// Server implements the TradingService gRPC server.
+type Server struct {
+	pb.UnimplementedTradingServiceServer
+	store  *Store
+	events *EventPublisher
+}
+
+// NewServer creates a TradingService server publishing order events through
+// events.
+func NewServer(store *Store, events *EventPublisher) *Server {
+	return &Server{store: store, events: events}
+}
+
+// PlaceOrder accepts an order for matching against later ticks.
+func (s *Server) PlaceOrder(ctx context.Context, req *pb.PlaceOrderRequest) (*pb.Order, error) {
+	o, err := orderFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.store.PlaceOrder(o)
+	if err != nil {
+		return nil, tradingError(err)
+	}
+	if created {
+		s.publish(pb.EventType_ORDER_ACCEPTED, o)
+	}
+	return orderToProto(o), nil
+}
+
+// orderFromProto validates a PlaceOrder request and converts it to an open
+// order.
+func orderFromProto(req *pb.PlaceOrderRequest) (*Order, error) {
+	if req.UserId <= 0 {
+		return nil, rpcerror.InvalidField("user_id", "must be positive")
+	}
+	symbol, err := symbols.Normalize(req.Symbol)
+	if err != nil {
+		return nil, rpcerror.InvalidField("symbol", err.Error())
+	}
+
+	var side, orderType string
+	switch req.Side {
+	case pb.Side_BUY:
+		side = SideBuy
+	case pb.Side_SELL:
+		side = SideSell
+	}
+	switch req.Type {
+	case pb.OrderType_MARKET:
+		orderType = TypeMarket
+	case pb.OrderType_LIMIT:
+		orderType = TypeLimit
+	case pb.OrderType_STOP:
+		orderType = TypeStop
+	case pb.OrderType_STOP_LIMIT:
+		orderType = TypeStopLimit
+	}
+	if err := ValidateOrder(side, orderType, req.Quantity, req.LimitPrice, req.StopPrice); err != nil {
+		var invalid *OrderError
+		if errors.As(err, &invalid) {
+			return nil, rpcerror.InvalidField(invalid.Field, invalid.Description)
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	o := &Order{
+		UserID:     int(req.UserId),
+		Symbol:     symbol,
+		Side:       side,
+		Type:       orderType,
+		Quantity:   req.Quantity,
+		LimitPrice: req.LimitPrice,
+		StopPrice:  req.StopPrice,
+		Status:     StatusOpen,
+	}
+	if req.ClientOrderId != "" {
+		if len(req.ClientOrderId) > maxClientOrderIDLen {
+			return nil, rpcerror.InvalidField("client_order_id", fmt.Sprintf("must be at most %d characters", maxClientOrderIDLen))
+		}
+		id := req.ClientOrderId
+		o.ClientOrderID = &id
+	}
+	return o, nil
+}
+
+// GetOrder retrieves a single order.
+func (s *Server) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+	o, err := s.store.GetOrder(req.Id, int(req.UserId))
+	if err != nil {
+		return nil, tradingError(err)
+	}
+	return orderToProto(o), nil
+}
+
+// ListOrders retrieves orders, newest first.
+func (s *Server) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	filter := OrderFilter{UserID: int(req.UserId)}
+
+	switch req.Status {
+	case pb.OrderStatus_STATUS_UNSPECIFIED:
+	case pb.OrderStatus_OPEN, pb.OrderStatus_FILLED, pb.OrderStatus_CANCELLED, pb.OrderStatus_REJECTED:
+		filter.Status = req.Status.String()
+	default:
+		return nil, rpcerror.InvalidField("status", "must be OPEN, FILLED, CANCELLED or REJECTED")
+	}
+
+	if req.Symbol != "" {
+		symbol, err := symbols.Normalize(req.Symbol)
+		if err != nil {
+			return nil, rpcerror.InvalidField("symbol", err.Error())
+		}
+		filter.Symbol = symbol
+	}
+
+	switch {
+	case req.Limit < 0:
+		return nil, rpcerror.InvalidField("limit", "must not be negative")
+	case req.Limit == 0:
+		filter.Limit = defaultOrderPageSize
+	case req.Limit > maxOrderPageSize:
+		filter.Limit = maxOrderPageSize
+	default:
+		filter.Limit = int(req.Limit)
+	}
+
+	orders, err := s.store.ListOrders(filter)
+	if err != nil {
+		return nil, tradingError(err)
+	}
+
+	resp := &pb.ListOrdersResponse{Orders: make([]*pb.Order, len(orders))}
+	for i := range orders {
+		resp.Orders[i] = orderToProto(&orders[i])
+	}
+	return resp, nil
+}
+
+// CancelOrder cancels an open order.
+func (s *Server) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.Order, error) {
+	o, err := s.store.CancelOrder(req.Id, int(req.UserId))
+	if err != nil {
+		return nil, tradingError(err)
+	}
+	s.publish(pb.EventType_ORDER_CANCELLED, o)
+	return orderToProto(o), nil
+}
+
+// GetAccount retrieves a user's cash and positions.
+func (s *Server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.Account, error) {
+	if req.UserId <= 0 {
+		return nil, rpcerror.InvalidField("user_id", "must be positive")
+	}
+	acct, positions, err := s.store.GetAccount(int(req.UserId))
+	if err != nil {
+		return nil, tradingError(err)
+	}
+	return accountToProto(acct, positions), nil
+}
+
+// ResetAccount cancels open orders, drops positions and restores the
+// starting cash.
+func (s *Server) ResetAccount(ctx context.Context, req *pb.ResetAccountRequest) (*pb.Account, error) {
+	if req.UserId <= 0 {
+		return nil, rpcerror.InvalidField("user_id", "must be positive")
+	}
+	if req.Cash < 0 {
+		return nil, rpcerror.InvalidField("cash", "must not be negative")
+	}
+
+	acct, cancelled, err := s.store.ResetAccount(int(req.UserId), req.Cash)
+	if err != nil {
+		return nil, tradingError(err)
+	}
+	for i := range cancelled {
+		s.publish(pb.EventType_ORDER_CANCELLED, &cancelled[i])
+	}
+	return accountToProto(acct, nil), nil
+}
+
+// publish sends an order event, logging failures: the order's state in
+// Postgres stays authoritative.
+func (s *Server) publish(eventType pb.EventType, o *Order) {
+	if err := s.events.Publish(eventType, o, 0); err != nil {
+		slog.Error("Failed to publish order event", "order_id", o.ID, "type", eventType, "error", err)
+	}
+}
+
+// tradingError converts a store error to a gRPC status error.
+func tradingError(err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return status.Error(codes.NotFound, "order not found")
+	case errors.Is(err, ErrNotOpen):
+		return status.Error(codes.FailedPrecondition, "order is not open")
+	case errors.Is(err, ErrInsufficientShares):
+		return rpcerror.FieldError(codes.FailedPrecondition, "quantity", "exceeds the position held")
+	}
+	return status.Errorf(codes.Internal, "trading operation failed: %v", err)
+}
+
+// orderToProto converts an Order to its proto form.
+func orderToProto(o *Order) *pb.Order {
+	out := &pb.Order{
+		Id:            o.ID,
+		UserId:        int32(o.UserID),
+		Symbol:        o.Symbol,
+		Side:          pb.Side(pb.Side_value[o.Side]),
+		Type:          pb.OrderType(pb.OrderType_value[o.Type]),
+		Quantity:      o.Quantity,
+		LimitPrice:    o.LimitPrice,
+		StopPrice:     o.StopPrice,
+		Status:        pb.OrderStatus(pb.OrderStatus_value[o.Status]),
+		StopTriggered: o.StopTriggered,
+		FillPrice:     o.FillPrice,
+		Fee:           o.Fee,
+		RejectReason:  o.RejectReason,
+		CreatedAt:     o.CreatedAt.UnixMilli(),
+		UpdatedAt:     o.UpdatedAt.UnixMilli(),
+	}
+	if o.ClientOrderID != nil {
+		out.ClientOrderId = *o.ClientOrderID
+	}
+	if o.FilledAt != nil {
+		out.FilledAt = o.FilledAt.UnixMilli()
+	}
+	return out
+}
+
+// accountToProto converts an account and its positions to proto form.
+func accountToProto(a *Account, positions []Position) *pb.Account {
+	out := &pb.Account{
+		UserId:       int32(a.UserID),
+		Cash:         a.Cash,
+		StartingCash: a.StartingCash,
+		Positions:    make([]*pb.Position, len(positions)),
+		UpdatedAt:    a.UpdatedAt.UnixMilli(),
+	}
+	for i, p := range positions {
+		out.Positions[i] = &pb.Position{
+			Symbol:      p.Symbol,
+			Quantity:    p.Quantity,
+			AverageCost: p.AverageCost,
+			RealizedPnl: p.RealizedPnL,
+		}
+		out.RealizedPnl += p.RealizedPnL
+	}
+	return out
+}
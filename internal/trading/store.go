@@ -0,0 +1,335 @@
+package trading
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tiongMax/gostocks/internal/pgerror"
+	"github.com/tiongMax/gostocks/internal/symbols"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrNotFound is returned when an order does not exist or belongs to
+	// another user.
+	ErrNotFound = errors.New("order not found")
+
+	// ErrNotOpen is returned when cancelling an order that already filled,
+	// was rejected or was cancelled.
+	ErrNotOpen = errors.New("order is not open")
+)
+
+// Store persists paper accounts, positions and orders in Postgres.
+type Store struct {
+	db           *gorm.DB
+	startingCash float64
+}
+
+// NewStore connects to the database. Accounts are opened with startingCash.
+func NewStore(connStr string, startingCash float64) (*Store, error) {
+	db, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db, startingCash: startingCash}, nil
+}
+
+// AutoMigrate creates the paper trading tables.
+func (s *Store) AutoMigrate() error {
+	if err := s.db.AutoMigrate(&Account{}, &Position{}, &Order{}, &symbols.Subscription{}); err != nil {
+		return fmt.Errorf("failed to migrate trading schema: %w", err)
+	}
+	return nil
+}
+
+// Close closes the database connection.
+func (s *Store) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// account loads a user's account through db, opening it with the starting
+// cash if needed and locking its row when forUpdate is set.
+func (s *Store) account(db *gorm.DB, userID int, forUpdate bool) (*Account, error) {
+	open := &Account{UserID: userID, Cash: s.startingCash, StartingCash: s.startingCash}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(open).Error; err != nil {
+		return nil, err
+	}
+
+	query := db.Where("user_id = ?", userID)
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var acct Account
+	if err := query.First(&acct).Error; err != nil {
+		return nil, err
+	}
+	return &acct, nil
+}
+
+// GetAccount retrieves a user's account and positions, ordered by symbol.
+func (s *Store) GetAccount(userID int) (*Account, []Position, error) {
+	acct, err := s.account(s.db, userID, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	var positions []Position
+	if err := s.db.Where("user_id = ?", userID).Order("symbol").Find(&positions).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to get positions: %w", err)
+	}
+	return acct, positions, nil
+}
+
+// PlaceOrder inserts an open order and registers its symbol for ingestion.
+// If the user already placed an order with the same client order ID, o is
+// replaced by that order and PlaceOrder returns false. Sells larger than the
+// position are refused with ErrInsufficientShares.
+func (s *Store) PlaceOrder(o *Order) (bool, error) {
+	created := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if o.ClientOrderID != nil {
+			var existing Order
+			err := tx.Where("user_id = ? AND client_order_id = ?", o.UserID, *o.ClientOrderID).First(&existing).Error
+			if err == nil {
+				*o = existing
+				return nil
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+		}
+
+		// Opening the account up front gives the user a balance to check
+		if _, err := s.account(tx, o.UserID, false); err != nil {
+			return err
+		}
+		if o.Side == SideSell {
+			var pos Position
+			err := tx.Where("user_id = ? AND symbol = ?", o.UserID, o.Symbol).Limit(1).Find(&pos).Error
+			if err != nil {
+				return err
+			}
+			if o.Quantity > pos.Quantity+quantityEpsilon {
+				return ErrInsufficientShares
+			}
+		}
+
+		o.Status = StatusOpen
+		if err := tx.Create(o).Error; err != nil {
+			return err
+		}
+		created = true
+		return symbols.Register(tx, []string{o.Symbol})
+	})
+	switch {
+	case errors.Is(err, ErrInsufficientShares):
+		return false, err
+	case o.ClientOrderID != nil && pgerror.IsUniqueViolation(err):
+		// A concurrent retry with the same client order ID won
+		existing, getErr := s.getOrder(s.db.Where("client_order_id = ?", *o.ClientOrderID), 0, o.UserID, false)
+		if getErr != nil {
+			return false, getErr
+		}
+		*o = *existing
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to place order: %w", err)
+	}
+	return created, nil
+}
+
+// GetOrder retrieves an order. A userID of 0 matches any owner.
+func (s *Store) GetOrder(id int64, userID int) (*Order, error) {
+	return s.getOrder(s.db, id, userID, false)
+}
+
+// getOrder loads an order through db, locking its row when forUpdate is set.
+// An id of 0 leaves the order to conditions already on db.
+func (s *Store) getOrder(db *gorm.DB, id int64, userID int, forUpdate bool) (*Order, error) {
+	query := db
+	if id != 0 {
+		query = query.Where("id = ?", id)
+	}
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	var o Order
+	if err := query.First(&o).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	return &o, nil
+}
+
+// OrderFilter selects orders. Zero fields do not filter.
+type OrderFilter struct {
+	UserID int
+	Status string
+	Symbol string
+	Limit  int
+}
+
+// ListOrders retrieves orders matching filter, newest first.
+func (s *Store) ListOrders(filter OrderFilter) ([]Order, error) {
+	query := s.db.Order("id DESC")
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Symbol != "" {
+		query = query.Where("symbol = ?", filter.Symbol)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var orders []Order
+	if err := query.Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+	return orders, nil
+}
+
+// CancelOrder cancels an open order. A userID of 0 matches any owner.
+func (s *Store) CancelOrder(id int64, userID int) (*Order, error) {
+	var order *Order
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		o, err := s.getOrder(tx, id, userID, true)
+		if err != nil {
+			return err
+		}
+		if o.Status != StatusOpen {
+			return ErrNotOpen
+		}
+		o.Status = StatusCancelled
+		order = o
+		return tx.Save(o).Error
+	})
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrNotOpen) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
+	}
+	return order, nil
+}
+
+// OpenOrders retrieves the open orders on symbol, oldest first.
+func (s *Store) OpenOrders(symbol string) ([]Order, error) {
+	var orders []Order
+	if err := s.db.Where("symbol = ? AND status = ?", symbol, StatusOpen).Order("id").Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("failed to query open orders for %s: %w", symbol, err)
+	}
+	return orders, nil
+}
+
+// MarkTriggered records that an open stop order's stop price traded. It
+// returns false if the order was no longer open or already triggered.
+func (s *Store) MarkTriggered(id int64) (bool, error) {
+	result := s.db.Model(&Order{}).
+		Where("id = ? AND status = ? AND NOT stop_triggered", id, StatusOpen).
+		Update("stop_triggered", true)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to mark order %d triggered: %w", id, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FillOrder books a fill of an open order at the event time of the filling
+// tick. Fills the account cannot cover reject the order instead. It returns
+// the order in its final state and the account after booking, or nil if
+// the order was no longer open, which makes redelivered ticks harmless.
+func (s *Store) FillOrder(id int64, fill Fill, at time.Time) (*Order, *Account, error) {
+	var order *Order
+	var acct *Account
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		o, err := s.getOrder(tx, id, 0, true)
+		if err != nil {
+			return err
+		}
+		if o.Status != StatusOpen {
+			return nil
+		}
+		a, err := s.account(tx, o.UserID, true)
+		if err != nil {
+			return err
+		}
+		pos := Position{UserID: o.UserID, Symbol: o.Symbol}
+		if err := tx.Where("user_id = ? AND symbol = ?", o.UserID, o.Symbol).Limit(1).Find(&pos).Error; err != nil {
+			return err
+		}
+
+		order, acct = o, a
+		if err := Apply(a, &pos, o.Side, o.Quantity, fill); err != nil {
+			if !errors.Is(err, ErrInsufficientCash) && !errors.Is(err, ErrInsufficientShares) {
+				return err
+			}
+			o.Status, o.RejectReason = StatusRejected, err.Error()
+			return tx.Save(o).Error
+		}
+
+		o.Status, o.FillPrice, o.Fee, o.FilledAt = StatusFilled, fill.Price, fill.Fee, &at
+		if o.Type == TypeStop || o.Type == TypeStopLimit {
+			o.StopTriggered = true
+		}
+		if err := tx.Save(o).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(a).Error; err != nil {
+			return err
+		}
+		return tx.Save(&pos).Error
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fill order %d: %w", id, err)
+	}
+	return order, acct, nil
+}
+
+// ResetAccount cancels a user's open orders, deletes their positions and
+// restores their cash to startingCash, or the default starting cash if 0.
+// It returns the account and the orders it cancelled.
+func (s *Store) ResetAccount(userID int, startingCash float64) (*Account, []Order, error) {
+	if startingCash == 0 {
+		startingCash = s.startingCash
+	}
+
+	var acct *Account
+	var cancelled []Order
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		a, err := s.account(tx, userID, true)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&cancelled).Clauses(clause.Returning{}).
+			Where("user_id = ? AND status = ?", userID, StatusOpen).
+			Update("status", StatusCancelled).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&Position{}).Error; err != nil {
+			return err
+		}
+
+		a.Cash, a.StartingCash = startingCash, startingCash
+		acct = a
+		return tx.Save(a).Error
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reset account: %w", err)
+	}
+	return acct, cancelled, nil
+}
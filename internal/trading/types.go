@@ -0,0 +1,83 @@
+package trading
+
+import "time"
+
+// Order types.
+const (
+	TypeMarket    = "MARKET"
+	TypeLimit     = "LIMIT"
+	TypeStop      = "STOP"
+	TypeStopLimit = "STOP_LIMIT"
+)
+
+// Order sides.
+const (
+	SideBuy  = "BUY"
+	SideSell = "SELL"
+)
+
+// Order states.
+const (
+	StatusOpen      = "OPEN"
+	StatusFilled    = "FILLED"
+	StatusCancelled = "CANCELLED"
+	StatusRejected  = "REJECTED"
+)
+
+// Account is a user's virtual cash. It is opened with the starting cash the
+// first time the user trades or asks for it.
+type Account struct {
+	UserID       int       `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	Cash         float64   `json:"cash" gorm:"not null"`
+	StartingCash float64   `json:"starting_cash" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName keeps paper accounts apart from other services' tables.
+func (Account) TableName() string {
+	return "paper_accounts"
+}
+
+// Position is a user's holding of one symbol. Short positions are not
+// supported. AverageCost includes buy fees.
+type Position struct {
+	UserID      int       `json:"-" gorm:"primaryKey;autoIncrement:false"`
+	Symbol      string    `json:"symbol" gorm:"primaryKey"`
+	Quantity    float64   `json:"quantity" gorm:"not null"`
+	AverageCost float64   `json:"average_cost" gorm:"not null"`
+	RealizedPnL float64   `json:"realized_pnl" gorm:"not null;default:0"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName keeps paper positions apart from other services' tables.
+func (Position) TableName() string {
+	return "paper_positions"
+}
+
+// Order is a simulated order. ClientOrderID is nil unless the caller chose
+// one; it is unique per user.
+type Order struct {
+	ID            int64      `json:"id" gorm:"primaryKey"`
+	UserID        int        `json:"user_id" gorm:"not null;index;uniqueIndex:idx_order_client_id"`
+	ClientOrderID *string    `json:"client_order_id,omitempty" gorm:"uniqueIndex:idx_order_client_id"`
+	Symbol        string     `json:"symbol" gorm:"not null;index:idx_order_symbol_status"`
+	Side          string     `json:"side" gorm:"not null"` // "BUY" or "SELL"
+	Type          string     `json:"type" gorm:"not null"` // "MARKET", "LIMIT", "STOP" or "STOP_LIMIT"
+	Quantity      float64    `json:"quantity" gorm:"not null"`
+	LimitPrice    float64    `json:"limit_price"`
+	StopPrice     float64    `json:"stop_price"`
+	Status        string     `json:"status" gorm:"not null;default:OPEN;index:idx_order_symbol_status"`
+	StopTriggered bool       `json:"stop_triggered" gorm:"not null;default:false"`
+	FillPrice     float64    `json:"fill_price"`
+	Fee           float64    `json:"fee"`
+	RejectReason  string     `json:"reject_reason,omitempty"`
+	FilledAt      *time.Time `json:"filled_at,omitempty"` // Event time of the filling tick
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName keeps paper orders apart from other services' tables.
+func (Order) TableName() string {
+	return "paper_orders"
+}
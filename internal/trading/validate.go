@@ -0,0 +1,54 @@
+package trading
+
+// OrderError is an invalid field of an order. Field is named as in the
+// PlaceOrder request, such as "limit_price".
+type OrderError struct {
+	Field       string
+	Description string
+}
+
+func (e *OrderError) Error() string {
+	return e.Field + " " + e.Description
+}
+
+// ValidateOrder checks the side, type, quantity and limit and stop prices of
+// a new order. Every order is checked by it, whether it is placed through
+// the Trading Service, by an alert action or in a backtest, so that they all
+// follow the same rules. The error is an *OrderError.
+func ValidateOrder(side, orderType string, quantity, limitPrice, stopPrice float64) error {
+	if side != SideBuy && side != SideSell {
+		return &OrderError{Field: "side", Description: "must be BUY or SELL"}
+	}
+	if quantity <= 0 {
+		return &OrderError{Field: "quantity", Description: "must be positive"}
+	}
+
+	needLimit, needStop := false, false
+	switch orderType {
+	case TypeMarket:
+	case TypeLimit:
+		needLimit = true
+	case TypeStop:
+		needStop = true
+	case TypeStopLimit:
+		needLimit, needStop = true, true
+	default:
+		return &OrderError{Field: "type", Description: "must be MARKET, LIMIT, STOP or STOP_LIMIT"}
+	}
+	if err := checkPrice("limit_price", limitPrice, needLimit, orderType); err != nil {
+		return err
+	}
+	return checkPrice("stop_price", stopPrice, needStop, orderType)
+}
+
+// checkPrice validates a limit or stop price, which an order of orderType
+// either needs or must leave unset.
+func checkPrice(field string, price float64, needed bool, orderType string) error {
+	switch {
+	case needed && price <= 0:
+		return &OrderError{Field: field, Description: "must be positive for " + orderType + " orders"}
+	case !needed && price != 0:
+		return &OrderError{Field: field, Description: "must not be set for " + orderType + " orders"}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package trading
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		side      string
+		orderType string
+		quantity  float64
+		limit     float64
+		stop      float64
+		wantField string // Empty if the order is valid
+	}{
+		{"market", SideBuy, TypeMarket, 10, 0, 0, ""},
+		{"limit", SideSell, TypeLimit, 5, 900, 0, ""},
+		{"stop", SideSell, TypeStop, 1, 0, 300, ""},
+		{"stop limit", SideBuy, TypeStopLimit, 1, 105.5, 105, ""},
+		{"missing side", "", TypeMarket, 1, 0, 0, "side"},
+		{"zero quantity", SideBuy, TypeMarket, 0, 0, 0, "quantity"},
+		{"missing type", SideBuy, "", 1, 0, 0, "type"},
+		{"limit without price", SideBuy, TypeLimit, 1, 0, 0, "limit_price"},
+		{"market with limit price", SideBuy, TypeMarket, 1, 100, 0, "limit_price"},
+		{"stop limit without stop", SideBuy, TypeStopLimit, 1, 100, 0, "stop_price"},
+		{"negative stop", SideSell, TypeStop, 1, 0, -5, "stop_price"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOrder(tt.side, tt.orderType, tt.quantity, tt.limit, tt.stop)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateOrder() = %v, want nil", err)
+				}
+				return
+			}
+			var invalid *OrderError
+			if !errors.As(err, &invalid) || invalid.Field != tt.wantField {
+				t.Errorf("ValidateOrder() = %v, want an error on %s", err, tt.wantField)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@
 package alert
 
 import (
+	trading "github.com/tiongMax/gostocks/proto/trading"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -71,32 +72,1330 @@ func (AlertCondition) EnumDescriptor() ([]byte, []int) {
 	return file_proto_alert_proto_rawDescGZIP(), []int{0}
 }
 
+// Lifecycle state of an alert
+type AlertStatus int32
+
+const (
+	AlertStatus_STATUS_UNSPECIFIED AlertStatus = 0
+	AlertStatus_ACTIVE             AlertStatus = 1 // Evaluated against incoming ticks
+	AlertStatus_TRIGGERED          AlertStatus = 2 // Condition was met
+	AlertStatus_EXPIRED            AlertStatus = 3 // Passed expires_at without triggering
+)
+
+// Enum value maps for AlertStatus.
+var (
+	AlertStatus_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "ACTIVE",
+		2: "TRIGGERED",
+		3: "EXPIRED",
+	}
+	AlertStatus_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"ACTIVE":             1,
+		"TRIGGERED":          2,
+		"EXPIRED":            3,
+	}
+)
+
+func (x AlertStatus) Enum() *AlertStatus {
+	p := new(AlertStatus)
+	*p = x
+	return p
+}
+
+func (x AlertStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_alert_proto_enumTypes[1].Descriptor()
+}
+
+func (AlertStatus) Type() protoreflect.EnumType {
+	return &file_proto_alert_proto_enumTypes[1]
+}
+
+func (x AlertStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertStatus.Descriptor instead.
+func (AlertStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{1}
+}
+
+// What an alert compares with its threshold
+type AlertKind int32
+
+const (
+	AlertKind_KIND_UNSPECIFIED AlertKind = 0 // Treated as PRICE
+	AlertKind_PRICE            AlertKind = 1 // A symbol's price against target_price
+	AlertKind_PORTFOLIO        AlertKind = 2 // A portfolio aggregate against a threshold
+)
+
+// Enum value maps for AlertKind.
+var (
+	AlertKind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "PRICE",
+		2: "PORTFOLIO",
+	}
+	AlertKind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"PRICE":            1,
+		"PORTFOLIO":        2,
+	}
+)
+
+func (x AlertKind) Enum() *AlertKind {
+	p := new(AlertKind)
+	*p = x
+	return p
+}
+
+func (x AlertKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_alert_proto_enumTypes[2].Descriptor()
+}
+
+func (AlertKind) Type() protoreflect.EnumType {
+	return &file_proto_alert_proto_enumTypes[2]
+}
+
+func (x AlertKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertKind.Descriptor instead.
+func (AlertKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{2}
+}
+
+// Portfolio aggregates a portfolio alert can watch
+type PortfolioMetric int32
+
+const (
+	PortfolioMetric_METRIC_UNSPECIFIED PortfolioMetric = 0
+	PortfolioMetric_UNREALIZED_PNL     PortfolioMetric = 1 // Total unrealized P&L
+	PortfolioMetric_DAY_CHANGE         PortfolioMetric = 2 // P&L since the session open
+	PortfolioMetric_DAY_CHANGE_PERCENT PortfolioMetric = 3 // DAY_CHANGE in percent of the value at the open
+	PortfolioMetric_MARKET_VALUE       PortfolioMetric = 4 // Total market value
+	PortfolioMetric_POSITION_WEIGHT    PortfolioMetric = 5 // Percent of the market value held in symbol
+)
+
+// Enum value maps for PortfolioMetric.
+var (
+	PortfolioMetric_name = map[int32]string{
+		0: "METRIC_UNSPECIFIED",
+		1: "UNREALIZED_PNL",
+		2: "DAY_CHANGE",
+		3: "DAY_CHANGE_PERCENT",
+		4: "MARKET_VALUE",
+		5: "POSITION_WEIGHT",
+	}
+	PortfolioMetric_value = map[string]int32{
+		"METRIC_UNSPECIFIED": 0,
+		"UNREALIZED_PNL":     1,
+		"DAY_CHANGE":         2,
+		"DAY_CHANGE_PERCENT": 3,
+		"MARKET_VALUE":       4,
+		"POSITION_WEIGHT":    5,
+	}
+)
+
+func (x PortfolioMetric) Enum() *PortfolioMetric {
+	p := new(PortfolioMetric)
+	*p = x
+	return p
+}
+
+func (x PortfolioMetric) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PortfolioMetric) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_alert_proto_enumTypes[3].Descriptor()
+}
+
+func (PortfolioMetric) Type() protoreflect.EnumType {
+	return &file_proto_alert_proto_enumTypes[3]
+}
+
+func (x PortfolioMetric) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PortfolioMetric.Descriptor instead.
+func (PortfolioMetric) EnumDescriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{3}
+}
+
+// Outcome of a trigger's order action
+type ActionStatus int32
+
+const (
+	ActionStatus_ACTION_STATUS_UNSPECIFIED ActionStatus = 0 // The alert has no action
+	ActionStatus_ACTION_PENDING            ActionStatus = 1 // Not placed yet; retried while the Trading Service is unreachable
+	ActionStatus_ACTION_PLACED             ActionStatus = 2 // The order was placed
+	ActionStatus_ACTION_FAILED             ActionStatus = 3 // The order was refused, see error
+)
+
+// Enum value maps for ActionStatus.
+var (
+	ActionStatus_name = map[int32]string{
+		0: "ACTION_STATUS_UNSPECIFIED",
+		1: "ACTION_PENDING",
+		2: "ACTION_PLACED",
+		3: "ACTION_FAILED",
+	}
+	ActionStatus_value = map[string]int32{
+		"ACTION_STATUS_UNSPECIFIED": 0,
+		"ACTION_PENDING":            1,
+		"ACTION_PLACED":             2,
+		"ACTION_FAILED":             3,
+	}
+)
+
+func (x ActionStatus) Enum() *ActionStatus {
+	p := new(ActionStatus)
+	*p = x
+	return p
+}
+
+func (x ActionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ActionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_alert_proto_enumTypes[4].Descriptor()
+}
+
+func (ActionStatus) Type() protoreflect.EnumType {
+	return &file_proto_alert_proto_enumTypes[4]
+}
+
+func (x ActionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ActionStatus.Descriptor instead.
+func (ActionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{4}
+}
+
+// PortfolioRule is the subject of a PORTFOLIO alert. The metric is compared
+// with the threshold using the alert's condition whenever a symbol the
+// portfolio holds ticks.
+type PortfolioRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId   int32                  `protobuf:"varint,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	Metric        PortfolioMetric        `protobuf:"varint,2,opt,name=metric,proto3,enum=alert.PortfolioMetric" json:"metric,omitempty"`
+	Threshold     float64                `protobuf:"fixed64,3,opt,name=threshold,proto3" json:"threshold,omitempty"` // Currency, or percent for *_PERCENT and POSITION_WEIGHT
+	Symbol        string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`         // Position for POSITION_WEIGHT
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortfolioRule) Reset() {
+	*x = PortfolioRule{}
+	mi := &file_proto_alert_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortfolioRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortfolioRule) ProtoMessage() {}
+
+func (x *PortfolioRule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortfolioRule.ProtoReflect.Descriptor instead.
+func (*PortfolioRule) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PortfolioRule) GetPortfolioId() int32 {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return 0
+}
+
+func (x *PortfolioRule) GetMetric() PortfolioMetric {
+	if x != nil {
+		return x.Metric
+	}
+	return PortfolioMetric_METRIC_UNSPECIFIED
+}
+
+func (x *PortfolioRule) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *PortfolioRule) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// OrderAction is a paper order placed through the Trading Service when the
+// alert triggers. It is placed at most once per trigger.
+type OrderAction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"` // Defaults to the alert's symbol, or the portfolio rule's
+	Side          trading.Side           `protobuf:"varint,2,opt,name=side,proto3,enum=trading.Side" json:"side,omitempty"`
+	Type          trading.OrderType      `protobuf:"varint,3,opt,name=type,proto3,enum=trading.OrderType" json:"type,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	LimitPrice    float64                `protobuf:"fixed64,5,opt,name=limit_price,json=limitPrice,proto3" json:"limit_price,omitempty"` // LIMIT and STOP_LIMIT
+	StopPrice     float64                `protobuf:"fixed64,6,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`    // STOP and STOP_LIMIT
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderAction) Reset() {
+	*x = OrderAction{}
+	mi := &file_proto_alert_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderAction) ProtoMessage() {}
+
+func (x *OrderAction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderAction.ProtoReflect.Descriptor instead.
+func (*OrderAction) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrderAction) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OrderAction) GetSide() trading.Side {
+	if x != nil {
+		return x.Side
+	}
+	return trading.Side(0)
+}
+
+func (x *OrderAction) GetType() trading.OrderType {
+	if x != nil {
+		return x.Type
+	}
+	return trading.OrderType(0)
+}
+
+func (x *OrderAction) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderAction) GetLimitPrice() float64 {
+	if x != nil {
+		return x.LimitPrice
+	}
+	return 0
+}
+
+func (x *OrderAction) GetStopPrice() float64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+// ActionResult links a trigger to the order its action placed.
+type ActionResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        ActionStatus           `protobuf:"varint,1,opt,name=status,proto3,enum=alert.ActionStatus" json:"status,omitempty"`
+	OrderId       int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // Set once PLACED
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                     // Why the last attempt failed
+	Attempts      int32                  `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionResult) Reset() {
+	*x = ActionResult{}
+	mi := &file_proto_alert_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionResult) ProtoMessage() {}
+
+func (x *ActionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionResult.ProtoReflect.Descriptor instead.
+func (*ActionResult) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ActionResult) GetStatus() ActionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ActionStatus_ACTION_STATUS_UNSPECIFIED
+}
+
+func (x *ActionResult) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ActionResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ActionResult) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+// ActiveWindow restricts alert evaluation to a recurring local-time window.
+type ActiveWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timezone      string                 `protobuf:"bytes,1,opt,name=timezone,proto3" json:"timezone,omitempty"`                              // IANA zone, e.g., "America/New_York" (empty = UTC)
+	Start         string                 `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`                                    // Local start time "HH:MM" (inclusive)
+	End           string                 `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`                                        // Local end time "HH:MM" (exclusive)
+	Weekdays      []int32                `protobuf:"varint,4,rep,packed,name=weekdays,proto3" json:"weekdays,omitempty"`                      // 0 = Sunday ... 6 = Saturday (empty = every day)
+	SkipHolidays  bool                   `protobuf:"varint,5,opt,name=skip_holidays,json=skipHolidays,proto3" json:"skip_holidays,omitempty"` // Closed on dates in the holiday calendar
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActiveWindow) Reset() {
+	*x = ActiveWindow{}
+	mi := &file_proto_alert_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActiveWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveWindow) ProtoMessage() {}
+
+func (x *ActiveWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveWindow.ProtoReflect.Descriptor instead.
+func (*ActiveWindow) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ActiveWindow) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ActiveWindow) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *ActiveWindow) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *ActiveWindow) GetWeekdays() []int32 {
+	if x != nil {
+		return x.Weekdays
+	}
+	return nil
+}
+
+func (x *ActiveWindow) GetSkipHolidays() bool {
+	if x != nil {
+		return x.SkipHolidays
+	}
+	return false
+}
+
 // CreateAlertRequest is the request message for creating a new alert.
 type CreateAlertRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol          string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`                                             // Stock symbol, e.g., "AAPL"
+	TargetPrice     float64                `protobuf:"fixed64,3,opt,name=target_price,json=targetPrice,proto3" json:"target_price,omitempty"`              // Target price to trigger alert
+	Condition       AlertCondition         `protobuf:"varint,4,opt,name=condition,proto3,enum=alert.AlertCondition" json:"condition,omitempty"`            // ABOVE or BELOW
+	ExpiresAt       int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                     // Unix timestamp (0 = never expires)
+	Window          *ActiveWindow          `protobuf:"bytes,6,opt,name=window,proto3" json:"window,omitempty"`                                             // Only evaluate ticks inside this window
+	MarketHoursOnly bool                   `protobuf:"varint,7,opt,name=market_hours_only,json=marketHoursOnly,proto3" json:"market_hours_only,omitempty"` // Shorthand for regular US market hours
+	Kind            AlertKind              `protobuf:"varint,8,opt,name=kind,proto3,enum=alert.AlertKind" json:"kind,omitempty"`                           // PORTFOLIO alerts need portfolio instead of symbol and target_price
+	Portfolio       *PortfolioRule         `protobuf:"bytes,9,opt,name=portfolio,proto3" json:"portfolio,omitempty"`
+	Action          *OrderAction           `protobuf:"bytes,10,opt,name=action,proto3" json:"action,omitempty"` // Paper order to place when the alert triggers
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateAlertRequest) Reset() {
+	*x = CreateAlertRequest{}
+	mi := &file_proto_alert_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAlertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAlertRequest) ProtoMessage() {}
+
+func (x *CreateAlertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAlertRequest.ProtoReflect.Descriptor instead.
+func (*CreateAlertRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateAlertRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateAlertRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CreateAlertRequest) GetTargetPrice() float64 {
+	if x != nil {
+		return x.TargetPrice
+	}
+	return 0
+}
+
+func (x *CreateAlertRequest) GetCondition() AlertCondition {
+	if x != nil {
+		return x.Condition
+	}
+	return AlertCondition_CONDITION_UNSPECIFIED
+}
+
+func (x *CreateAlertRequest) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *CreateAlertRequest) GetWindow() *ActiveWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *CreateAlertRequest) GetMarketHoursOnly() bool {
+	if x != nil {
+		return x.MarketHoursOnly
+	}
+	return false
+}
+
+func (x *CreateAlertRequest) GetKind() AlertKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AlertKind_KIND_UNSPECIFIED
+}
+
+func (x *CreateAlertRequest) GetPortfolio() *PortfolioRule {
+	if x != nil {
+		return x.Portfolio
+	}
+	return nil
+}
+
+func (x *CreateAlertRequest) GetAction() *OrderAction {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+// CreateAlertResponse is the response message after creating an alert.
+type CreateAlertResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AlertId       int32                  `protobuf:"varint,1,opt,name=alert_id,json=alertId,proto3" json:"alert_id,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAlertResponse) Reset() {
+	*x = CreateAlertResponse{}
+	mi := &file_proto_alert_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAlertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAlertResponse) ProtoMessage() {}
+
+func (x *CreateAlertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAlertResponse.ProtoReflect.Descriptor instead.
+func (*CreateAlertResponse) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateAlertResponse) GetAlertId() int32 {
+	if x != nil {
+		return x.AlertId
+	}
+	return 0
+}
+
+func (x *CreateAlertResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// GetAlertsRequest is the request message for retrieving alerts.
+type GetAlertsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // Filter by user (0 = all users)
+	ActiveOnly    bool                   `protobuf:"varint,2,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"` // Only return alerts in the ACTIVE state
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlertsRequest) Reset() {
+	*x = GetAlertsRequest{}
+	mi := &file_proto_alert_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlertsRequest) ProtoMessage() {}
+
+func (x *GetAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlertsRequest.ProtoReflect.Descriptor instead.
+func (*GetAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAlertsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetAlertsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+// Alert represents a single alert entry.
+type Alert struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	TargetPrice   float64                `protobuf:"fixed64,4,opt,name=target_price,json=targetPrice,proto3" json:"target_price,omitempty"`
+	Condition     AlertCondition         `protobuf:"varint,5,opt,name=condition,proto3,enum=alert.AlertCondition" json:"condition,omitempty"`
+	Triggered     bool                   `protobuf:"varint,6,opt,name=triggered,proto3" json:"triggered,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp
+	Status        AlertStatus            `protobuf:"varint,8,opt,name=status,proto3,enum=alert.AlertStatus" json:"status,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix timestamp (0 = never expires)
+	Window        *ActiveWindow          `protobuf:"bytes,10,opt,name=window,proto3" json:"window,omitempty"`
+	Kind          AlertKind              `protobuf:"varint,11,opt,name=kind,proto3,enum=alert.AlertKind" json:"kind,omitempty"`
+	Portfolio     *PortfolioRule         `protobuf:"bytes,12,opt,name=portfolio,proto3" json:"portfolio,omitempty"` // Set for PORTFOLIO alerts
+	Action        *OrderAction           `protobuf:"bytes,13,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_proto_alert_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Alert) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Alert) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Alert) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Alert) GetTargetPrice() float64 {
+	if x != nil {
+		return x.TargetPrice
+	}
+	return 0
+}
+
+func (x *Alert) GetCondition() AlertCondition {
+	if x != nil {
+		return x.Condition
+	}
+	return AlertCondition_CONDITION_UNSPECIFIED
+}
+
+func (x *Alert) GetTriggered() bool {
+	if x != nil {
+		return x.Triggered
+	}
+	return false
+}
+
+func (x *Alert) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Alert) GetStatus() AlertStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AlertStatus_STATUS_UNSPECIFIED
+}
+
+func (x *Alert) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Alert) GetWindow() *ActiveWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *Alert) GetKind() AlertKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AlertKind_KIND_UNSPECIFIED
+}
+
+func (x *Alert) GetPortfolio() *PortfolioRule {
+	if x != nil {
+		return x.Portfolio
+	}
+	return nil
+}
+
+func (x *Alert) GetAction() *OrderAction {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+// GetAlertsResponse is the response message containing a list of alerts.
+type GetAlertsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alerts        []*Alert               `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlertsResponse) Reset() {
+	*x = GetAlertsResponse{}
+	mi := &file_proto_alert_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlertsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlertsResponse) ProtoMessage() {}
+
+func (x *GetAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlertsResponse.ProtoReflect.Descriptor instead.
+func (*GetAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetAlertsResponse) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+// ListAlertTriggersRequest filters the trigger history. Zero values match everything.
+type ListAlertTriggersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`         // Filter by user (0 = all users)
+	AlertId       int32                  `protobuf:"varint,2,opt,name=alert_id,json=alertId,proto3" json:"alert_id,omitempty"`      // Filter by alert (0 = all alerts)
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`                        // Filter by symbol
+	From          int64                  `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`                           // Triggered at or after, Unix milliseconds
+	To            int64                  `protobuf:"varint,5,opt,name=to,proto3" json:"to,omitempty"`                               // Triggered before, Unix milliseconds
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Maximum results (default 50, max 500)
+	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token from a previous response
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertTriggersRequest) Reset() {
+	*x = ListAlertTriggersRequest{}
+	mi := &file_proto_alert_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertTriggersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertTriggersRequest) ProtoMessage() {}
+
+func (x *ListAlertTriggersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertTriggersRequest.ProtoReflect.Descriptor instead.
+func (*ListAlertTriggersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListAlertTriggersRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListAlertTriggersRequest) GetAlertId() int32 {
+	if x != nil {
+		return x.AlertId
+	}
+	return 0
+}
+
+func (x *ListAlertTriggersRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ListAlertTriggersRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ListAlertTriggersRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *ListAlertTriggersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAlertTriggersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// NotificationStatus is the delivery outcome of a trigger on one channel.
+type NotificationStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"` // e.g., "log"
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`   // PENDING, SENT or FAILED
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // Unix milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationStatus) Reset() {
+	*x = NotificationStatus{}
+	mi := &file_proto_alert_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationStatus) ProtoMessage() {}
+
+func (x *NotificationStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationStatus.ProtoReflect.Descriptor instead.
+func (*NotificationStatus) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NotificationStatus) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *NotificationStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *NotificationStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *NotificationStatus) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// AlertTrigger records a single firing of an alert.
+type AlertTrigger struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AlertId       int32                  `protobuf:"varint,2,opt,name=alert_id,json=alertId,proto3" json:"alert_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"` // Symbol of the tick that fired the alert
+	Condition     AlertCondition         `protobuf:"varint,5,opt,name=condition,proto3,enum=alert.AlertCondition" json:"condition,omitempty"`
+	TargetPrice   float64                `protobuf:"fixed64,6,opt,name=target_price,json=targetPrice,proto3" json:"target_price,omitempty"`
+	TriggerPrice  float64                `protobuf:"fixed64,7,opt,name=trigger_price,json=triggerPrice,proto3" json:"trigger_price,omitempty"`   // Price of the tick that fired the alert
+	TickTimestamp int64                  `protobuf:"varint,8,opt,name=tick_timestamp,json=tickTimestamp,proto3" json:"tick_timestamp,omitempty"` // Tick event time, Unix milliseconds
+	Partition     int32                  `protobuf:"varint,9,opt,name=partition,proto3" json:"partition,omitempty"`                              // Kafka partition of the tick
+	Offset        int64                  `protobuf:"varint,10,opt,name=offset,proto3" json:"offset,omitempty"`                                   // Kafka offset of the tick
+	TriggeredAt   int64                  `protobuf:"varint,11,opt,name=triggered_at,json=triggeredAt,proto3" json:"triggered_at,omitempty"`      // Unix milliseconds
+	Notifications []*NotificationStatus  `protobuf:"bytes,12,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	Kind          AlertKind              `protobuf:"varint,13,opt,name=kind,proto3,enum=alert.AlertKind" json:"kind,omitempty"`
+	Portfolio     *PortfolioRule         `protobuf:"bytes,14,opt,name=portfolio,proto3" json:"portfolio,omitempty"` // For PORTFOLIO alerts, whose trigger_price is the metric value
+	Action        *ActionResult          `protobuf:"bytes,15,opt,name=action,proto3" json:"action,omitempty"`       // Set if the alert has an action
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertTrigger) Reset() {
+	*x = AlertTrigger{}
+	mi := &file_proto_alert_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertTrigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertTrigger) ProtoMessage() {}
+
+func (x *AlertTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertTrigger.ProtoReflect.Descriptor instead.
+func (*AlertTrigger) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AlertTrigger) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AlertTrigger) GetAlertId() int32 {
+	if x != nil {
+		return x.AlertId
+	}
+	return 0
+}
+
+func (x *AlertTrigger) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AlertTrigger) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AlertTrigger) GetCondition() AlertCondition {
+	if x != nil {
+		return x.Condition
+	}
+	return AlertCondition_CONDITION_UNSPECIFIED
+}
+
+func (x *AlertTrigger) GetTargetPrice() float64 {
+	if x != nil {
+		return x.TargetPrice
+	}
+	return 0
+}
+
+func (x *AlertTrigger) GetTriggerPrice() float64 {
+	if x != nil {
+		return x.TriggerPrice
+	}
+	return 0
+}
+
+func (x *AlertTrigger) GetTickTimestamp() int64 {
+	if x != nil {
+		return x.TickTimestamp
+	}
+	return 0
+}
+
+func (x *AlertTrigger) GetPartition() int32 {
+	if x != nil {
+		return x.Partition
+	}
+	return 0
+}
+
+func (x *AlertTrigger) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *AlertTrigger) GetTriggeredAt() int64 {
+	if x != nil {
+		return x.TriggeredAt
+	}
+	return 0
+}
+
+func (x *AlertTrigger) GetNotifications() []*NotificationStatus {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *AlertTrigger) GetKind() AlertKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AlertKind_KIND_UNSPECIFIED
+}
+
+func (x *AlertTrigger) GetPortfolio() *PortfolioRule {
+	if x != nil {
+		return x.Portfolio
+	}
+	return nil
+}
+
+func (x *AlertTrigger) GetAction() *ActionResult {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+// ListAlertTriggersResponse is a page of triggers, newest first.
+type ListAlertTriggersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Triggers      []*AlertTrigger        `protobuf:"bytes,1,rep,name=triggers,proto3" json:"triggers,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty when there are no more results
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertTriggersResponse) Reset() {
+	*x = ListAlertTriggersResponse{}
+	mi := &file_proto_alert_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertTriggersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertTriggersResponse) ProtoMessage() {}
+
+func (x *ListAlertTriggersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertTriggersResponse.ProtoReflect.Descriptor instead.
+func (*ListAlertTriggersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListAlertTriggersResponse) GetTriggers() []*AlertTrigger {
+	if x != nil {
+		return x.Triggers
+	}
+	return nil
+}
+
+func (x *ListAlertTriggersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// WatchAlertsRequest subscribes to trigger events as they happen.
+type WatchAlertsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`                                  // Stock symbol, e.g., "AAPL"
-	TargetPrice   float64                `protobuf:"fixed64,3,opt,name=target_price,json=targetPrice,proto3" json:"target_price,omitempty"`   // Target price to trigger alert
-	Condition     AlertCondition         `protobuf:"varint,4,opt,name=condition,proto3,enum=alert.AlertCondition" json:"condition,omitempty"` // ABOVE or BELOW
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Filter by user (0 = all users)
+	Symbols       []string               `protobuf:"bytes,2,rep,name=symbols,proto3" json:"symbols,omitempty"`              // Filter by symbol (empty = all symbols)
+	Cursor        int64                  `protobuf:"varint,3,opt,name=cursor,proto3" json:"cursor,omitempty"`               // Resume after this cursor (0 = only new events)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAlertRequest) Reset() {
-	*x = CreateAlertRequest{}
-	mi := &file_proto_alert_proto_msgTypes[0]
+func (x *WatchAlertsRequest) Reset() {
+	*x = WatchAlertsRequest{}
+	mi := &file_proto_alert_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAlertRequest) String() string {
+func (x *WatchAlertsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAlertRequest) ProtoMessage() {}
+func (*WatchAlertsRequest) ProtoMessage() {}
 
-func (x *CreateAlertRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_alert_proto_msgTypes[0]
+func (x *WatchAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -107,63 +1406,105 @@ func (x *CreateAlertRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAlertRequest.ProtoReflect.Descriptor instead.
-func (*CreateAlertRequest) Descriptor() ([]byte, []int) {
-	return file_proto_alert_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use WatchAlertsRequest.ProtoReflect.Descriptor instead.
+func (*WatchAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *CreateAlertRequest) GetUserId() int32 {
+func (x *WatchAlertsRequest) GetUserId() int32 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *CreateAlertRequest) GetSymbol() string {
+func (x *WatchAlertsRequest) GetSymbols() []string {
 	if x != nil {
-		return x.Symbol
+		return x.Symbols
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateAlertRequest) GetTargetPrice() float64 {
+func (x *WatchAlertsRequest) GetCursor() int64 {
 	if x != nil {
-		return x.TargetPrice
+		return x.Cursor
 	}
 	return 0
 }
 
-func (x *CreateAlertRequest) GetCondition() AlertCondition {
+// Heartbeat keeps idle streams alive and reports the current cursor.
+type Heartbeat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Server time, Unix milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Heartbeat) Reset() {
+	*x = Heartbeat{}
+	mi := &file_proto_alert_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Heartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heartbeat) ProtoMessage() {}
+
+func (x *Heartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[14]
 	if x != nil {
-		return x.Condition
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return AlertCondition_CONDITION_UNSPECIFIED
+	return mi.MessageOf(x)
 }
 
-// CreateAlertResponse is the response message after creating an alert.
-type CreateAlertResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AlertId       int32                  `protobuf:"varint,1,opt,name=alert_id,json=alertId,proto3" json:"alert_id,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+// Deprecated: Use Heartbeat.ProtoReflect.Descriptor instead.
+func (*Heartbeat) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Heartbeat) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// AlertEvent is a single message on a WatchAlerts stream.
+type AlertEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Cursor int64                  `protobuf:"varint,1,opt,name=cursor,proto3" json:"cursor,omitempty"` // Pass back in WatchAlertsRequest.cursor to resume
+	// Types that are valid to be assigned to Event:
+	//
+	//	*AlertEvent_Trigger
+	//	*AlertEvent_Heartbeat
+	Event         isAlertEvent_Event `protobuf_oneof:"event"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAlertResponse) Reset() {
-	*x = CreateAlertResponse{}
-	mi := &file_proto_alert_proto_msgTypes[1]
+func (x *AlertEvent) Reset() {
+	*x = AlertEvent{}
+	mi := &file_proto_alert_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAlertResponse) String() string {
+func (x *AlertEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAlertResponse) ProtoMessage() {}
+func (*AlertEvent) ProtoMessage() {}
 
-func (x *CreateAlertResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_alert_proto_msgTypes[1]
+func (x *AlertEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -174,49 +1515,90 @@ func (x *CreateAlertResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAlertResponse.ProtoReflect.Descriptor instead.
-func (*CreateAlertResponse) Descriptor() ([]byte, []int) {
-	return file_proto_alert_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use AlertEvent.ProtoReflect.Descriptor instead.
+func (*AlertEvent) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *CreateAlertResponse) GetAlertId() int32 {
+func (x *AlertEvent) GetCursor() int64 {
 	if x != nil {
-		return x.AlertId
+		return x.Cursor
 	}
 	return 0
 }
 
-func (x *CreateAlertResponse) GetMessage() string {
+func (x *AlertEvent) GetEvent() isAlertEvent_Event {
 	if x != nil {
-		return x.Message
+		return x.Event
 	}
-	return ""
+	return nil
 }
 
-// GetAlertsRequest is the request message for retrieving alerts.
-type GetAlertsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // Filter by user (0 = all users)
-	ActiveOnly    bool                   `protobuf:"varint,2,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"` // Only return non-triggered alerts
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AlertEvent) GetTrigger() *AlertTrigger {
+	if x != nil {
+		if x, ok := x.Event.(*AlertEvent_Trigger); ok {
+			return x.Trigger
+		}
+	}
+	return nil
 }
 
-func (x *GetAlertsRequest) Reset() {
-	*x = GetAlertsRequest{}
-	mi := &file_proto_alert_proto_msgTypes[2]
+func (x *AlertEvent) GetHeartbeat() *Heartbeat {
+	if x != nil {
+		if x, ok := x.Event.(*AlertEvent_Heartbeat); ok {
+			return x.Heartbeat
+		}
+	}
+	return nil
+}
+
+type isAlertEvent_Event interface {
+	isAlertEvent_Event()
+}
+
+type AlertEvent_Trigger struct {
+	Trigger *AlertTrigger `protobuf:"bytes,2,opt,name=trigger,proto3,oneof"`
+}
+
+type AlertEvent_Heartbeat struct {
+	Heartbeat *Heartbeat `protobuf:"bytes,3,opt,name=heartbeat,proto3,oneof"`
+}
+
+func (*AlertEvent_Trigger) isAlertEvent_Event() {}
+
+func (*AlertEvent_Heartbeat) isAlertEvent_Event() {}
+
+// BacktestAlertRequest replays a symbol's stored history through a price
+// alert's condition and window.
+type BacktestAlertRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	TargetPrice     float64                `protobuf:"fixed64,2,opt,name=target_price,json=targetPrice,proto3" json:"target_price,omitempty"`
+	Condition       AlertCondition         `protobuf:"varint,3,opt,name=condition,proto3,enum=alert.AlertCondition" json:"condition,omitempty"`
+	Window          *ActiveWindow          `protobuf:"bytes,4,opt,name=window,proto3" json:"window,omitempty"`
+	MarketHoursOnly bool                   `protobuf:"varint,5,opt,name=market_hours_only,json=marketHoursOnly,proto3" json:"market_hours_only,omitempty"`
+	From            int64                  `protobuf:"varint,6,opt,name=from,proto3" json:"from,omitempty"`        // Unix milliseconds (inclusive)
+	To              int64                  `protobuf:"varint,7,opt,name=to,proto3" json:"to,omitempty"`            // Unix milliseconds (exclusive, 0 = now)
+	Interval        string                 `protobuf:"bytes,8,opt,name=interval,proto3" json:"interval,omitempty"` // "tick" (default) or a candle interval: 1m, 5m, 15m, 30m, 1h, 4h, 1d
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BacktestAlertRequest) Reset() {
+	*x = BacktestAlertRequest{}
+	mi := &file_proto_alert_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAlertsRequest) String() string {
+func (x *BacktestAlertRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAlertsRequest) ProtoMessage() {}
+func (*BacktestAlertRequest) ProtoMessage() {}
 
-func (x *GetAlertsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_alert_proto_msgTypes[2]
+func (x *BacktestAlertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -227,54 +1609,91 @@ func (x *GetAlertsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAlertsRequest.ProtoReflect.Descriptor instead.
-func (*GetAlertsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_alert_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use BacktestAlertRequest.ProtoReflect.Descriptor instead.
+func (*BacktestAlertRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GetAlertsRequest) GetUserId() int32 {
+func (x *BacktestAlertRequest) GetSymbol() string {
 	if x != nil {
-		return x.UserId
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *BacktestAlertRequest) GetTargetPrice() float64 {
+	if x != nil {
+		return x.TargetPrice
 	}
 	return 0
 }
 
-func (x *GetAlertsRequest) GetActiveOnly() bool {
+func (x *BacktestAlertRequest) GetCondition() AlertCondition {
 	if x != nil {
-		return x.ActiveOnly
+		return x.Condition
+	}
+	return AlertCondition_CONDITION_UNSPECIFIED
+}
+
+func (x *BacktestAlertRequest) GetWindow() *ActiveWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *BacktestAlertRequest) GetMarketHoursOnly() bool {
+	if x != nil {
+		return x.MarketHoursOnly
 	}
 	return false
 }
 
-// Alert represents a single alert entry.
-type Alert struct {
+func (x *BacktestAlertRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *BacktestAlertRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *BacktestAlertRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+// BacktestTrigger is a trigger the alert would have recorded.
+type BacktestTrigger struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	TargetPrice   float64                `protobuf:"fixed64,4,opt,name=target_price,json=targetPrice,proto3" json:"target_price,omitempty"`
-	Condition     AlertCondition         `protobuf:"varint,5,opt,name=condition,proto3,enum=alert.AlertCondition" json:"condition,omitempty"`
-	Triggered     bool                   `protobuf:"varint,6,opt,name=triggered,proto3" json:"triggered,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Tick time or candle start, Unix milliseconds
+	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`        // Tick price, or the candle's high (ABOVE) or low (BELOW)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Alert) Reset() {
-	*x = Alert{}
-	mi := &file_proto_alert_proto_msgTypes[3]
+func (x *BacktestTrigger) Reset() {
+	*x = BacktestTrigger{}
+	mi := &file_proto_alert_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Alert) String() string {
+func (x *BacktestTrigger) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Alert) ProtoMessage() {}
+func (*BacktestTrigger) ProtoMessage() {}
 
-func (x *Alert) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_alert_proto_msgTypes[3]
+func (x *BacktestTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -285,83 +1704,166 @@ func (x *Alert) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
-func (*Alert) Descriptor() ([]byte, []int) {
-	return file_proto_alert_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use BacktestTrigger.ProtoReflect.Descriptor instead.
+func (*BacktestTrigger) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *Alert) GetId() int32 {
+func (x *BacktestTrigger) GetTimestamp() int64 {
 	if x != nil {
-		return x.Id
+		return x.Timestamp
 	}
 	return 0
 }
 
-func (x *Alert) GetUserId() int32 {
+func (x *BacktestTrigger) GetPrice() float64 {
 	if x != nil {
-		return x.UserId
+		return x.Price
 	}
 	return 0
 }
 
-func (x *Alert) GetSymbol() string {
+// BacktestSummary describes a backtest run.
+type BacktestSummary struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TriggerCount   int32                  `protobuf:"varint,1,opt,name=trigger_count,json=triggerCount,proto3" json:"trigger_count,omitempty"`
+	Points         int64                  `protobuf:"varint,2,opt,name=points,proto3" json:"points,omitempty"`                                         // Ticks or candles evaluated
+	PointsInWindow int64                  `protobuf:"varint,3,opt,name=points_in_window,json=pointsInWindow,proto3" json:"points_in_window,omitempty"` // Of which inside the alert's window
+	PointsMatched  int64                  `protobuf:"varint,4,opt,name=points_matched,json=pointsMatched,proto3" json:"points_matched,omitempty"`      // Of which meeting the condition
+	MinPrice       float64                `protobuf:"fixed64,5,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice       float64                `protobuf:"fixed64,6,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	FirstTriggerAt int64                  `protobuf:"varint,7,opt,name=first_trigger_at,json=firstTriggerAt,proto3" json:"first_trigger_at,omitempty"` // Unix milliseconds (0 = never triggered)
+	LastTriggerAt  int64                  `protobuf:"varint,8,opt,name=last_trigger_at,json=lastTriggerAt,proto3" json:"last_trigger_at,omitempty"`
+	Truncated      bool                   `protobuf:"varint,9,opt,name=truncated,proto3" json:"truncated,omitempty"`                         // The point limit was reached before to
+	EvaluatedTo    int64                  `protobuf:"varint,10,opt,name=evaluated_to,json=evaluatedTo,proto3" json:"evaluated_to,omitempty"` // Time of the last point evaluated, Unix milliseconds
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BacktestSummary) Reset() {
+	*x = BacktestSummary{}
+	mi := &file_proto_alert_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacktestSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacktestSummary) ProtoMessage() {}
+
+func (x *BacktestSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[18]
 	if x != nil {
-		return x.Symbol
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Alert) GetTargetPrice() float64 {
+// Deprecated: Use BacktestSummary.ProtoReflect.Descriptor instead.
+func (*BacktestSummary) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BacktestSummary) GetTriggerCount() int32 {
 	if x != nil {
-		return x.TargetPrice
+		return x.TriggerCount
 	}
 	return 0
 }
 
-func (x *Alert) GetCondition() AlertCondition {
+func (x *BacktestSummary) GetPoints() int64 {
 	if x != nil {
-		return x.Condition
+		return x.Points
 	}
-	return AlertCondition_CONDITION_UNSPECIFIED
+	return 0
 }
 
-func (x *Alert) GetTriggered() bool {
+func (x *BacktestSummary) GetPointsInWindow() int64 {
 	if x != nil {
-		return x.Triggered
+		return x.PointsInWindow
+	}
+	return 0
+}
+
+func (x *BacktestSummary) GetPointsMatched() int64 {
+	if x != nil {
+		return x.PointsMatched
+	}
+	return 0
+}
+
+func (x *BacktestSummary) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *BacktestSummary) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *BacktestSummary) GetFirstTriggerAt() int64 {
+	if x != nil {
+		return x.FirstTriggerAt
+	}
+	return 0
+}
+
+func (x *BacktestSummary) GetLastTriggerAt() int64 {
+	if x != nil {
+		return x.LastTriggerAt
+	}
+	return 0
+}
+
+func (x *BacktestSummary) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
 	}
 	return false
 }
 
-func (x *Alert) GetCreatedAt() int64 {
+func (x *BacktestSummary) GetEvaluatedTo() int64 {
 	if x != nil {
-		return x.CreatedAt
+		return x.EvaluatedTo
 	}
 	return 0
 }
 
-// GetAlertsResponse is the response message containing a list of alerts.
-type GetAlertsResponse struct {
+// BacktestAlertResponse lists every hypothetical trigger, oldest first.
+type BacktestAlertResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Alerts        []*Alert               `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	Triggers      []*BacktestTrigger     `protobuf:"bytes,1,rep,name=triggers,proto3" json:"triggers,omitempty"`
+	Summary       *BacktestSummary       `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAlertsResponse) Reset() {
-	*x = GetAlertsResponse{}
-	mi := &file_proto_alert_proto_msgTypes[4]
+func (x *BacktestAlertResponse) Reset() {
+	*x = BacktestAlertResponse{}
+	mi := &file_proto_alert_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAlertsResponse) String() string {
+func (x *BacktestAlertResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAlertsResponse) ProtoMessage() {}
+func (*BacktestAlertResponse) ProtoMessage() {}
 
-func (x *GetAlertsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_alert_proto_msgTypes[4]
+func (x *BacktestAlertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -372,14 +1874,21 @@ func (x *GetAlertsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAlertsResponse.ProtoReflect.Descriptor instead.
-func (*GetAlertsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_alert_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use BacktestAlertResponse.ProtoReflect.Descriptor instead.
+func (*BacktestAlertResponse) Descriptor() ([]byte, []int) {
+	return file_proto_alert_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetAlertsResponse) GetAlerts() []*Alert {
+func (x *BacktestAlertResponse) GetTriggers() []*BacktestTrigger {
 	if x != nil {
-		return x.Alerts
+		return x.Triggers
+	}
+	return nil
+}
+
+func (x *BacktestAlertResponse) GetSummary() *BacktestSummary {
+	if x != nil {
+		return x.Summary
 	}
 	return nil
 }
@@ -388,19 +1897,52 @@ var File_proto_alert_proto protoreflect.FileDescriptor
 
 const file_proto_alert_proto_rawDesc = "" +
 	"\n" +
-	"\x11proto/alert.proto\x12\x05alert\"\x9d\x01\n" +
+	"\x11proto/alert.proto\x12\x05alert\x1a\x13proto/trading.proto\"\x98\x01\n" +
+	"\rPortfolioRule\x12!\n" +
+	"\fportfolio_id\x18\x01 \x01(\x05R\vportfolioId\x12.\n" +
+	"\x06metric\x18\x02 \x01(\x0e2\x16.alert.PortfolioMetricR\x06metric\x12\x1c\n" +
+	"\tthreshold\x18\x03 \x01(\x01R\tthreshold\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\"\xcc\x01\n" +
+	"\vOrderAction\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12!\n" +
+	"\x04side\x18\x02 \x01(\x0e2\r.trading.SideR\x04side\x12&\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x12.trading.OrderTypeR\x04type\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x01R\bquantity\x12\x1f\n" +
+	"\vlimit_price\x18\x05 \x01(\x01R\n" +
+	"limitPrice\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\x06 \x01(\x01R\tstopPrice\"\x88\x01\n" +
+	"\fActionResult\x12+\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x13.alert.ActionStatusR\x06status\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x03R\aorderId\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1a\n" +
+	"\battempts\x18\x04 \x01(\x05R\battempts\"\x93\x01\n" +
+	"\fActiveWindow\x12\x1a\n" +
+	"\btimezone\x18\x01 \x01(\tR\btimezone\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\tR\x03end\x12\x1a\n" +
+	"\bweekdays\x18\x04 \x03(\x05R\bweekdays\x12#\n" +
+	"\rskip_holidays\x18\x05 \x01(\bR\fskipHolidays\"\x9b\x03\n" +
 	"\x12CreateAlertRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x16\n" +
 	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12!\n" +
 	"\ftarget_price\x18\x03 \x01(\x01R\vtargetPrice\x123\n" +
-	"\tcondition\x18\x04 \x01(\x0e2\x15.alert.AlertConditionR\tcondition\"J\n" +
+	"\tcondition\x18\x04 \x01(\x0e2\x15.alert.AlertConditionR\tcondition\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\x03R\texpiresAt\x12+\n" +
+	"\x06window\x18\x06 \x01(\v2\x13.alert.ActiveWindowR\x06window\x12*\n" +
+	"\x11market_hours_only\x18\a \x01(\bR\x0fmarketHoursOnly\x12$\n" +
+	"\x04kind\x18\b \x01(\x0e2\x10.alert.AlertKindR\x04kind\x122\n" +
+	"\tportfolio\x18\t \x01(\v2\x14.alert.PortfolioRuleR\tportfolio\x12*\n" +
+	"\x06action\x18\n" +
+	" \x01(\v2\x12.alert.OrderActionR\x06action\"J\n" +
 	"\x13CreateAlertResponse\x12\x19\n" +
 	"\balert_id\x18\x01 \x01(\x05R\aalertId\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"L\n" +
 	"\x10GetAlertsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1f\n" +
 	"\vactive_only\x18\x02 \x01(\bR\n" +
-	"activeOnly\"\xdd\x01\n" +
+	"activeOnly\"\xdb\x03\n" +
 	"\x05Alert\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x16\n" +
@@ -409,16 +1951,124 @@ const file_proto_alert_proto_rawDesc = "" +
 	"\tcondition\x18\x05 \x01(\x0e2\x15.alert.AlertConditionR\tcondition\x12\x1c\n" +
 	"\ttriggered\x18\x06 \x01(\bR\ttriggered\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\a \x01(\x03R\tcreatedAt\"9\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\x12*\n" +
+	"\x06status\x18\b \x01(\x0e2\x12.alert.AlertStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\x03R\texpiresAt\x12+\n" +
+	"\x06window\x18\n" +
+	" \x01(\v2\x13.alert.ActiveWindowR\x06window\x12$\n" +
+	"\x04kind\x18\v \x01(\x0e2\x10.alert.AlertKindR\x04kind\x122\n" +
+	"\tportfolio\x18\f \x01(\v2\x14.alert.PortfolioRuleR\tportfolio\x12*\n" +
+	"\x06action\x18\r \x01(\v2\x12.alert.OrderActionR\x06action\"9\n" +
 	"\x11GetAlertsResponse\x12$\n" +
-	"\x06alerts\x18\x01 \x03(\v2\f.alert.AlertR\x06alerts*A\n" +
+	"\x06alerts\x18\x01 \x03(\v2\f.alert.AlertR\x06alerts\"\xc6\x01\n" +
+	"\x18ListAlertTriggersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x19\n" +
+	"\balert_id\x18\x02 \x01(\x05R\aalertId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\x03R\x02to\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"{\n" +
+	"\x12NotificationStatus\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\x03R\tupdatedAt\"\xaf\x04\n" +
+	"\fAlertTrigger\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\balert_id\x18\x02 \x01(\x05R\aalertId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x123\n" +
+	"\tcondition\x18\x05 \x01(\x0e2\x15.alert.AlertConditionR\tcondition\x12!\n" +
+	"\ftarget_price\x18\x06 \x01(\x01R\vtargetPrice\x12#\n" +
+	"\rtrigger_price\x18\a \x01(\x01R\ftriggerPrice\x12%\n" +
+	"\x0etick_timestamp\x18\b \x01(\x03R\rtickTimestamp\x12\x1c\n" +
+	"\tpartition\x18\t \x01(\x05R\tpartition\x12\x16\n" +
+	"\x06offset\x18\n" +
+	" \x01(\x03R\x06offset\x12!\n" +
+	"\ftriggered_at\x18\v \x01(\x03R\vtriggeredAt\x12?\n" +
+	"\rnotifications\x18\f \x03(\v2\x19.alert.NotificationStatusR\rnotifications\x12$\n" +
+	"\x04kind\x18\r \x01(\x0e2\x10.alert.AlertKindR\x04kind\x122\n" +
+	"\tportfolio\x18\x0e \x01(\v2\x14.alert.PortfolioRuleR\tportfolio\x12+\n" +
+	"\x06action\x18\x0f \x01(\v2\x13.alert.ActionResultR\x06action\"t\n" +
+	"\x19ListAlertTriggersResponse\x12/\n" +
+	"\btriggers\x18\x01 \x03(\v2\x13.alert.AlertTriggerR\btriggers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"_\n" +
+	"\x12WatchAlertsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x18\n" +
+	"\asymbols\x18\x02 \x03(\tR\asymbols\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\x03R\x06cursor\")\n" +
+	"\tHeartbeat\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\"\x90\x01\n" +
+	"\n" +
+	"AlertEvent\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\x03R\x06cursor\x12/\n" +
+	"\atrigger\x18\x02 \x01(\v2\x13.alert.AlertTriggerH\x00R\atrigger\x120\n" +
+	"\theartbeat\x18\x03 \x01(\v2\x10.alert.HeartbeatH\x00R\theartbeatB\a\n" +
+	"\x05event\"\x9f\x02\n" +
+	"\x14BacktestAlertRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12!\n" +
+	"\ftarget_price\x18\x02 \x01(\x01R\vtargetPrice\x123\n" +
+	"\tcondition\x18\x03 \x01(\x0e2\x15.alert.AlertConditionR\tcondition\x12+\n" +
+	"\x06window\x18\x04 \x01(\v2\x13.alert.ActiveWindowR\x06window\x12*\n" +
+	"\x11market_hours_only\x18\x05 \x01(\bR\x0fmarketHoursOnly\x12\x12\n" +
+	"\x04from\x18\x06 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\a \x01(\x03R\x02to\x12\x1a\n" +
+	"\binterval\x18\b \x01(\tR\binterval\"E\n" +
+	"\x0fBacktestTrigger\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\"\xec\x02\n" +
+	"\x0fBacktestSummary\x12#\n" +
+	"\rtrigger_count\x18\x01 \x01(\x05R\ftriggerCount\x12\x16\n" +
+	"\x06points\x18\x02 \x01(\x03R\x06points\x12(\n" +
+	"\x10points_in_window\x18\x03 \x01(\x03R\x0epointsInWindow\x12%\n" +
+	"\x0epoints_matched\x18\x04 \x01(\x03R\rpointsMatched\x12\x1b\n" +
+	"\tmin_price\x18\x05 \x01(\x01R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x06 \x01(\x01R\bmaxPrice\x12(\n" +
+	"\x10first_trigger_at\x18\a \x01(\x03R\x0efirstTriggerAt\x12&\n" +
+	"\x0flast_trigger_at\x18\b \x01(\x03R\rlastTriggerAt\x12\x1c\n" +
+	"\ttruncated\x18\t \x01(\bR\ttruncated\x12!\n" +
+	"\fevaluated_to\x18\n" +
+	" \x01(\x03R\vevaluatedTo\"}\n" +
+	"\x15BacktestAlertResponse\x122\n" +
+	"\btriggers\x18\x01 \x03(\v2\x16.alert.BacktestTriggerR\btriggers\x120\n" +
+	"\asummary\x18\x02 \x01(\v2\x16.alert.BacktestSummaryR\asummary*A\n" +
 	"\x0eAlertCondition\x12\x19\n" +
 	"\x15CONDITION_UNSPECIFIED\x10\x00\x12\t\n" +
 	"\x05ABOVE\x10\x01\x12\t\n" +
-	"\x05BELOW\x10\x022\x94\x01\n" +
+	"\x05BELOW\x10\x02*M\n" +
+	"\vAlertStatus\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06ACTIVE\x10\x01\x12\r\n" +
+	"\tTRIGGERED\x10\x02\x12\v\n" +
+	"\aEXPIRED\x10\x03*;\n" +
+	"\tAlertKind\x12\x14\n" +
+	"\x10KIND_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05PRICE\x10\x01\x12\r\n" +
+	"\tPORTFOLIO\x10\x02*\x8c\x01\n" +
+	"\x0fPortfolioMetric\x12\x16\n" +
+	"\x12METRIC_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eUNREALIZED_PNL\x10\x01\x12\x0e\n" +
+	"\n" +
+	"DAY_CHANGE\x10\x02\x12\x16\n" +
+	"\x12DAY_CHANGE_PERCENT\x10\x03\x12\x10\n" +
+	"\fMARKET_VALUE\x10\x04\x12\x13\n" +
+	"\x0fPOSITION_WEIGHT\x10\x05*g\n" +
+	"\fActionStatus\x12\x1d\n" +
+	"\x19ACTION_STATUS_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eACTION_PENDING\x10\x01\x12\x11\n" +
+	"\rACTION_PLACED\x10\x02\x12\x11\n" +
+	"\rACTION_FAILED\x10\x032\xf7\x02\n" +
 	"\fAlertService\x12D\n" +
 	"\vCreateAlert\x12\x19.alert.CreateAlertRequest\x1a\x1a.alert.CreateAlertResponse\x12>\n" +
-	"\tGetAlerts\x12\x17.alert.GetAlertsRequest\x1a\x18.alert.GetAlertsResponseB*Z(github.com/tiongMax/gostocks/proto/alertb\x06proto3"
+	"\tGetAlerts\x12\x17.alert.GetAlertsRequest\x1a\x18.alert.GetAlertsResponse\x12V\n" +
+	"\x11ListAlertTriggers\x12\x1f.alert.ListAlertTriggersRequest\x1a .alert.ListAlertTriggersResponse\x12=\n" +
+	"\vWatchAlerts\x12\x19.alert.WatchAlertsRequest\x1a\x11.alert.AlertEvent0\x01\x12J\n" +
+	"\rBacktestAlert\x12\x1b.alert.BacktestAlertRequest\x1a\x1c.alert.BacktestAlertResponseB*Z(github.com/tiongMax/gostocks/proto/alertb\x06proto3"
 
 var (
 	file_proto_alert_proto_rawDescOnce sync.Once
@@ -432,29 +2082,81 @@ func file_proto_alert_proto_rawDescGZIP() []byte {
 	return file_proto_alert_proto_rawDescData
 }
 
-var file_proto_alert_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_alert_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_alert_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_proto_alert_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_proto_alert_proto_goTypes = []any{
-	(AlertCondition)(0),         // 0: alert.AlertCondition
-	(*CreateAlertRequest)(nil),  // 1: alert.CreateAlertRequest
-	(*CreateAlertResponse)(nil), // 2: alert.CreateAlertResponse
-	(*GetAlertsRequest)(nil),    // 3: alert.GetAlertsRequest
-	(*Alert)(nil),               // 4: alert.Alert
-	(*GetAlertsResponse)(nil),   // 5: alert.GetAlertsResponse
+	(AlertCondition)(0),               // 0: alert.AlertCondition
+	(AlertStatus)(0),                  // 1: alert.AlertStatus
+	(AlertKind)(0),                    // 2: alert.AlertKind
+	(PortfolioMetric)(0),              // 3: alert.PortfolioMetric
+	(ActionStatus)(0),                 // 4: alert.ActionStatus
+	(*PortfolioRule)(nil),             // 5: alert.PortfolioRule
+	(*OrderAction)(nil),               // 6: alert.OrderAction
+	(*ActionResult)(nil),              // 7: alert.ActionResult
+	(*ActiveWindow)(nil),              // 8: alert.ActiveWindow
+	(*CreateAlertRequest)(nil),        // 9: alert.CreateAlertRequest
+	(*CreateAlertResponse)(nil),       // 10: alert.CreateAlertResponse
+	(*GetAlertsRequest)(nil),          // 11: alert.GetAlertsRequest
+	(*Alert)(nil),                     // 12: alert.Alert
+	(*GetAlertsResponse)(nil),         // 13: alert.GetAlertsResponse
+	(*ListAlertTriggersRequest)(nil),  // 14: alert.ListAlertTriggersRequest
+	(*NotificationStatus)(nil),        // 15: alert.NotificationStatus
+	(*AlertTrigger)(nil),              // 16: alert.AlertTrigger
+	(*ListAlertTriggersResponse)(nil), // 17: alert.ListAlertTriggersResponse
+	(*WatchAlertsRequest)(nil),        // 18: alert.WatchAlertsRequest
+	(*Heartbeat)(nil),                 // 19: alert.Heartbeat
+	(*AlertEvent)(nil),                // 20: alert.AlertEvent
+	(*BacktestAlertRequest)(nil),      // 21: alert.BacktestAlertRequest
+	(*BacktestTrigger)(nil),           // 22: alert.BacktestTrigger
+	(*BacktestSummary)(nil),           // 23: alert.BacktestSummary
+	(*BacktestAlertResponse)(nil),     // 24: alert.BacktestAlertResponse
+	(trading.Side)(0),                 // 25: trading.Side
+	(trading.OrderType)(0),            // 26: trading.OrderType
 }
 var file_proto_alert_proto_depIdxs = []int32{
-	0, // 0: alert.CreateAlertRequest.condition:type_name -> alert.AlertCondition
-	0, // 1: alert.Alert.condition:type_name -> alert.AlertCondition
-	4, // 2: alert.GetAlertsResponse.alerts:type_name -> alert.Alert
-	1, // 3: alert.AlertService.CreateAlert:input_type -> alert.CreateAlertRequest
-	3, // 4: alert.AlertService.GetAlerts:input_type -> alert.GetAlertsRequest
-	2, // 5: alert.AlertService.CreateAlert:output_type -> alert.CreateAlertResponse
-	5, // 6: alert.AlertService.GetAlerts:output_type -> alert.GetAlertsResponse
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3,  // 0: alert.PortfolioRule.metric:type_name -> alert.PortfolioMetric
+	25, // 1: alert.OrderAction.side:type_name -> trading.Side
+	26, // 2: alert.OrderAction.type:type_name -> trading.OrderType
+	4,  // 3: alert.ActionResult.status:type_name -> alert.ActionStatus
+	0,  // 4: alert.CreateAlertRequest.condition:type_name -> alert.AlertCondition
+	8,  // 5: alert.CreateAlertRequest.window:type_name -> alert.ActiveWindow
+	2,  // 6: alert.CreateAlertRequest.kind:type_name -> alert.AlertKind
+	5,  // 7: alert.CreateAlertRequest.portfolio:type_name -> alert.PortfolioRule
+	6,  // 8: alert.CreateAlertRequest.action:type_name -> alert.OrderAction
+	0,  // 9: alert.Alert.condition:type_name -> alert.AlertCondition
+	1,  // 10: alert.Alert.status:type_name -> alert.AlertStatus
+	8,  // 11: alert.Alert.window:type_name -> alert.ActiveWindow
+	2,  // 12: alert.Alert.kind:type_name -> alert.AlertKind
+	5,  // 13: alert.Alert.portfolio:type_name -> alert.PortfolioRule
+	6,  // 14: alert.Alert.action:type_name -> alert.OrderAction
+	12, // 15: alert.GetAlertsResponse.alerts:type_name -> alert.Alert
+	0,  // 16: alert.AlertTrigger.condition:type_name -> alert.AlertCondition
+	15, // 17: alert.AlertTrigger.notifications:type_name -> alert.NotificationStatus
+	2,  // 18: alert.AlertTrigger.kind:type_name -> alert.AlertKind
+	5,  // 19: alert.AlertTrigger.portfolio:type_name -> alert.PortfolioRule
+	7,  // 20: alert.AlertTrigger.action:type_name -> alert.ActionResult
+	16, // 21: alert.ListAlertTriggersResponse.triggers:type_name -> alert.AlertTrigger
+	16, // 22: alert.AlertEvent.trigger:type_name -> alert.AlertTrigger
+	19, // 23: alert.AlertEvent.heartbeat:type_name -> alert.Heartbeat
+	0,  // 24: alert.BacktestAlertRequest.condition:type_name -> alert.AlertCondition
+	8,  // 25: alert.BacktestAlertRequest.window:type_name -> alert.ActiveWindow
+	22, // 26: alert.BacktestAlertResponse.triggers:type_name -> alert.BacktestTrigger
+	23, // 27: alert.BacktestAlertResponse.summary:type_name -> alert.BacktestSummary
+	9,  // 28: alert.AlertService.CreateAlert:input_type -> alert.CreateAlertRequest
+	11, // 29: alert.AlertService.GetAlerts:input_type -> alert.GetAlertsRequest
+	14, // 30: alert.AlertService.ListAlertTriggers:input_type -> alert.ListAlertTriggersRequest
+	18, // 31: alert.AlertService.WatchAlerts:input_type -> alert.WatchAlertsRequest
+	21, // 32: alert.AlertService.BacktestAlert:input_type -> alert.BacktestAlertRequest
+	10, // 33: alert.AlertService.CreateAlert:output_type -> alert.CreateAlertResponse
+	13, // 34: alert.AlertService.GetAlerts:output_type -> alert.GetAlertsResponse
+	17, // 35: alert.AlertService.ListAlertTriggers:output_type -> alert.ListAlertTriggersResponse
+	20, // 36: alert.AlertService.WatchAlerts:output_type -> alert.AlertEvent
+	24, // 37: alert.AlertService.BacktestAlert:output_type -> alert.BacktestAlertResponse
+	33, // [33:38] is the sub-list for method output_type
+	28, // [28:33] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_proto_alert_proto_init() }
@@ -462,13 +2164,17 @@ func file_proto_alert_proto_init() {
 	if File_proto_alert_proto != nil {
 		return
 	}
+	file_proto_alert_proto_msgTypes[15].OneofWrappers = []any{
+		(*AlertEvent_Trigger)(nil),
+		(*AlertEvent_Heartbeat)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_alert_proto_rawDesc), len(file_proto_alert_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   5,
+			NumEnums:      5,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
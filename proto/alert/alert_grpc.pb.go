@@ -19,8 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AlertService_CreateAlert_FullMethodName = "/alert.AlertService/CreateAlert"
-	AlertService_GetAlerts_FullMethodName   = "/alert.AlertService/GetAlerts"
+	AlertService_CreateAlert_FullMethodName       = "/alert.AlertService/CreateAlert"
+	AlertService_GetAlerts_FullMethodName         = "/alert.AlertService/GetAlerts"
+	AlertService_ListAlertTriggers_FullMethodName = "/alert.AlertService/ListAlertTriggers"
+	AlertService_WatchAlerts_FullMethodName       = "/alert.AlertService/WatchAlerts"
+	AlertService_BacktestAlert_FullMethodName     = "/alert.AlertService/BacktestAlert"
 )
 
 // AlertServiceClient is the client API for AlertService service.
@@ -33,6 +36,13 @@ type AlertServiceClient interface {
 	CreateAlert(ctx context.Context, in *CreateAlertRequest, opts ...grpc.CallOption) (*CreateAlertResponse, error)
 	// GetAlerts retrieves alerts based on filter criteria.
 	GetAlerts(ctx context.Context, in *GetAlertsRequest, opts ...grpc.CallOption) (*GetAlertsResponse, error)
+	// ListAlertTriggers retrieves the trigger history of alerts.
+	ListAlertTriggers(ctx context.Context, in *ListAlertTriggersRequest, opts ...grpc.CallOption) (*ListAlertTriggersResponse, error)
+	// WatchAlerts streams trigger events, resuming from a cursor after reconnects.
+	WatchAlerts(ctx context.Context, in *WatchAlertsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AlertEvent], error)
+	// BacktestAlert replays stored ticks or candles through a price alert to
+	// show how often it would have fired.
+	BacktestAlert(ctx context.Context, in *BacktestAlertRequest, opts ...grpc.CallOption) (*BacktestAlertResponse, error)
 }
 
 type alertServiceClient struct {
@@ -63,6 +73,45 @@ func (c *alertServiceClient) GetAlerts(ctx context.Context, in *GetAlertsRequest
 	return out, nil
 }
 
+func (c *alertServiceClient) ListAlertTriggers(ctx context.Context, in *ListAlertTriggersRequest, opts ...grpc.CallOption) (*ListAlertTriggersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAlertTriggersResponse)
+	err := c.cc.Invoke(ctx, AlertService_ListAlertTriggers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertServiceClient) WatchAlerts(ctx context.Context, in *WatchAlertsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AlertEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AlertService_ServiceDesc.Streams[0], AlertService_WatchAlerts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAlertsRequest, AlertEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AlertService_WatchAlertsClient = grpc.ServerStreamingClient[AlertEvent]
+
+func (c *alertServiceClient) BacktestAlert(ctx context.Context, in *BacktestAlertRequest, opts ...grpc.CallOption) (*BacktestAlertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BacktestAlertResponse)
+	err := c.cc.Invoke(ctx, AlertService_BacktestAlert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AlertServiceServer is the server API for AlertService service.
 // All implementations must embed UnimplementedAlertServiceServer
 // for forward compatibility.
@@ -73,6 +122,13 @@ type AlertServiceServer interface {
 	CreateAlert(context.Context, *CreateAlertRequest) (*CreateAlertResponse, error)
 	// GetAlerts retrieves alerts based on filter criteria.
 	GetAlerts(context.Context, *GetAlertsRequest) (*GetAlertsResponse, error)
+	// ListAlertTriggers retrieves the trigger history of alerts.
+	ListAlertTriggers(context.Context, *ListAlertTriggersRequest) (*ListAlertTriggersResponse, error)
+	// WatchAlerts streams trigger events, resuming from a cursor after reconnects.
+	WatchAlerts(*WatchAlertsRequest, grpc.ServerStreamingServer[AlertEvent]) error
+	// BacktestAlert replays stored ticks or candles through a price alert to
+	// show how often it would have fired.
+	BacktestAlert(context.Context, *BacktestAlertRequest) (*BacktestAlertResponse, error)
 	mustEmbedUnimplementedAlertServiceServer()
 }
 
@@ -89,6 +145,15 @@ func (UnimplementedAlertServiceServer) CreateAlert(context.Context, *CreateAlert
 func (UnimplementedAlertServiceServer) GetAlerts(context.Context, *GetAlertsRequest) (*GetAlertsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAlerts not implemented")
 }
+func (UnimplementedAlertServiceServer) ListAlertTriggers(context.Context, *ListAlertTriggersRequest) (*ListAlertTriggersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAlertTriggers not implemented")
+}
+func (UnimplementedAlertServiceServer) WatchAlerts(*WatchAlertsRequest, grpc.ServerStreamingServer[AlertEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchAlerts not implemented")
+}
+func (UnimplementedAlertServiceServer) BacktestAlert(context.Context, *BacktestAlertRequest) (*BacktestAlertResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BacktestAlert not implemented")
+}
 func (UnimplementedAlertServiceServer) mustEmbedUnimplementedAlertServiceServer() {}
 func (UnimplementedAlertServiceServer) testEmbeddedByValue()                      {}
 
@@ -146,6 +211,53 @@ func _AlertService_GetAlerts_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AlertService_ListAlertTriggers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertTriggersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertServiceServer).ListAlertTriggers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlertService_ListAlertTriggers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertServiceServer).ListAlertTriggers(ctx, req.(*ListAlertTriggersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlertService_WatchAlerts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAlertsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AlertServiceServer).WatchAlerts(m, &grpc.GenericServerStream[WatchAlertsRequest, AlertEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AlertService_WatchAlertsServer = grpc.ServerStreamingServer[AlertEvent]
+
+func _AlertService_BacktestAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BacktestAlertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertServiceServer).BacktestAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlertService_BacktestAlert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertServiceServer).BacktestAlert(ctx, req.(*BacktestAlertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AlertService_ServiceDesc is the grpc.ServiceDesc for AlertService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,7 +273,21 @@ var AlertService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAlerts",
 			Handler:    _AlertService_GetAlerts_Handler,
 		},
+		{
+			MethodName: "ListAlertTriggers",
+			Handler:    _AlertService_ListAlertTriggers_Handler,
+		},
+		{
+			MethodName: "BacktestAlert",
+			Handler:    _AlertService_BacktestAlert_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAlerts",
+			Handler:       _AlertService_WatchAlerts_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/alert.proto",
 }
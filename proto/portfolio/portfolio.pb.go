@@ -0,0 +1,1394 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: proto/portfolio.proto
+
+package portfolio
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// How sold quantities are matched against open lots
+type CostMethod int32
+
+const (
+	CostMethod_COST_METHOD_UNSPECIFIED CostMethod = 0 // Defaults to FIFO
+	CostMethod_FIFO                    CostMethod = 1 // Oldest lots are sold first
+	CostMethod_LIFO                    CostMethod = 2 // Newest lots are sold first
+	CostMethod_AVERAGE                 CostMethod = 3 // Every share carries the average cost
+)
+
+// Enum value maps for CostMethod.
+var (
+	CostMethod_name = map[int32]string{
+		0: "COST_METHOD_UNSPECIFIED",
+		1: "FIFO",
+		2: "LIFO",
+		3: "AVERAGE",
+	}
+	CostMethod_value = map[string]int32{
+		"COST_METHOD_UNSPECIFIED": 0,
+		"FIFO":                    1,
+		"LIFO":                    2,
+		"AVERAGE":                 3,
+	}
+)
+
+func (x CostMethod) Enum() *CostMethod {
+	p := new(CostMethod)
+	*p = x
+	return p
+}
+
+func (x CostMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CostMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_portfolio_proto_enumTypes[0].Descriptor()
+}
+
+func (CostMethod) Type() protoreflect.EnumType {
+	return &file_proto_portfolio_proto_enumTypes[0]
+}
+
+func (x CostMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CostMethod.Descriptor instead.
+func (CostMethod) EnumDescriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{0}
+}
+
+type Side int32
+
+const (
+	Side_SIDE_UNSPECIFIED Side = 0
+	Side_BUY              Side = 1
+	Side_SELL             Side = 2
+)
+
+// Enum value maps for Side.
+var (
+	Side_name = map[int32]string{
+		0: "SIDE_UNSPECIFIED",
+		1: "BUY",
+		2: "SELL",
+	}
+	Side_value = map[string]int32{
+		"SIDE_UNSPECIFIED": 0,
+		"BUY":              1,
+		"SELL":             2,
+	}
+)
+
+func (x Side) Enum() *Side {
+	p := new(Side)
+	*p = x
+	return p
+}
+
+func (x Side) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Side) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_portfolio_proto_enumTypes[1].Descriptor()
+}
+
+func (Side) Type() protoreflect.EnumType {
+	return &file_proto_portfolio_proto_enumTypes[1]
+}
+
+func (x Side) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Side.Descriptor instead.
+func (Side) EnumDescriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{1}
+}
+
+// A set of positions owned by a user, built from its transactions
+type Portfolio struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CostMethod    CostMethod             `protobuf:"varint,4,opt,name=cost_method,json=costMethod,proto3,enum=portfolio.CostMethod" json:"cost_method,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Portfolio) Reset() {
+	*x = Portfolio{}
+	mi := &file_proto_portfolio_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Portfolio) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Portfolio) ProtoMessage() {}
+
+func (x *Portfolio) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Portfolio.ProtoReflect.Descriptor instead.
+func (*Portfolio) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Portfolio) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Portfolio) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Portfolio) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Portfolio) GetCostMethod() CostMethod {
+	if x != nil {
+		return x.CostMethod
+	}
+	return CostMethod_COST_METHOD_UNSPECIFIED
+}
+
+func (x *Portfolio) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// A buy or sell of a symbol
+type Transaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PortfolioId   int32                  `protobuf:"varint,2,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          Side                   `protobuf:"varint,4,opt,name=side,proto3,enum=portfolio.Side" json:"side,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         float64                `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`                            // Per unit
+	Fee           float64                `protobuf:"fixed64,7,opt,name=fee,proto3" json:"fee,omitempty"`                                // Total for the transaction
+	ExecutedAt    int64                  `protobuf:"varint,8,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"` // Unix milliseconds
+	CreatedAt     int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`    // Unix timestamp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_proto_portfolio_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Transaction) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Transaction) GetPortfolioId() int32 {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return 0
+}
+
+func (x *Transaction) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Transaction) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *Transaction) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Transaction) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Transaction) GetFee() float64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *Transaction) GetExecutedAt() int64 {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return 0
+}
+
+func (x *Transaction) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// An open lot of a position. Its price includes the buy fee.
+type Lot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quantity      float64                `protobuf:"fixed64,1,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	OpenedAt      int64                  `protobuf:"varint,3,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"` // Unix milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Lot) Reset() {
+	*x = Lot{}
+	mi := &file_proto_portfolio_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lot) ProtoMessage() {}
+
+func (x *Lot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lot.ProtoReflect.Descriptor instead.
+func (*Lot) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Lot) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Lot) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Lot) GetOpenedAt() int64 {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return 0
+}
+
+// The valuation of one symbol. Closed positions (quantity 0) are listed for
+// their realized P&L.
+type Position struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	AverageCost   float64                `protobuf:"fixed64,3,opt,name=average_cost,json=averageCost,proto3" json:"average_cost,omitempty"`
+	CostBasis     float64                `protobuf:"fixed64,4,opt,name=cost_basis,json=costBasis,proto3" json:"cost_basis,omitempty"`
+	LastPrice     float64                `protobuf:"fixed64,5,opt,name=last_price,json=lastPrice,proto3" json:"last_price,omitempty"` // 0 if no price is known
+	PriceTime     int64                  `protobuf:"varint,6,opt,name=price_time,json=priceTime,proto3" json:"price_time,omitempty"`  // Unix milliseconds of the last price
+	MarketValue   float64                `protobuf:"fixed64,7,opt,name=market_value,json=marketValue,proto3" json:"market_value,omitempty"`
+	UnrealizedPnl float64                `protobuf:"fixed64,8,opt,name=unrealized_pnl,json=unrealizedPnl,proto3" json:"unrealized_pnl,omitempty"`
+	RealizedPnl   float64                `protobuf:"fixed64,9,opt,name=realized_pnl,json=realizedPnl,proto3" json:"realized_pnl,omitempty"`
+	DayChange     float64                `protobuf:"fixed64,10,opt,name=day_change,json=dayChange,proto3" json:"day_change,omitempty"` // Since the session open
+	Weight        float64                `protobuf:"fixed64,11,opt,name=weight,proto3" json:"weight,omitempty"`                        // Share of the portfolio's market value, 0..1
+	Priced        bool                   `protobuf:"varint,12,opt,name=priced,proto3" json:"priced,omitempty"`                         // False while no price is known
+	Lots          []*Lot                 `protobuf:"bytes,13,rep,name=lots,proto3" json:"lots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	mi := &file_proto_portfolio_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Position) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Position) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Position) GetAverageCost() float64 {
+	if x != nil {
+		return x.AverageCost
+	}
+	return 0
+}
+
+func (x *Position) GetCostBasis() float64 {
+	if x != nil {
+		return x.CostBasis
+	}
+	return 0
+}
+
+func (x *Position) GetLastPrice() float64 {
+	if x != nil {
+		return x.LastPrice
+	}
+	return 0
+}
+
+func (x *Position) GetPriceTime() int64 {
+	if x != nil {
+		return x.PriceTime
+	}
+	return 0
+}
+
+func (x *Position) GetMarketValue() float64 {
+	if x != nil {
+		return x.MarketValue
+	}
+	return 0
+}
+
+func (x *Position) GetUnrealizedPnl() float64 {
+	if x != nil {
+		return x.UnrealizedPnl
+	}
+	return 0
+}
+
+func (x *Position) GetRealizedPnl() float64 {
+	if x != nil {
+		return x.RealizedPnl
+	}
+	return 0
+}
+
+func (x *Position) GetDayChange() float64 {
+	if x != nil {
+		return x.DayChange
+	}
+	return 0
+}
+
+func (x *Position) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Position) GetPriced() bool {
+	if x != nil {
+		return x.Priced
+	}
+	return false
+}
+
+func (x *Position) GetLots() []*Lot {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+// A point-in-time valuation of a portfolio. Totals cover priced positions.
+type Valuation struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId      int32                  `protobuf:"varint,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	CostMethod       CostMethod             `protobuf:"varint,2,opt,name=cost_method,json=costMethod,proto3,enum=portfolio.CostMethod" json:"cost_method,omitempty"`
+	Positions        []*Position            `protobuf:"bytes,3,rep,name=positions,proto3" json:"positions,omitempty"`
+	MarketValue      float64                `protobuf:"fixed64,4,opt,name=market_value,json=marketValue,proto3" json:"market_value,omitempty"`
+	CostBasis        float64                `protobuf:"fixed64,5,opt,name=cost_basis,json=costBasis,proto3" json:"cost_basis,omitempty"`
+	UnrealizedPnl    float64                `protobuf:"fixed64,6,opt,name=unrealized_pnl,json=unrealizedPnl,proto3" json:"unrealized_pnl,omitempty"`
+	RealizedPnl      float64                `protobuf:"fixed64,7,opt,name=realized_pnl,json=realizedPnl,proto3" json:"realized_pnl,omitempty"`
+	DayChange        float64                `protobuf:"fixed64,8,opt,name=day_change,json=dayChange,proto3" json:"day_change,omitempty"`
+	DayChangePercent float64                `protobuf:"fixed64,9,opt,name=day_change_percent,json=dayChangePercent,proto3" json:"day_change_percent,omitempty"` // Relative to the value at the session open
+	Unpriced         []string               `protobuf:"bytes,10,rep,name=unpriced,proto3" json:"unpriced,omitempty"`                                            // Open positions without a price
+	Timestamp        int64                  `protobuf:"varint,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                         // Unix milliseconds
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Valuation) Reset() {
+	*x = Valuation{}
+	mi := &file_proto_portfolio_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Valuation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Valuation) ProtoMessage() {}
+
+func (x *Valuation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Valuation.ProtoReflect.Descriptor instead.
+func (*Valuation) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Valuation) GetPortfolioId() int32 {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return 0
+}
+
+func (x *Valuation) GetCostMethod() CostMethod {
+	if x != nil {
+		return x.CostMethod
+	}
+	return CostMethod_COST_METHOD_UNSPECIFIED
+}
+
+func (x *Valuation) GetPositions() []*Position {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+func (x *Valuation) GetMarketValue() float64 {
+	if x != nil {
+		return x.MarketValue
+	}
+	return 0
+}
+
+func (x *Valuation) GetCostBasis() float64 {
+	if x != nil {
+		return x.CostBasis
+	}
+	return 0
+}
+
+func (x *Valuation) GetUnrealizedPnl() float64 {
+	if x != nil {
+		return x.UnrealizedPnl
+	}
+	return 0
+}
+
+func (x *Valuation) GetRealizedPnl() float64 {
+	if x != nil {
+		return x.RealizedPnl
+	}
+	return 0
+}
+
+func (x *Valuation) GetDayChange() float64 {
+	if x != nil {
+		return x.DayChange
+	}
+	return 0
+}
+
+func (x *Valuation) GetDayChangePercent() float64 {
+	if x != nil {
+		return x.DayChangePercent
+	}
+	return 0
+}
+
+func (x *Valuation) GetUnpriced() []string {
+	if x != nil {
+		return x.Unpriced
+	}
+	return nil
+}
+
+func (x *Valuation) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type CreatePortfolioRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CostMethod    CostMethod             `protobuf:"varint,3,opt,name=cost_method,json=costMethod,proto3,enum=portfolio.CostMethod" json:"cost_method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePortfolioRequest) Reset() {
+	*x = CreatePortfolioRequest{}
+	mi := &file_proto_portfolio_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePortfolioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePortfolioRequest) ProtoMessage() {}
+
+func (x *CreatePortfolioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePortfolioRequest.ProtoReflect.Descriptor instead.
+func (*CreatePortfolioRequest) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreatePortfolioRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreatePortfolioRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePortfolioRequest) GetCostMethod() CostMethod {
+	if x != nil {
+		return x.CostMethod
+	}
+	return CostMethod_COST_METHOD_UNSPECIFIED
+}
+
+type GetPortfolioRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPortfolioRequest) Reset() {
+	*x = GetPortfolioRequest{}
+	mi := &file_proto_portfolio_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPortfolioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPortfolioRequest) ProtoMessage() {}
+
+func (x *GetPortfolioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPortfolioRequest.ProtoReflect.Descriptor instead.
+func (*GetPortfolioRequest) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetPortfolioRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetPortfolioRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ListPortfoliosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 0 = all users
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPortfoliosRequest) Reset() {
+	*x = ListPortfoliosRequest{}
+	mi := &file_proto_portfolio_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPortfoliosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPortfoliosRequest) ProtoMessage() {}
+
+func (x *ListPortfoliosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPortfoliosRequest.ProtoReflect.Descriptor instead.
+func (*ListPortfoliosRequest) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListPortfoliosRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ListPortfoliosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Portfolios    []*Portfolio           `protobuf:"bytes,1,rep,name=portfolios,proto3" json:"portfolios,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPortfoliosResponse) Reset() {
+	*x = ListPortfoliosResponse{}
+	mi := &file_proto_portfolio_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPortfoliosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPortfoliosResponse) ProtoMessage() {}
+
+func (x *ListPortfoliosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPortfoliosResponse.ProtoReflect.Descriptor instead.
+func (*ListPortfoliosResponse) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListPortfoliosResponse) GetPortfolios() []*Portfolio {
+	if x != nil {
+		return x.Portfolios
+	}
+	return nil
+}
+
+type DeletePortfolioRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePortfolioRequest) Reset() {
+	*x = DeletePortfolioRequest{}
+	mi := &file_proto_portfolio_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePortfolioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePortfolioRequest) ProtoMessage() {}
+
+func (x *DeletePortfolioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePortfolioRequest.ProtoReflect.Descriptor instead.
+func (*DeletePortfolioRequest) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeletePortfolioRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeletePortfolioRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type DeletePortfolioResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePortfolioResponse) Reset() {
+	*x = DeletePortfolioResponse{}
+	mi := &file_proto_portfolio_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePortfolioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePortfolioResponse) ProtoMessage() {}
+
+func (x *DeletePortfolioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePortfolioResponse.ProtoReflect.Descriptor instead.
+func (*DeletePortfolioResponse) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{10}
+}
+
+type RecordTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId   int32                  `protobuf:"varint,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          Side                   `protobuf:"varint,4,opt,name=side,proto3,enum=portfolio.Side" json:"side,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         float64                `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
+	Fee           float64                `protobuf:"fixed64,7,opt,name=fee,proto3" json:"fee,omitempty"`
+	ExecutedAt    int64                  `protobuf:"varint,8,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"` // Unix milliseconds (0 = now)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordTransactionRequest) Reset() {
+	*x = RecordTransactionRequest{}
+	mi := &file_proto_portfolio_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordTransactionRequest) ProtoMessage() {}
+
+func (x *RecordTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordTransactionRequest.ProtoReflect.Descriptor instead.
+func (*RecordTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RecordTransactionRequest) GetPortfolioId() int32 {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return 0
+}
+
+func (x *RecordTransactionRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RecordTransactionRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *RecordTransactionRequest) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *RecordTransactionRequest) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *RecordTransactionRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *RecordTransactionRequest) GetFee() float64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *RecordTransactionRequest) GetExecutedAt() int64 {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return 0
+}
+
+type ListTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId   int32                  `protobuf:"varint,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"` // Optional filter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_proto_portfolio_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListTransactionsRequest) GetPortfolioId() int32 {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type ListTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_proto_portfolio_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type GetValuationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId   int32                  `protobuf:"varint,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetValuationRequest) Reset() {
+	*x = GetValuationRequest{}
+	mi := &file_proto_portfolio_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetValuationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetValuationRequest) ProtoMessage() {}
+
+func (x *GetValuationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_portfolio_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetValuationRequest.ProtoReflect.Descriptor instead.
+func (*GetValuationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_portfolio_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetValuationRequest) GetPortfolioId() int32 {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return 0
+}
+
+func (x *GetValuationRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+var File_proto_portfolio_proto protoreflect.FileDescriptor
+
+const file_proto_portfolio_proto_rawDesc = "" +
+	"\n" +
+	"\x15proto/portfolio.proto\x12\tportfolio\"\x9f\x01\n" +
+	"\tPortfolio\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x126\n" +
+	"\vcost_method\x18\x04 \x01(\x0e2\x15.portfolio.CostMethodR\n" +
+	"costMethod\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"\x81\x02\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12!\n" +
+	"\fportfolio_id\x18\x02 \x01(\x05R\vportfolioId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12#\n" +
+	"\x04side\x18\x04 \x01(\x0e2\x0f.portfolio.SideR\x04side\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x01R\bquantity\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\x01R\x05price\x12\x10\n" +
+	"\x03fee\x18\a \x01(\x01R\x03fee\x12\x1f\n" +
+	"\vexecuted_at\x18\b \x01(\x03R\n" +
+	"executedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\"T\n" +
+	"\x03Lot\x12\x1a\n" +
+	"\bquantity\x18\x01 \x01(\x01R\bquantity\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x1b\n" +
+	"\topened_at\x18\x03 \x01(\x03R\bopenedAt\"\x9e\x03\n" +
+	"\bPosition\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12!\n" +
+	"\faverage_cost\x18\x03 \x01(\x01R\vaverageCost\x12\x1d\n" +
+	"\n" +
+	"cost_basis\x18\x04 \x01(\x01R\tcostBasis\x12\x1d\n" +
+	"\n" +
+	"last_price\x18\x05 \x01(\x01R\tlastPrice\x12\x1d\n" +
+	"\n" +
+	"price_time\x18\x06 \x01(\x03R\tpriceTime\x12!\n" +
+	"\fmarket_value\x18\a \x01(\x01R\vmarketValue\x12%\n" +
+	"\x0eunrealized_pnl\x18\b \x01(\x01R\runrealizedPnl\x12!\n" +
+	"\frealized_pnl\x18\t \x01(\x01R\vrealizedPnl\x12\x1d\n" +
+	"\n" +
+	"day_change\x18\n" +
+	" \x01(\x01R\tdayChange\x12\x16\n" +
+	"\x06weight\x18\v \x01(\x01R\x06weight\x12\x16\n" +
+	"\x06priced\x18\f \x01(\bR\x06priced\x12\"\n" +
+	"\x04lots\x18\r \x03(\v2\x0e.portfolio.LotR\x04lots\"\xac\x03\n" +
+	"\tValuation\x12!\n" +
+	"\fportfolio_id\x18\x01 \x01(\x05R\vportfolioId\x126\n" +
+	"\vcost_method\x18\x02 \x01(\x0e2\x15.portfolio.CostMethodR\n" +
+	"costMethod\x121\n" +
+	"\tpositions\x18\x03 \x03(\v2\x13.portfolio.PositionR\tpositions\x12!\n" +
+	"\fmarket_value\x18\x04 \x01(\x01R\vmarketValue\x12\x1d\n" +
+	"\n" +
+	"cost_basis\x18\x05 \x01(\x01R\tcostBasis\x12%\n" +
+	"\x0eunrealized_pnl\x18\x06 \x01(\x01R\runrealizedPnl\x12!\n" +
+	"\frealized_pnl\x18\a \x01(\x01R\vrealizedPnl\x12\x1d\n" +
+	"\n" +
+	"day_change\x18\b \x01(\x01R\tdayChange\x12,\n" +
+	"\x12day_change_percent\x18\t \x01(\x01R\x10dayChangePercent\x12\x1a\n" +
+	"\bunpriced\x18\n" +
+	" \x03(\tR\bunpriced\x12\x1c\n" +
+	"\ttimestamp\x18\v \x01(\x03R\ttimestamp\"}\n" +
+	"\x16CreatePortfolioRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x126\n" +
+	"\vcost_method\x18\x03 \x01(\x0e2\x15.portfolio.CostMethodR\n" +
+	"costMethod\">\n" +
+	"\x13GetPortfolioRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"0\n" +
+	"\x15ListPortfoliosRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"N\n" +
+	"\x16ListPortfoliosResponse\x124\n" +
+	"\n" +
+	"portfolios\x18\x01 \x03(\v2\x14.portfolio.PortfolioR\n" +
+	"portfolios\"A\n" +
+	"\x16DeletePortfolioRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"\x19\n" +
+	"\x17DeletePortfolioResponse\"\xf8\x01\n" +
+	"\x18RecordTransactionRequest\x12!\n" +
+	"\fportfolio_id\x18\x01 \x01(\x05R\vportfolioId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12#\n" +
+	"\x04side\x18\x04 \x01(\x0e2\x0f.portfolio.SideR\x04side\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x01R\bquantity\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\x01R\x05price\x12\x10\n" +
+	"\x03fee\x18\a \x01(\x01R\x03fee\x12\x1f\n" +
+	"\vexecuted_at\x18\b \x01(\x03R\n" +
+	"executedAt\"m\n" +
+	"\x17ListTransactionsRequest\x12!\n" +
+	"\fportfolio_id\x18\x01 \x01(\x05R\vportfolioId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\"V\n" +
+	"\x18ListTransactionsResponse\x12:\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x16.portfolio.TransactionR\ftransactions\"Q\n" +
+	"\x13GetValuationRequest\x12!\n" +
+	"\fportfolio_id\x18\x01 \x01(\x05R\vportfolioId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId*J\n" +
+	"\n" +
+	"CostMethod\x12\x1b\n" +
+	"\x17COST_METHOD_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04FIFO\x10\x01\x12\b\n" +
+	"\x04LIFO\x10\x02\x12\v\n" +
+	"\aAVERAGE\x10\x03*/\n" +
+	"\x04Side\x12\x14\n" +
+	"\x10SIDE_UNSPECIFIED\x10\x00\x12\a\n" +
+	"\x03BUY\x10\x01\x12\b\n" +
+	"\x04SELL\x10\x022\x94\x05\n" +
+	"\x10PortfolioService\x12J\n" +
+	"\x0fCreatePortfolio\x12!.portfolio.CreatePortfolioRequest\x1a\x14.portfolio.Portfolio\x12D\n" +
+	"\fGetPortfolio\x12\x1e.portfolio.GetPortfolioRequest\x1a\x14.portfolio.Portfolio\x12U\n" +
+	"\x0eListPortfolios\x12 .portfolio.ListPortfoliosRequest\x1a!.portfolio.ListPortfoliosResponse\x12X\n" +
+	"\x0fDeletePortfolio\x12!.portfolio.DeletePortfolioRequest\x1a\".portfolio.DeletePortfolioResponse\x12P\n" +
+	"\x11RecordTransaction\x12#.portfolio.RecordTransactionRequest\x1a\x16.portfolio.Transaction\x12[\n" +
+	"\x10ListTransactions\x12\".portfolio.ListTransactionsRequest\x1a#.portfolio.ListTransactionsResponse\x12D\n" +
+	"\fGetValuation\x12\x1e.portfolio.GetValuationRequest\x1a\x14.portfolio.Valuation\x12H\n" +
+	"\x0eWatchPortfolio\x12\x1e.portfolio.GetValuationRequest\x1a\x14.portfolio.Valuation0\x01B.Z,github.com/tiongMax/gostocks/proto/portfoliob\x06proto3"
+
+var (
+	file_proto_portfolio_proto_rawDescOnce sync.Once
+	file_proto_portfolio_proto_rawDescData []byte
+)
+
+func file_proto_portfolio_proto_rawDescGZIP() []byte {
+	file_proto_portfolio_proto_rawDescOnce.Do(func() {
+		file_proto_portfolio_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_portfolio_proto_rawDesc), len(file_proto_portfolio_proto_rawDesc)))
+	})
+	return file_proto_portfolio_proto_rawDescData
+}
+
+var file_proto_portfolio_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_portfolio_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_proto_portfolio_proto_goTypes = []any{
+	(CostMethod)(0),                  // 0: portfolio.CostMethod
+	(Side)(0),                        // 1: portfolio.Side
+	(*Portfolio)(nil),                // 2: portfolio.Portfolio
+	(*Transaction)(nil),              // 3: portfolio.Transaction
+	(*Lot)(nil),                      // 4: portfolio.Lot
+	(*Position)(nil),                 // 5: portfolio.Position
+	(*Valuation)(nil),                // 6: portfolio.Valuation
+	(*CreatePortfolioRequest)(nil),   // 7: portfolio.CreatePortfolioRequest
+	(*GetPortfolioRequest)(nil),      // 8: portfolio.GetPortfolioRequest
+	(*ListPortfoliosRequest)(nil),    // 9: portfolio.ListPortfoliosRequest
+	(*ListPortfoliosResponse)(nil),   // 10: portfolio.ListPortfoliosResponse
+	(*DeletePortfolioRequest)(nil),   // 11: portfolio.DeletePortfolioRequest
+	(*DeletePortfolioResponse)(nil),  // 12: portfolio.DeletePortfolioResponse
+	(*RecordTransactionRequest)(nil), // 13: portfolio.RecordTransactionRequest
+	(*ListTransactionsRequest)(nil),  // 14: portfolio.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil), // 15: portfolio.ListTransactionsResponse
+	(*GetValuationRequest)(nil),      // 16: portfolio.GetValuationRequest
+}
+var file_proto_portfolio_proto_depIdxs = []int32{
+	0,  // 0: portfolio.Portfolio.cost_method:type_name -> portfolio.CostMethod
+	1,  // 1: portfolio.Transaction.side:type_name -> portfolio.Side
+	4,  // 2: portfolio.Position.lots:type_name -> portfolio.Lot
+	0,  // 3: portfolio.Valuation.cost_method:type_name -> portfolio.CostMethod
+	5,  // 4: portfolio.Valuation.positions:type_name -> portfolio.Position
+	0,  // 5: portfolio.CreatePortfolioRequest.cost_method:type_name -> portfolio.CostMethod
+	2,  // 6: portfolio.ListPortfoliosResponse.portfolios:type_name -> portfolio.Portfolio
+	1,  // 7: portfolio.RecordTransactionRequest.side:type_name -> portfolio.Side
+	3,  // 8: portfolio.ListTransactionsResponse.transactions:type_name -> portfolio.Transaction
+	7,  // 9: portfolio.PortfolioService.CreatePortfolio:input_type -> portfolio.CreatePortfolioRequest
+	8,  // 10: portfolio.PortfolioService.GetPortfolio:input_type -> portfolio.GetPortfolioRequest
+	9,  // 11: portfolio.PortfolioService.ListPortfolios:input_type -> portfolio.ListPortfoliosRequest
+	11, // 12: portfolio.PortfolioService.DeletePortfolio:input_type -> portfolio.DeletePortfolioRequest
+	13, // 13: portfolio.PortfolioService.RecordTransaction:input_type -> portfolio.RecordTransactionRequest
+	14, // 14: portfolio.PortfolioService.ListTransactions:input_type -> portfolio.ListTransactionsRequest
+	16, // 15: portfolio.PortfolioService.GetValuation:input_type -> portfolio.GetValuationRequest
+	16, // 16: portfolio.PortfolioService.WatchPortfolio:input_type -> portfolio.GetValuationRequest
+	2,  // 17: portfolio.PortfolioService.CreatePortfolio:output_type -> portfolio.Portfolio
+	2,  // 18: portfolio.PortfolioService.GetPortfolio:output_type -> portfolio.Portfolio
+	10, // 19: portfolio.PortfolioService.ListPortfolios:output_type -> portfolio.ListPortfoliosResponse
+	12, // 20: portfolio.PortfolioService.DeletePortfolio:output_type -> portfolio.DeletePortfolioResponse
+	3,  // 21: portfolio.PortfolioService.RecordTransaction:output_type -> portfolio.Transaction
+	15, // 22: portfolio.PortfolioService.ListTransactions:output_type -> portfolio.ListTransactionsResponse
+	6,  // 23: portfolio.PortfolioService.GetValuation:output_type -> portfolio.Valuation
+	6,  // 24: portfolio.PortfolioService.WatchPortfolio:output_type -> portfolio.Valuation
+	17, // [17:25] is the sub-list for method output_type
+	9,  // [9:17] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_proto_portfolio_proto_init() }
+func file_proto_portfolio_proto_init() {
+	if File_proto_portfolio_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_portfolio_proto_rawDesc), len(file_proto_portfolio_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_portfolio_proto_goTypes,
+		DependencyIndexes: file_proto_portfolio_proto_depIdxs,
+		EnumInfos:         file_proto_portfolio_proto_enumTypes,
+		MessageInfos:      file_proto_portfolio_proto_msgTypes,
+	}.Build()
+	File_proto_portfolio_proto = out.File
+	file_proto_portfolio_proto_goTypes = nil
+	file_proto_portfolio_proto_depIdxs = nil
+}
@@ -27,6 +27,7 @@ type StockTick struct {
 	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`        // e.g., "AAPL", "GOOGL"
 	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`        // e.g., 150.25
 	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp in milliseconds
+	Volume        float64                `protobuf:"fixed64,4,opt,name=volume,proto3" json:"volume,omitempty"`      // Traded volume, e.g., 100
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,15 +83,23 @@ func (x *StockTick) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *StockTick) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
 var File_proto_stock_proto protoreflect.FileDescriptor
 
 const file_proto_stock_proto_rawDesc = "" +
 	"\n" +
-	"\x11proto/stock.proto\x12\x05stock\"W\n" +
+	"\x11proto/stock.proto\x12\x05stock\"o\n" +
 	"\tStockTick\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x14\n" +
 	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x1c\n" +
-	"\ttimestamp\x18\x03 \x01(\x03R\ttimestampB*Z(github.com/tiongMax/gostocks/proto/stockb\x06proto3"
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12\x16\n" +
+	"\x06volume\x18\x04 \x01(\x01R\x06volumeB*Z(github.com/tiongMax/gostocks/proto/stockb\x06proto3"
 
 var (
 	file_proto_stock_proto_rawDescOnce sync.Once
@@ -0,0 +1,369 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.2
+// source: proto/watchlist.proto
+
+package watchlist
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	WatchlistService_CreateWatchlist_FullMethodName = "/watchlist.WatchlistService/CreateWatchlist"
+	WatchlistService_GetWatchlist_FullMethodName    = "/watchlist.WatchlistService/GetWatchlist"
+	WatchlistService_ListWatchlists_FullMethodName  = "/watchlist.WatchlistService/ListWatchlists"
+	WatchlistService_RenameWatchlist_FullMethodName = "/watchlist.WatchlistService/RenameWatchlist"
+	WatchlistService_DeleteWatchlist_FullMethodName = "/watchlist.WatchlistService/DeleteWatchlist"
+	WatchlistService_AddSymbols_FullMethodName      = "/watchlist.WatchlistService/AddSymbols"
+	WatchlistService_RemoveSymbols_FullMethodName   = "/watchlist.WatchlistService/RemoveSymbols"
+)
+
+// WatchlistServiceClient is the client API for WatchlistService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Watchlist management. Symbols added to any watchlist are registered for
+// ingestion, so their prices start streaming.
+type WatchlistServiceClient interface {
+	// CreateWatchlist creates a watchlist, optionally with initial symbols.
+	CreateWatchlist(ctx context.Context, in *CreateWatchlistRequest, opts ...grpc.CallOption) (*Watchlist, error)
+	// GetWatchlist retrieves a single watchlist.
+	GetWatchlist(ctx context.Context, in *GetWatchlistRequest, opts ...grpc.CallOption) (*Watchlist, error)
+	// ListWatchlists retrieves a user's watchlists, ordered by name.
+	ListWatchlists(ctx context.Context, in *ListWatchlistsRequest, opts ...grpc.CallOption) (*ListWatchlistsResponse, error)
+	// RenameWatchlist changes a watchlist's name.
+	RenameWatchlist(ctx context.Context, in *RenameWatchlistRequest, opts ...grpc.CallOption) (*Watchlist, error)
+	// DeleteWatchlist deletes a watchlist and its symbols.
+	DeleteWatchlist(ctx context.Context, in *DeleteWatchlistRequest, opts ...grpc.CallOption) (*DeleteWatchlistResponse, error)
+	// AddSymbols adds symbols to a watchlist; symbols already present are kept.
+	AddSymbols(ctx context.Context, in *UpdateSymbolsRequest, opts ...grpc.CallOption) (*Watchlist, error)
+	// RemoveSymbols removes symbols from a watchlist; absent symbols are ignored.
+	RemoveSymbols(ctx context.Context, in *UpdateSymbolsRequest, opts ...grpc.CallOption) (*Watchlist, error)
+}
+
+type watchlistServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWatchlistServiceClient(cc grpc.ClientConnInterface) WatchlistServiceClient {
+	return &watchlistServiceClient{cc}
+}
+
+func (c *watchlistServiceClient) CreateWatchlist(ctx context.Context, in *CreateWatchlistRequest, opts ...grpc.CallOption) (*Watchlist, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Watchlist)
+	err := c.cc.Invoke(ctx, WatchlistService_CreateWatchlist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchlistServiceClient) GetWatchlist(ctx context.Context, in *GetWatchlistRequest, opts ...grpc.CallOption) (*Watchlist, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Watchlist)
+	err := c.cc.Invoke(ctx, WatchlistService_GetWatchlist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchlistServiceClient) ListWatchlists(ctx context.Context, in *ListWatchlistsRequest, opts ...grpc.CallOption) (*ListWatchlistsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWatchlistsResponse)
+	err := c.cc.Invoke(ctx, WatchlistService_ListWatchlists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchlistServiceClient) RenameWatchlist(ctx context.Context, in *RenameWatchlistRequest, opts ...grpc.CallOption) (*Watchlist, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Watchlist)
+	err := c.cc.Invoke(ctx, WatchlistService_RenameWatchlist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchlistServiceClient) DeleteWatchlist(ctx context.Context, in *DeleteWatchlistRequest, opts ...grpc.CallOption) (*DeleteWatchlistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWatchlistResponse)
+	err := c.cc.Invoke(ctx, WatchlistService_DeleteWatchlist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchlistServiceClient) AddSymbols(ctx context.Context, in *UpdateSymbolsRequest, opts ...grpc.CallOption) (*Watchlist, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Watchlist)
+	err := c.cc.Invoke(ctx, WatchlistService_AddSymbols_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchlistServiceClient) RemoveSymbols(ctx context.Context, in *UpdateSymbolsRequest, opts ...grpc.CallOption) (*Watchlist, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Watchlist)
+	err := c.cc.Invoke(ctx, WatchlistService_RemoveSymbols_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WatchlistServiceServer is the server API for WatchlistService service.
+// All implementations must embed UnimplementedWatchlistServiceServer
+// for forward compatibility.
+//
+// Watchlist management. Symbols added to any watchlist are registered for
+// ingestion, so their prices start streaming.
+type WatchlistServiceServer interface {
+	// CreateWatchlist creates a watchlist, optionally with initial symbols.
+	CreateWatchlist(context.Context, *CreateWatchlistRequest) (*Watchlist, error)
+	// GetWatchlist retrieves a single watchlist.
+	GetWatchlist(context.Context, *GetWatchlistRequest) (*Watchlist, error)
+	// ListWatchlists retrieves a user's watchlists, ordered by name.
+	ListWatchlists(context.Context, *ListWatchlistsRequest) (*ListWatchlistsResponse, error)
+	// RenameWatchlist changes a watchlist's name.
+	RenameWatchlist(context.Context, *RenameWatchlistRequest) (*Watchlist, error)
+	// DeleteWatchlist deletes a watchlist and its symbols.
+	DeleteWatchlist(context.Context, *DeleteWatchlistRequest) (*DeleteWatchlistResponse, error)
+	// AddSymbols adds symbols to a watchlist; symbols already present are kept.
+	AddSymbols(context.Context, *UpdateSymbolsRequest) (*Watchlist, error)
+	// RemoveSymbols removes symbols from a watchlist; absent symbols are ignored.
+	RemoveSymbols(context.Context, *UpdateSymbolsRequest) (*Watchlist, error)
+	mustEmbedUnimplementedWatchlistServiceServer()
+}
+
+// UnimplementedWatchlistServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWatchlistServiceServer struct{}
+
+func (UnimplementedWatchlistServiceServer) CreateWatchlist(context.Context, *CreateWatchlistRequest) (*Watchlist, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateWatchlist not implemented")
+}
+func (UnimplementedWatchlistServiceServer) GetWatchlist(context.Context, *GetWatchlistRequest) (*Watchlist, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWatchlist not implemented")
+}
+func (UnimplementedWatchlistServiceServer) ListWatchlists(context.Context, *ListWatchlistsRequest) (*ListWatchlistsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWatchlists not implemented")
+}
+func (UnimplementedWatchlistServiceServer) RenameWatchlist(context.Context, *RenameWatchlistRequest) (*Watchlist, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenameWatchlist not implemented")
+}
+func (UnimplementedWatchlistServiceServer) DeleteWatchlist(context.Context, *DeleteWatchlistRequest) (*DeleteWatchlistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteWatchlist not implemented")
+}
+func (UnimplementedWatchlistServiceServer) AddSymbols(context.Context, *UpdateSymbolsRequest) (*Watchlist, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddSymbols not implemented")
+}
+func (UnimplementedWatchlistServiceServer) RemoveSymbols(context.Context, *UpdateSymbolsRequest) (*Watchlist, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveSymbols not implemented")
+}
+func (UnimplementedWatchlistServiceServer) mustEmbedUnimplementedWatchlistServiceServer() {}
+func (UnimplementedWatchlistServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeWatchlistServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WatchlistServiceServer will
+// result in compilation errors.
+type UnsafeWatchlistServiceServer interface {
+	mustEmbedUnimplementedWatchlistServiceServer()
+}
+
+func RegisterWatchlistServiceServer(s grpc.ServiceRegistrar, srv WatchlistServiceServer) {
+	// If the following call panics, it indicates UnimplementedWatchlistServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WatchlistService_ServiceDesc, srv)
+}
+
+func _WatchlistService_CreateWatchlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWatchlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServiceServer).CreateWatchlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchlistService_CreateWatchlist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServiceServer).CreateWatchlist(ctx, req.(*CreateWatchlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchlistService_GetWatchlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWatchlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServiceServer).GetWatchlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchlistService_GetWatchlist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServiceServer).GetWatchlist(ctx, req.(*GetWatchlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchlistService_ListWatchlists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWatchlistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServiceServer).ListWatchlists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchlistService_ListWatchlists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServiceServer).ListWatchlists(ctx, req.(*ListWatchlistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchlistService_RenameWatchlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameWatchlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServiceServer).RenameWatchlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchlistService_RenameWatchlist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServiceServer).RenameWatchlist(ctx, req.(*RenameWatchlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchlistService_DeleteWatchlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWatchlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServiceServer).DeleteWatchlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchlistService_DeleteWatchlist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServiceServer).DeleteWatchlist(ctx, req.(*DeleteWatchlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchlistService_AddSymbols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSymbolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServiceServer).AddSymbols(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchlistService_AddSymbols_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServiceServer).AddSymbols(ctx, req.(*UpdateSymbolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WatchlistService_RemoveSymbols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSymbolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServiceServer).RemoveSymbols(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WatchlistService_RemoveSymbols_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServiceServer).RemoveSymbols(ctx, req.(*UpdateSymbolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WatchlistService_ServiceDesc is the grpc.ServiceDesc for WatchlistService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WatchlistService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "watchlist.WatchlistService",
+	HandlerType: (*WatchlistServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWatchlist",
+			Handler:    _WatchlistService_CreateWatchlist_Handler,
+		},
+		{
+			MethodName: "GetWatchlist",
+			Handler:    _WatchlistService_GetWatchlist_Handler,
+		},
+		{
+			MethodName: "ListWatchlists",
+			Handler:    _WatchlistService_ListWatchlists_Handler,
+		},
+		{
+			MethodName: "RenameWatchlist",
+			Handler:    _WatchlistService_RenameWatchlist_Handler,
+		},
+		{
+			MethodName: "DeleteWatchlist",
+			Handler:    _WatchlistService_DeleteWatchlist_Handler,
+		},
+		{
+			MethodName: "AddSymbols",
+			Handler:    _WatchlistService_AddSymbols_Handler,
+		},
+		{
+			MethodName: "RemoveSymbols",
+			Handler:    _WatchlistService_RemoveSymbols_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/watchlist.proto",
+}